@@ -6,17 +6,39 @@ import (
 	"time"
 
 	"github.com/rogerwesterbo/familytree/internal/repositories/arangorepository"
+	"github.com/rogerwesterbo/familytree/internal/services/v1attributeservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1citationservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1eventservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1exportservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1familyservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1importservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1mediaservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1noteservice"
 	"github.com/rogerwesterbo/familytree/internal/services/v1personservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1placeservice"
 	"github.com/rogerwesterbo/familytree/internal/services/v1relationshipservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1sourceservice"
+	"github.com/rogerwesterbo/familytree/pkg/blobstore"
 	"github.com/rogerwesterbo/familytree/pkg/clients/arangodbclient"
 	"github.com/rogerwesterbo/familytree/pkg/consts"
 	"github.com/spf13/viper"
+	"github.com/vitistack/common/pkg/loggers/vlog"
 )
 
 var (
 	ArangoClient        *arangodbclient.Client
 	PersonService       *v1personservice.PersonService
 	RelationshipService *v1relationshipservice.RelationshipService
+	FamilyService       *v1familyservice.FamilyService
+	EventService        *v1eventservice.EventService
+	PlaceService        *v1placeservice.PlaceService
+	SourceService       *v1sourceservice.SourceService
+	CitationService     *v1citationservice.CitationService
+	MediaService        *v1mediaservice.MediaService
+	AttributeService    *v1attributeservice.AttributeService
+	NoteService         *v1noteservice.NoteService
+	ImportService       *v1importservice.ImportService
+	ExportService       *v1exportservice.ExportService
 )
 
 // Init initializes all clients, repositories, and services
@@ -57,12 +79,141 @@ func Init() error {
 		return fmt.Errorf("failed to get relationships collection: %w", err)
 	}
 
+	placesCollection, err := client.GetCollection(ctx, "places")
+	if err != nil {
+		return fmt.Errorf("failed to get places collection: %w", err)
+	}
+
+	eventsCollection, err := client.GetCollection(ctx, "events")
+	if err != nil {
+		return fmt.Errorf("failed to get events collection: %w", err)
+	}
+
+	participantsCollection, err := client.GetCollection(ctx, "participants")
+	if err != nil {
+		return fmt.Errorf("failed to get participants collection: %w", err)
+	}
+
+	sourcesCollection, err := client.GetCollection(ctx, "sources")
+	if err != nil {
+		return fmt.Errorf("failed to get sources collection: %w", err)
+	}
+
+	citationsCollection, err := client.GetCollection(ctx, "citations")
+	if err != nil {
+		return fmt.Errorf("failed to get citations collection: %w", err)
+	}
+
+	supportsCollection, err := client.GetCollection(ctx, "supports")
+	if err != nil {
+		return fmt.Errorf("failed to get supports collection: %w", err)
+	}
+
+	mediaCollection, err := client.GetCollection(ctx, "media")
+	if err != nil {
+		return fmt.Errorf("failed to get media collection: %w", err)
+	}
+
+	attachmentsCollection, err := client.GetCollection(ctx, "attachments")
+	if err != nil {
+		return fmt.Errorf("failed to get attachments collection: %w", err)
+	}
+
+	attributeTypesCollection, err := client.GetCollection(ctx, "attributeTypes")
+	if err != nil {
+		return fmt.Errorf("failed to get attributeTypes collection: %w", err)
+	}
+
+	notesCollection, err := client.GetCollection(ctx, "notes")
+	if err != nil {
+		return fmt.Errorf("failed to get notes collection: %w", err)
+	}
+
+	familyGraph, err := client.GetGraph(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get family graph: %w", err)
+	}
+
 	personRepo := arangorepository.NewPersonRepository(client.GetDatabase(), personsCollection)
-	relationshipRepo := arangorepository.NewRelationshipRepository(client.GetDatabase(), relationshipsCollection)
+	relationshipRepo := arangorepository.NewRelationshipRepository(client.GetDatabase(), relationshipsCollection, familyGraph)
+	placeRepo := arangorepository.NewPlaceRepository(client.GetDatabase(), placesCollection)
+	eventRepo := arangorepository.NewEventRepository(client.GetDatabase(), eventsCollection)
+	participantRepo := arangorepository.NewParticipantRepository(client.GetDatabase(), participantsCollection)
+	sourceRepo := arangorepository.NewSourceRepository(client.GetDatabase(), sourcesCollection)
+	citationRepo := arangorepository.NewCitationRepository(client.GetDatabase(), citationsCollection)
+	citationLinkRepo := arangorepository.NewCitationLinkRepository(client.GetDatabase(), supportsCollection)
+	mediaRepo := arangorepository.NewMediaRepository(client.GetDatabase(), mediaCollection)
+	mediaLinkRepo := arangorepository.NewMediaLinkRepository(client.GetDatabase(), attachmentsCollection)
+	attributeTypeRepo := arangorepository.NewAttributeTypeRepository(client.GetDatabase(), attributeTypesCollection)
+	noteRepo := arangorepository.NewNoteRepository(client.GetDatabase(), notesCollection)
+
+	// Initialize the blob store holding the content of media files
+	blobStore, err := blobstore.NewLocalBlobStore(viper.GetString(consts.MEDIA_STORAGE_PATH))
+	if err != nil {
+		return fmt.Errorf("failed to initialize media storage: %w", err)
+	}
+
+	// Migrate dates stored as timestamps into genealogical dates
+	migrated, err := personRepo.MigrateDates(ctx, "birthDate", "deathDate")
+	if err != nil {
+		return fmt.Errorf("failed to migrate person dates: %w", err)
+	}
+	if migrated > 0 {
+		vlog.Infof("Migrated dates of %d persons", migrated)
+	}
+
+	migrated, err = relationshipRepo.MigrateDates(ctx, "startDate", "endDate")
+	if err != nil {
+		return fmt.Errorf("failed to migrate relationship dates: %w", err)
+	}
+	if migrated > 0 {
+		vlog.Infof("Migrated dates of %d relationships", migrated)
+	}
+
+	// Give persons stored with a single name a list of names
+	migrated, err = personRepo.MigrateNames(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to migrate person names: %w", err)
+	}
+	if migrated > 0 {
+		vlog.Infof("Migrated names of %d persons", migrated)
+	}
+
+	// Qualify parent edges recorded as adoptions in their notes
+	migrated, err = relationshipRepo.MigrateAdoptions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to migrate adoptions: %w", err)
+	}
+	if migrated > 0 {
+		vlog.Infof("Migrated %d adoptive relationships", migrated)
+	}
 
 	// Initialize services
-	PersonService = v1personservice.NewPersonService(personRepo)
-	RelationshipService = v1relationshipservice.NewRelationshipService(relationshipRepo)
+	PlaceService = v1placeservice.NewPlaceService(placeRepo)
+	SourceService = v1sourceservice.NewSourceService(sourceRepo)
+	CitationService = v1citationservice.NewCitationService(citationRepo, citationLinkRepo, sourceRepo, personRepo, relationshipRepo, eventRepo)
+	AttributeService = v1attributeservice.NewAttributeService(attributeTypeRepo)
+	PersonService = v1personservice.NewPersonService(personRepo, relationshipRepo, PlaceService, CitationService, AttributeService,
+		viper.GetInt(consts.LIVING_PERSON_YEARS))
+	RelationshipService = v1relationshipservice.NewRelationshipService(relationshipRepo, personRepo)
+	FamilyService = v1familyservice.NewFamilyService(relationshipRepo, personRepo, RelationshipService)
+	EventService = v1eventservice.NewEventService(eventRepo, participantRepo, personRepo, PlaceService)
+	MediaService = v1mediaservice.NewMediaService(mediaRepo, mediaLinkRepo, blobStore, personRepo, eventRepo, sourceRepo)
+	NoteService = v1noteservice.NewNoteService(noteRepo, personRepo, relationshipRepo, eventRepo, participantRepo)
+	ImportService = v1importservice.NewImportService(PersonService, RelationshipService, EventService, PlaceService, SourceService,
+		CitationService, NoteService, MediaService, viper.GetInt64(consts.IMPORT_MAX_UPLOAD_SIZE),
+		viper.GetInt64(consts.MEDIA_MAX_UPLOAD_SIZE))
+	ExportService = v1exportservice.NewExportService(PersonService, RelationshipService, PlaceService, MediaService,
+		CitationService, EventService)
+
+	// Refer to the places of events stored with a place name by ID
+	migrated, err = eventRepo.MigratePlaces(ctx, v1placeservice.NewPlaceResolver(PlaceService, "").Resolve)
+	if err != nil {
+		return fmt.Errorf("failed to migrate event places: %w", err)
+	}
+	if migrated > 0 {
+		vlog.Infof("Migrated places of %d events", migrated)
+	}
 
 	return nil
 }
@@ -0,0 +1,260 @@
+package v1attributetypeshandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/internal/httpserver/helpers"
+	"github.com/rogerwesterbo/familytree/internal/httpserver/middleware"
+	"github.com/rogerwesterbo/familytree/internal/services/v1attributeservice"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// Handler handles HTTP requests for attribute type operations
+type Handler struct {
+	service *v1attributeservice.AttributeService
+}
+
+// NewHandler creates a new attribute type handler
+func NewHandler(service *v1attributeservice.AttributeService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// HandleAttributeTypes routes attribute type requests based on HTTP method.
+// Anyone can read attribute types; only admins can create, change or delete them.
+// @Summary Attribute type operations
+// @Description Handle attribute type CRUD operations
+// @Tags attribute-types
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/attribute-types [get]
+// @Router /v1/attribute-types [post]
+// @Router /v1/attribute-types/{id} [get]
+// @Router /v1/attribute-types/{id} [put]
+// @Router /v1/attribute-types/{id} [delete]
+func (h *Handler) HandleAttributeTypes(w http.ResponseWriter, r *http.Request) {
+	attributeTypeID := strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1/attribute-types"), "/")
+
+	if r.Method != http.MethodGet && !middleware.HasRole(r.Context(), middleware.RoleAdmin) {
+		helpers.SendError(w, http.StatusForbidden, "only admins can manage attribute types")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if attributeTypeID != "" {
+			h.GetAttributeType(w, r, attributeTypeID)
+			return
+		}
+		h.ListAttributeTypes(w, r)
+	case http.MethodPost:
+		h.CreateAttributeType(w, r)
+	case http.MethodPut:
+		if attributeTypeID == "" {
+			helpers.SendError(w, http.StatusBadRequest, "attribute type ID is required")
+			return
+		}
+		h.UpdateAttributeType(w, r, attributeTypeID)
+	case http.MethodDelete:
+		if attributeTypeID == "" {
+			helpers.SendError(w, http.StatusBadRequest, "attribute type ID is required")
+			return
+		}
+		h.DeleteAttributeType(w, r, attributeTypeID)
+	default:
+		helpers.SendError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// ListAttributeTypes returns all attribute types
+// @Summary List all attribute types
+// @Description Get the custom person attributes defined for this deployment
+// @Tags attribute-types
+// @Accept json
+// @Produce json
+// @Success 200 {object} interfaces.AttributeTypesListResponse
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/attribute-types [get]
+func (h *Handler) ListAttributeTypes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	attributeTypes, err := h.service.ListAttributeTypes(ctx)
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to list attribute types: %v", err))
+		return
+	}
+
+	response := interfaces.AttributeTypesListResponse{
+		AttributeTypes: attributeTypes,
+		Count:          len(attributeTypes),
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// GetAttributeType returns a specific attribute type by ID
+// @Summary Get an attribute type
+// @Description Get an attribute type by ID
+// @Tags attribute-types
+// @Accept json
+// @Produce json
+// @Param id path string true "Attribute type ID"
+// @Success 200 {object} interfaces.AttributeTypeResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/attribute-types/{id} [get]
+func (h *Handler) GetAttributeType(w http.ResponseWriter, r *http.Request, attributeTypeID string) {
+	ctx := r.Context()
+
+	attributeType, err := h.service.GetAttributeType(ctx, attributeTypeID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "attribute type not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get attribute type: %v", err))
+		return
+	}
+
+	response := interfaces.AttributeTypeResponse{
+		AttributeType: attributeType,
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// CreateAttributeType creates a new attribute type
+// @Summary Create an attribute type
+// @Description Define a custom person attribute with a string, date, number, enum or place value. Requires the familytree-admin role.
+// @Tags attribute-types
+// @Accept json
+// @Produce json
+// @Param attributeType body interfaces.AttributeTypeCreateRequest true "Attribute type data"
+// @Success 201 {object} interfaces.AttributeTypeResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/attribute-types [post]
+func (h *Handler) CreateAttributeType(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req interfaces.AttributeTypeCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.SendError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	attributeType, err := h.service.CreateAttributeType(ctx, &req)
+	if err != nil {
+		if errors.Is(err, v1attributeservice.ErrInvalidAttributeType) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to create attribute type: %v", err))
+		return
+	}
+
+	response := interfaces.AttributeTypeResponse{
+		AttributeType: attributeType,
+		Message:       "Attribute type created successfully",
+	}
+
+	helpers.SendJSON(w, http.StatusCreated, response)
+}
+
+// UpdateAttributeType updates an existing attribute type
+// @Summary Update an attribute type
+// @Description Update the label, enum values or description of an attribute type. Requires the familytree-admin role.
+// @Tags attribute-types
+// @Accept json
+// @Produce json
+// @Param id path string true "Attribute type ID"
+// @Param attributeType body interfaces.AttributeTypeUpdateRequest true "Attribute type data"
+// @Success 200 {object} interfaces.AttributeTypeResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/attribute-types/{id} [put]
+func (h *Handler) UpdateAttributeType(w http.ResponseWriter, r *http.Request, attributeTypeID string) {
+	ctx := r.Context()
+
+	var req interfaces.AttributeTypeUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.SendError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	attributeType, err := h.service.UpdateAttributeType(ctx, attributeTypeID, &req)
+	if err != nil {
+		if errors.Is(err, v1attributeservice.ErrInvalidAttributeType) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "attribute type not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to update attribute type: %v", err))
+		return
+	}
+
+	response := interfaces.AttributeTypeResponse{
+		AttributeType: attributeType,
+		Message:       "Attribute type updated successfully",
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// DeleteAttributeType deletes an attribute type
+// @Summary Delete an attribute type
+// @Description Delete an attribute type that no person has a value for. Requires the familytree-admin role.
+// @Tags attribute-types
+// @Accept json
+// @Produce json
+// @Param id path string true "Attribute type ID"
+// @Success 200 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/attribute-types/{id} [delete]
+func (h *Handler) DeleteAttributeType(w http.ResponseWriter, r *http.Request, attributeTypeID string) {
+	ctx := r.Context()
+
+	err := h.service.DeleteAttributeType(ctx, attributeTypeID)
+	if err != nil {
+		if errors.Is(err, v1attributeservice.ErrAttributeTypeInUse) {
+			helpers.SendError(w, http.StatusConflict, err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "attribute type not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to delete attribute type: %v", err))
+		return
+	}
+
+	helpers.SendJSON(w, http.StatusOK, map[string]string{
+		"message": "Attribute type deleted successfully",
+	})
+}
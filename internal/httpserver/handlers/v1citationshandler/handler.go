@@ -0,0 +1,301 @@
+package v1citationshandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/internal/httpserver/helpers"
+	"github.com/rogerwesterbo/familytree/internal/httpserver/middleware"
+	"github.com/rogerwesterbo/familytree/internal/services/v1citationservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1personservice"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// Handler handles HTTP requests for citation operations
+type Handler struct {
+	service       *v1citationservice.CitationService
+	personService *v1personservice.PersonService
+}
+
+// NewHandler creates a new citation handler
+func NewHandler(service *v1citationservice.CitationService, personService *v1personservice.PersonService) *Handler {
+	return &Handler{
+		service:       service,
+		personService: personService,
+	}
+}
+
+// HandleCitations routes citation requests based on HTTP method
+// @Summary Citation operations
+// @Description Handle citation CRUD operations
+// @Tags citations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/citations [get]
+// @Router /v1/citations [post]
+// @Router /v1/citations/{id} [get]
+// @Router /v1/citations/{id} [put]
+// @Router /v1/citations/{id} [delete]
+func (h *Handler) HandleCitations(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		if strings.HasPrefix(r.URL.Path, "/v1/citations/") {
+			citationID := strings.TrimPrefix(r.URL.Path, "/v1/citations/")
+			if citationID != "" {
+				h.GetCitation(w, r, citationID)
+				return
+			}
+		}
+		h.ListCitations(w, r)
+	case http.MethodPost:
+		h.CreateCitation(w, r)
+	case http.MethodPut:
+		citationID := strings.TrimPrefix(r.URL.Path, "/v1/citations/")
+		if citationID == "" {
+			helpers.SendError(w, http.StatusBadRequest, "citation ID is required")
+			return
+		}
+		h.UpdateCitation(w, r, citationID)
+	case http.MethodDelete:
+		citationID := strings.TrimPrefix(r.URL.Path, "/v1/citations/")
+		if citationID == "" {
+			helpers.SendError(w, http.StatusBadRequest, "citation ID is required")
+			return
+		}
+		h.DeleteCitation(w, r, citationID)
+	default:
+		helpers.SendError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// ListCitations returns all citations
+// @Summary List all citations
+// @Description Get a list of all citations with the persons, relationships and events they support
+// @Tags citations
+// @Accept json
+// @Produce json
+// @Success 200 {object} interfaces.CitationsListResponse
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/citations [get]
+func (h *Handler) ListCitations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	citations, err := h.service.ListCitations(ctx)
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to list citations: %v", err))
+		return
+	}
+
+	response := interfaces.CitationsListResponse{
+		Citations: citations,
+		Count:     len(citations),
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// GetCitation returns a specific citation by ID
+// @Summary Get a citation
+// @Description Get a citation by ID with the persons, relationships and events it supports
+// @Tags citations
+// @Accept json
+// @Produce json
+// @Param id path string true "Citation ID"
+// @Success 200 {object} interfaces.CitationResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/citations/{id} [get]
+func (h *Handler) GetCitation(w http.ResponseWriter, r *http.Request, citationID string) {
+	ctx := r.Context()
+
+	citation, err := h.service.GetCitation(ctx, citationID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "citation not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get citation: %v", err))
+		return
+	}
+
+	response := interfaces.CitationResponse{
+		Citation: citation,
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// CreateCitation creates a new citation
+// @Summary Create a citation
+// @Description Create a new citation of a source and link it to the persons, relationships and events it supports
+// @Tags citations
+// @Accept json
+// @Produce json
+// @Param citation body interfaces.CitationCreateRequest true "Citation data"
+// @Success 201 {object} interfaces.CitationResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/citations [post]
+func (h *Handler) CreateCitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req interfaces.CitationCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.SendError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	citation, err := h.service.CreateCitation(ctx, &req)
+	if err != nil {
+		if errors.Is(err, v1citationservice.ErrInvalidCitation) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to create citation: %v", err))
+		return
+	}
+
+	response := interfaces.CitationResponse{
+		Citation: citation,
+		Message:  "Citation created successfully",
+	}
+
+	helpers.SendJSON(w, http.StatusCreated, response)
+}
+
+// UpdateCitation updates an existing citation
+// @Summary Update a citation
+// @Description Update an existing citation. Subjects, when given, replace the current subjects.
+// @Tags citations
+// @Accept json
+// @Produce json
+// @Param id path string true "Citation ID"
+// @Param citation body interfaces.CitationUpdateRequest true "Citation data"
+// @Success 200 {object} interfaces.CitationResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/citations/{id} [put]
+func (h *Handler) UpdateCitation(w http.ResponseWriter, r *http.Request, citationID string) {
+	ctx := r.Context()
+
+	var req interfaces.CitationUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.SendError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	citation, err := h.service.UpdateCitation(ctx, citationID, &req)
+	if err != nil {
+		if errors.Is(err, v1citationservice.ErrInvalidCitation) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "citation not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to update citation: %v", err))
+		return
+	}
+
+	response := interfaces.CitationResponse{
+		Citation: citation,
+		Message:  "Citation updated successfully",
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// DeleteCitation deletes a citation
+// @Summary Delete a citation
+// @Description Delete a citation and its links to the facts it supports
+// @Tags citations
+// @Accept json
+// @Produce json
+// @Param id path string true "Citation ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/citations/{id} [delete]
+func (h *Handler) DeleteCitation(w http.ResponseWriter, r *http.Request, citationID string) {
+	ctx := r.Context()
+
+	err := h.service.DeleteCitation(ctx, citationID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "citation not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to delete citation: %v", err))
+		return
+	}
+
+	helpers.SendJSON(w, http.StatusOK, map[string]string{
+		"message": "Citation deleted successfully",
+	})
+}
+
+// GetPersonCitations returns the citations supporting a person
+// @Summary Get the citations of a person
+// @Description Get the citations supporting a person, with the fact each supports and its source. The citations of a person the user may not see are left out.
+// @Tags citations
+// @Accept json
+// @Produce json
+// @Param id path string true "Person ID"
+// @Success 200 {object} interfaces.SubjectCitationsResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/persons/{id}/citations [get]
+func (h *Handler) GetPersonCitations(w http.ResponseWriter, r *http.Request, personID string) {
+	ctx := r.Context()
+
+	subjectID := personID
+	if !strings.Contains(subjectID, "/") {
+		subjectID = "persons/" + subjectID
+	}
+
+	citations, err := h.service.GetSubjectCitations(ctx, subjectID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "person not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get citations: %v", err))
+		return
+	}
+
+	restricted, err := h.personService.RestrictedPersons(ctx, middleware.AllowedPrivacy(ctx), []string{subjectID})
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply privacy: %v", err))
+		return
+	}
+	if restricted[subjectID] {
+		citations = []interfaces.CitationReference{}
+	}
+
+	response := interfaces.SubjectCitationsResponse{
+		SubjectID: subjectID,
+		Citations: citations,
+		Count:     len(citations),
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,340 @@
+package v1eventshandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/internal/httpserver/helpers"
+	"github.com/rogerwesterbo/familytree/internal/httpserver/middleware"
+	"github.com/rogerwesterbo/familytree/internal/services/v1eventservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1personservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1placeservice"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// Handler handles HTTP requests for event operations
+type Handler struct {
+	service       *v1eventservice.EventService
+	personService *v1personservice.PersonService
+}
+
+// NewHandler creates a new event handler
+func NewHandler(service *v1eventservice.EventService, personService *v1personservice.PersonService) *Handler {
+	return &Handler{
+		service:       service,
+		personService: personService,
+	}
+}
+
+// HandleEvents routes event requests based on HTTP method
+// @Summary Event operations
+// @Description Handle life event CRUD operations
+// @Tags events
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/events [get]
+// @Router /v1/events [post]
+// @Router /v1/events/{id} [get]
+// @Router /v1/events/{id} [put]
+// @Router /v1/events/{id} [delete]
+func (h *Handler) HandleEvents(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		if strings.HasPrefix(r.URL.Path, "/v1/events/") {
+			eventID := strings.TrimPrefix(r.URL.Path, "/v1/events/")
+			if eventID != "" {
+				h.GetEvent(w, r, eventID)
+				return
+			}
+		}
+		h.ListEvents(w, r)
+	case http.MethodPost:
+		h.CreateEvent(w, r)
+	case http.MethodPut:
+		eventID := strings.TrimPrefix(r.URL.Path, "/v1/events/")
+		if eventID == "" {
+			helpers.SendError(w, http.StatusBadRequest, "event ID is required")
+			return
+		}
+		h.UpdateEvent(w, r, eventID)
+	case http.MethodDelete:
+		eventID := strings.TrimPrefix(r.URL.Path, "/v1/events/")
+		if eventID == "" {
+			helpers.SendError(w, http.StatusBadRequest, "event ID is required")
+			return
+		}
+		h.DeleteEvent(w, r, eventID)
+	default:
+		helpers.SendError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// ListEvents returns all events
+// @Summary List all events
+// @Description Get a list of all life events in the family tree with their participants. Events of persons the user may not see are left out.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Success 200 {object} interfaces.EventsListResponse
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/events [get]
+func (h *Handler) ListEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	events, err := h.service.ListEvents(ctx)
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to list events: %v", err))
+		return
+	}
+
+	events, err = h.visible(ctx, events)
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply privacy: %v", err))
+		return
+	}
+
+	response := interfaces.EventsListResponse{
+		Events: events,
+		Count:  len(events),
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// GetEvent returns a specific event by ID
+// @Summary Get an event
+// @Description Get a life event by ID with its participants. Events of persons the user may not see are not found.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Success 200 {object} interfaces.EventResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/events/{id} [get]
+func (h *Handler) GetEvent(w http.ResponseWriter, r *http.Request, eventID string) {
+	ctx := r.Context()
+
+	event, err := h.service.GetEvent(ctx, eventID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get event: %v", err))
+		return
+	}
+
+	// An event the user may not see is not found, so its existence is not given away
+	visible, err := h.visible(ctx, []interfaces.Event{*event})
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply privacy: %v", err))
+		return
+	}
+	if len(visible) == 0 {
+		helpers.SendError(w, http.StatusNotFound, "event not found")
+		return
+	}
+
+	response := interfaces.EventResponse{
+		Event: event,
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// CreateEvent creates a new event
+// @Summary Create an event
+// @Description Create a new life event and link its participants
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param event body interfaces.EventCreateRequest true "Event data"
+// @Success 201 {object} interfaces.EventResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/events [post]
+func (h *Handler) CreateEvent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req interfaces.EventCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.SendError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	event, err := h.service.CreateEvent(ctx, &req)
+	if err != nil {
+		if errors.Is(err, v1eventservice.ErrInvalidEvent) || errors.Is(err, v1placeservice.ErrInvalidPlace) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to create event: %v", err))
+		return
+	}
+
+	response := interfaces.EventResponse{
+		Event:   event,
+		Message: "Event created successfully",
+	}
+
+	helpers.SendJSON(w, http.StatusCreated, response)
+}
+
+// UpdateEvent updates an existing event
+// @Summary Update an event
+// @Description Update an existing life event. Participants, when given, replace the current participants.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param event body interfaces.EventUpdateRequest true "Event data"
+// @Success 200 {object} interfaces.EventResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/events/{id} [put]
+func (h *Handler) UpdateEvent(w http.ResponseWriter, r *http.Request, eventID string) {
+	ctx := r.Context()
+
+	var req interfaces.EventUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.SendError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	event, err := h.service.UpdateEvent(ctx, eventID, &req)
+	if err != nil {
+		if errors.Is(err, v1eventservice.ErrInvalidEvent) || errors.Is(err, v1placeservice.ErrInvalidPlace) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to update event: %v", err))
+		return
+	}
+
+	response := interfaces.EventResponse{
+		Event:   event,
+		Message: "Event updated successfully",
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// DeleteEvent deletes an event
+// @Summary Delete an event
+// @Description Delete a life event and its participant links
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/events/{id} [delete]
+func (h *Handler) DeleteEvent(w http.ResponseWriter, r *http.Request, eventID string) {
+	ctx := r.Context()
+
+	err := h.service.DeleteEvent(ctx, eventID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to delete event: %v", err))
+		return
+	}
+
+	helpers.SendJSON(w, http.StatusOK, map[string]string{
+		"message": "Event deleted successfully",
+	})
+}
+
+// GetPersonEvents returns the events of a person
+// @Summary Get the events of a person
+// @Description Get the life events a person takes part in, in any role, ordered by date. Events of persons the user may not see are left out.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param id path string true "Person ID"
+// @Success 200 {object} interfaces.PersonEventsResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/persons/{id}/events [get]
+func (h *Handler) GetPersonEvents(w http.ResponseWriter, r *http.Request, personID string) {
+	ctx := r.Context()
+
+	events, err := h.service.GetPersonEvents(ctx, personID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "person not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get events: %v", err))
+		return
+	}
+
+	events, err = h.visible(ctx, events)
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply privacy: %v", err))
+		return
+	}
+
+	response := interfaces.PersonEventsResponse{
+		PersonID: personID,
+		Events:   events,
+		Count:    len(events),
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// visible returns the events without participants the user may not see. The events of private persons
+// tell as much about them as their dates and places do.
+func (h *Handler) visible(ctx context.Context, events []interfaces.Event) ([]interfaces.Event, error) {
+	var personIDs []string
+	for _, event := range events {
+		for _, participant := range event.Participants {
+			personIDs = append(personIDs, participant.PersonID)
+		}
+	}
+
+	restricted, err := h.personService.RestrictedPersons(ctx, middleware.AllowedPrivacy(ctx), personIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]interfaces.Event, 0, len(events))
+	for _, event := range events {
+		if !slices.ContainsFunc(event.Participants, func(participant interfaces.EventParticipant) bool {
+			return restricted[participant.PersonID]
+		}) {
+			visible = append(visible, event)
+		}
+	}
+
+	return visible, nil
+}
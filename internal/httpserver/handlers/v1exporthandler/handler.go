@@ -0,0 +1,184 @@
+package v1exporthandler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/internal/httpserver/helpers"
+	"github.com/rogerwesterbo/familytree/internal/httpserver/middleware"
+	"github.com/rogerwesterbo/familytree/internal/services/v1exportservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1personservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1relationshipservice"
+	"github.com/rogerwesterbo/familytree/pkg/gedcomx"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+	"github.com/vitistack/common/pkg/loggers/vlog"
+)
+
+// contentTypes holds the content type of every export format
+var contentTypes = map[string]string{
+	interfaces.ExportFormatJSON:    "application/json",
+	interfaces.ExportFormatGedcom:  "text/plain; charset=utf-8",
+	interfaces.ExportFormatGedcom7: "text/vnd.familysearch.gedcom",
+	interfaces.ExportFormatGedzip:  "application/zip",
+	interfaces.ExportFormatGedcomX: gedcomx.MediaType,
+}
+
+// fileExtensions holds the file name extension of every export format
+var fileExtensions = map[string]string{
+	interfaces.ExportFormatJSON:    ".json",
+	interfaces.ExportFormatGedcom:  ".ged",
+	interfaces.ExportFormatGedcom7: ".ged",
+	interfaces.ExportFormatGedzip:  ".gdz",
+	interfaces.ExportFormatGedcomX: ".json",
+}
+
+// Handler handles HTTP requests for exporting the family tree
+type Handler struct {
+	service *v1exportservice.ExportService
+}
+
+// NewHandler creates a new export handler
+func NewHandler(service *v1exportservice.ExportService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// HandleExport routes export requests to the whole tree or to a person
+func (h *Handler) HandleExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		helpers.SendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	if personID, found := strings.CutPrefix(r.URL.Path, "/v1/export/person/"); found {
+		if personID == "" {
+			helpers.SendError(w, http.StatusBadRequest, "person ID is required")
+			return
+		}
+		h.ExportPerson(w, r, personID)
+		return
+	}
+	h.ExportAll(w, r)
+}
+
+// ExportAll exports the whole family tree
+// @Summary Export the family tree
+// @Description Export every person with the spouse and parent relationships between them and the places they were born and died in. GEDCOM exports are GEDCOM 5.5.1 files in UTF-8 with an INDI record for every person and a FAM record for every couple or single parent, and gedcom7 exports the same as GEDCOM 7.0. GEDZIP exports are zip archives of a GEDCOM 7.0 file and the media files attached to the persons. GEDCOM X exports (gedcomx) are JSON documents that also hold the events of the persons, the sources cited for them and the places. Persons the user may not see are redacted, or left out with restricted=hide.
+// @Tags export
+// @Produce json
+// @Produce plain
+// @Produce application/zip
+// @Produce application/x-gedcomx-v1+json
+// @Param format query string true "Format of the export" Enums(json, gedcom, gedcom7, gedzip, gedcomx)
+// @Param privacy query string false "Most restricted privacy level to export, capped at what the user may see" Enums(public, family, private)
+// @Param restricted query string false "Whether persons above the privacy level are redacted or hidden" Enums(redact, hide) default(redact)
+// @Success 200 {object} interfaces.ExportData
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/export [get]
+func (h *Handler) ExportAll(w http.ResponseWriter, r *http.Request) {
+	opts, err := exportOptions(r)
+	if err != nil {
+		helpers.SendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.export(w, r, opts, "familytree")
+}
+
+// ExportPerson exports a person with their ancestors, descendants or both
+// @Summary Export a person and their relatives
+// @Description Export a person with their ancestors, their descendants and the spouses of the descendants, or both, up to depth generations, in the same formats as the whole tree.
+// @Tags export
+// @Produce json
+// @Produce plain
+// @Produce application/zip
+// @Produce application/x-gedcomx-v1+json
+// @Param id path string true "Person ID"
+// @Param format query string true "Format of the export" Enums(json, gedcom, gedcom7, gedzip, gedcomx)
+// @Param scope query string false "Relatives to export with the person" Enums(ancestors, descendants, both) default(both)
+// @Param depth query int false "Maximum number of generations" default(10)
+// @Param numbering query string false "Descendant numbering system to label the descendants with, written as REFN in GEDCOM files and as descendantNumbers in JSON" Enums(daboville, henry)
+// @Param privacy query string false "Most restricted privacy level to export, capped at what the user may see" Enums(public, family, private)
+// @Param restricted query string false "Whether persons above the privacy level are redacted or hidden" Enums(redact, hide) default(redact)
+// @Success 200 {object} interfaces.ExportData
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/export/person/{id} [get]
+func (h *Handler) ExportPerson(w http.ResponseWriter, r *http.Request, personID string) {
+	opts, err := exportOptions(r)
+	if err != nil {
+		helpers.SendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	opts.PersonID = personID
+	opts.Scope = r.URL.Query().Get("scope")
+	if opts.Scope == "" {
+		opts.Scope = interfaces.ExportScopeBoth
+	}
+	opts.Depth, err = helpers.QueryInt(r, "depth", v1relationshipservice.DefaultTraversalDepth)
+	if err != nil {
+		helpers.SendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	opts.Numbering = r.URL.Query().Get("numbering")
+
+	h.export(w, r, opts, "person-"+strings.TrimPrefix(personID, "persons/"))
+}
+
+// export writes an export as a file named name. The export is built in memory first, so failures
+// are still sent as errors.
+func (h *Handler) export(w http.ResponseWriter, r *http.Request, opts interfaces.ExportOptions, name string) {
+	var buf bytes.Buffer
+	if err := h.service.Export(r.Context(), &buf, opts); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			helpers.SendError(w, http.StatusNotFound, "person not found")
+		case errors.Is(err, v1exportservice.ErrInvalidExport), errors.Is(err, v1personservice.ErrInvalidPrivacy):
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+		default:
+			helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to export: %v", err))
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", contentTypes[opts.Format])
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name + fileExtensions[opts.Format]}))
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := buf.WriteTo(w); err != nil {
+		vlog.Errorf("Failed to send export: %v", err)
+	}
+}
+
+// exportOptions reads the format and privacy options shared by all exports
+func exportOptions(r *http.Request) (interfaces.ExportOptions, error) {
+	access, hide, err := middleware.PrivacyView(r)
+	if err != nil {
+		return interfaces.ExportOptions{}, err
+	}
+
+	_, username, email := middleware.GetUserFromContext(r.Context())
+	submitter := username
+	if submitter == "" {
+		submitter = email
+	}
+
+	return interfaces.ExportOptions{
+		Format:         r.URL.Query().Get("format"),
+		Privacy:        access,
+		HideRestricted: hide,
+		Submitter:      submitter,
+	}, nil
+}
@@ -0,0 +1,213 @@
+package v1familieshandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/internal/httpserver/helpers"
+	"github.com/rogerwesterbo/familytree/internal/httpserver/middleware"
+	"github.com/rogerwesterbo/familytree/internal/services/v1familyservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1personservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1relationshipservice"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// Handler handles HTTP requests for family operations
+type Handler struct {
+	service       *v1familyservice.FamilyService
+	personService *v1personservice.PersonService
+}
+
+// NewHandler creates a new family handler
+func NewHandler(service *v1familyservice.FamilyService, personService *v1personservice.PersonService) *Handler {
+	return &Handler{
+		service:       service,
+		personService: personService,
+	}
+}
+
+// HandleFamilies routes family requests based on HTTP method
+// @Summary Family operations
+// @Description List the derived family units or create a family
+// @Tags families
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/families [get]
+// @Router /v1/families [post]
+func (h *Handler) HandleFamilies(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.ListFamilies(w, r)
+	case http.MethodPost:
+		h.CreateFamily(w, r)
+	default:
+		helpers.SendError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// ListFamilies returns all families
+// @Summary List all families
+// @Description Derive every family unit in the tree from the spouse and parent edges. Persons the user may not see are redacted.
+// @Tags families
+// @Accept json
+// @Produce json
+// @Success 200 {object} interfaces.FamiliesListResponse
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/families [get]
+func (h *Handler) ListFamilies(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	families, err := h.service.ListFamilies(ctx)
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to list families: %v", err))
+		return
+	}
+
+	if err := h.redact(ctx, families); err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply privacy: %v", err))
+		return
+	}
+
+	response := interfaces.FamiliesListResponse{
+		Families: families,
+		Count:    len(families),
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// CreateFamily creates a family
+// @Summary Create a family
+// @Description Create the spouse edge between the partners and the parent edges to every child in one request. A family needs married partners or children, and the marriage dates and notes need married to be true.
+// @Tags families
+// @Accept json
+// @Produce json
+// @Param family body interfaces.FamilyCreateRequest true "Family data"
+// @Success 201 {object} interfaces.FamilyResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/families [post]
+func (h *Handler) CreateFamily(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req interfaces.FamilyCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.SendError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	family, err := h.service.CreateFamily(ctx, &req)
+	if err != nil {
+		if errors.Is(err, v1relationshipservice.ErrConflictingRelationship) {
+			helpers.SendError(w, http.StatusConflict, err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "person not found")
+			return
+		}
+		if errors.Is(err, v1familyservice.ErrInvalidFamily) || errors.Is(err, v1relationshipservice.ErrInvalidRelationship) ||
+			errors.Is(err, v1relationshipservice.ErrInvalidQualifier) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to create family: %v", err))
+		return
+	}
+
+	response := interfaces.FamilyResponse{
+		Family:  family,
+		Message: "Family created successfully",
+	}
+
+	helpers.SendJSON(w, http.StatusCreated, response)
+}
+
+// GetPersonFamilies returns the families of a person
+// @Summary Get the families of a person
+// @Description Get the families a person is a partner in and the families they are a child in. Persons the user may not see are redacted.
+// @Tags families
+// @Accept json
+// @Produce json
+// @Param id path string true "Person ID"
+// @Success 200 {object} interfaces.PersonFamiliesResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/persons/{id}/families [get]
+func (h *Handler) GetPersonFamilies(w http.ResponseWriter, r *http.Request, personID string) {
+	ctx := r.Context()
+
+	asPartner, asChild, err := h.service.GetPersonFamilies(ctx, personID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "person not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get families: %v", err))
+		return
+	}
+
+	for _, families := range [][]interfaces.Family{asPartner, asChild} {
+		if err := h.redact(ctx, families); err != nil {
+			helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply privacy: %v", err))
+			return
+		}
+	}
+
+	response := interfaces.PersonFamiliesResponse{
+		PersonID:  personID,
+		AsPartner: asPartner,
+		AsChild:   asChild,
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// redact redacts the partners and children of the families the user may not see, as GetPerson does.
+// The union of a redacted partner only tells who it joins.
+func (h *Handler) redact(ctx context.Context, families []interfaces.Family) error {
+	var persons []*interfaces.Person
+	for i := range families {
+		for j := range families[i].Partners {
+			persons = append(persons, &families[i].Partners[j])
+		}
+		for j := range families[i].Children {
+			persons = append(persons, &families[i].Children[j])
+		}
+	}
+	if err := h.personService.RedactPersons(ctx, middleware.AllowedPrivacy(ctx), persons...); err != nil {
+		return err
+	}
+
+	for i, family := range families {
+		if family.Union != nil && slices.ContainsFunc(family.Partners, func(partner interfaces.Person) bool {
+			return partner.Redacted
+		}) {
+			families[i].Union = &interfaces.Relationship{
+				Key:          family.Union.Key,
+				ID:           family.Union.ID,
+				Rev:          family.Union.Rev,
+				From:         family.Union.From,
+				To:           family.Union.To,
+				RelationType: family.Union.RelationType,
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package v1importhandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/rogerwesterbo/familytree/internal/httpserver/helpers"
+	"github.com/rogerwesterbo/familytree/internal/httpserver/middleware"
+	"github.com/rogerwesterbo/familytree/internal/services/v1importservice"
+	"github.com/rogerwesterbo/familytree/pkg/consts"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+	"github.com/spf13/viper"
+)
+
+// errInvalidOptions is returned when the options of an import request are invalid
+var errInvalidOptions = errors.New("invalid import options")
+
+// maxFormMemory is how much of a multipart upload is kept in memory before it is spooled to disk
+const maxFormMemory = 10 << 20
+
+// Handler handles HTTP requests for importing files from other family tree programs
+type Handler struct {
+	service       *v1importservice.ImportService
+	maxUploadSize int64
+}
+
+// NewHandler creates a new import handler
+func NewHandler(service *v1importservice.ImportService) *Handler {
+	return &Handler{
+		service:       service,
+		maxUploadSize: viper.GetInt64(consts.IMPORT_MAX_UPLOAD_SIZE),
+	}
+}
+
+// HandleImport imports a file
+// @Summary Import a file
+// @Description Import the persons, families, sources and notes of a file from another family tree program. The file is sent as the file field of a multipart form or as the request body. GEDCOM 5.5.1 files may be in ANSEL, UTF-8, UTF-16, ANSI or ASCII; GEDCOM 7.0 files (gedcom7) are UTF-8, GEDZIP archives (gedzip) also import the media files they bundle, and GEDCOM X documents (gedcomx) are read from their JSON form. CSV files (csv) are spreadsheets of persons with a header row, separated by commas, semicolons or tabs. Their columns are mapped to the fields id, firstName, lastName, gender, birthDate, birthPlace, deathDate, deathPlace, email, phone, privacy and notes by mapping, or by a header naming the field. A second spreadsheet, the relationships field of the form, may join the persons with the columns from, to, relationType, qualifier, startDate, endDate and notes, where from and to are ids of person rows. With dryRun=true every row is validated and nothing is written: places are looked up without being created, and relationships are not checked against each other. Records that fail validation are left out; the report lists what was created, the tags that were not imported, the rows that were left out and warnings.
+// @Tags import
+// @Accept multipart/form-data
+// @Accept application/octet-stream
+// @Produce json
+// @Param format query string true "Format of the file" Enums(gedcom, gedcom7, gedzip, gedcomx, csv)
+// @Param file formData file false "The file to import"
+// @Param relationships formData file false "Spreadsheet of the relationships between the persons of a CSV import"
+// @Param mapping query string false "JSON object mapping the column headers of a CSV import to fields, such as {\"Fornavn\": \"firstName\"}"
+// @Param dryRun query bool false "Only validate the rows of a CSV import and report the errors, without checking the relationships against each other" default(false)
+// @Success 200 {object} interfaces.ImportResponse
+// @Success 201 {object} interfaces.ImportResponse
+// @Failure 400 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/import [post]
+func (h *Handler) HandleImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		helpers.SendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	ctx := r.Context()
+
+	file, cleanup, status, err := h.upload(w, r)
+	if err != nil {
+		helpers.SendError(w, status, err.Error())
+		return
+	}
+	defer cleanup()
+
+	userID, username, email := middleware.GetUserFromContext(ctx)
+	author := interfaces.NoteAuthor{
+		UserID:   userID,
+		Username: username,
+		Email:    email,
+	}
+
+	var report *interfaces.ImportReport
+	if format := r.URL.Query().Get("format"); format == interfaces.ImportFormatCSV {
+		report, err = h.importCSV(r, file, author)
+	} else {
+		report, err = h.service.Import(ctx, format, file, author)
+	}
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesErr):
+			helpers.SendError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("files may be at most %d bytes", h.maxUploadSize))
+		case errors.Is(err, v1importservice.ErrInvalidImport), errors.Is(err, errInvalidOptions):
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+		default:
+			helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to import file: %v", err))
+		}
+		return
+	}
+
+	if report.DryRun {
+		helpers.SendJSON(w, http.StatusOK, interfaces.ImportResponse{
+			Report:  report,
+			Message: "File validated, nothing was imported",
+		})
+		return
+	}
+
+	response := interfaces.ImportResponse{
+		Report:  report,
+		Message: "File imported successfully",
+	}
+
+	helpers.SendJSON(w, http.StatusCreated, response)
+}
+
+// importCSV imports a spreadsheet of persons with the relationships spreadsheet of the form, if any,
+// read with the mapping and dry run options of the request
+func (h *Handler) importCSV(r *http.Request, persons io.Reader, author interfaces.NoteAuthor) (*interfaces.ImportReport, error) {
+	var opts interfaces.CSVImportOptions
+	if mapping := r.FormValue("mapping"); mapping != "" {
+		if err := json.Unmarshal([]byte(mapping), &opts.Mapping); err != nil {
+			return nil, fmt.Errorf("%w: mapping must be a JSON object of column headers to fields", errInvalidOptions)
+		}
+	}
+	dryRun, err := helpers.QueryBool(r, "dryRun", false)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidOptions, err)
+	}
+	opts.DryRun = dryRun
+
+	var relationships io.Reader
+	if r.MultipartForm != nil && len(r.MultipartForm.File["relationships"]) > 0 {
+		header := r.MultipartForm.File["relationships"][0]
+		if header.Size > h.maxUploadSize {
+			return nil, &http.MaxBytesError{Limit: h.maxUploadSize}
+		}
+		file, err := header.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open relationships: %w", err)
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+		relationships = file
+	}
+
+	return h.service.ImportCSV(r.Context(), persons, relationships, opts, author)
+}
+
+// upload returns the uploaded file, from the file field of a multipart form or the request body, with a
+// function releasing it. On failure it returns the status to respond with.
+func (h *Handler) upload(w http.ResponseWriter, r *http.Request) (io.Reader, func(), int, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return http.MaxBytesReader(w, r.Body, h.maxUploadSize), func() {}, 0, nil
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxUploadSize+maxFormMemory)
+	if err := r.ParseMultipartForm(maxFormMemory); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, nil, http.StatusRequestEntityTooLarge, fmt.Errorf("files may be at most %d bytes", h.maxUploadSize)
+		}
+		return nil, nil, http.StatusBadRequest, fmt.Errorf("invalid multipart form: %v", err)
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		_ = r.MultipartForm.RemoveAll()
+		return nil, nil, http.StatusBadRequest, fmt.Errorf("file is required")
+	}
+	cleanup := func() {
+		_ = file.Close()
+		_ = r.MultipartForm.RemoveAll()
+	}
+	if header.Size > h.maxUploadSize {
+		cleanup()
+		return nil, nil, http.StatusRequestEntityTooLarge, fmt.Errorf("files may be at most %d bytes", h.maxUploadSize)
+	}
+
+	return file, cleanup, 0, nil
+}
@@ -0,0 +1,480 @@
+package v1mediahandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/internal/httpserver/helpers"
+	"github.com/rogerwesterbo/familytree/internal/httpserver/middleware"
+	"github.com/rogerwesterbo/familytree/internal/services/v1mediaservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1personservice"
+	"github.com/rogerwesterbo/familytree/pkg/consts"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+	"github.com/spf13/viper"
+	"github.com/vitistack/common/pkg/loggers/vlog"
+)
+
+// maxFormMemory is how much of a multipart upload is kept in memory before it is spooled to disk
+const maxFormMemory = 10 << 20
+
+// Handler handles HTTP requests for media operations
+type Handler struct {
+	service       *v1mediaservice.MediaService
+	personService *v1personservice.PersonService
+	maxUploadSize int64
+}
+
+// NewHandler creates a new media handler
+func NewHandler(service *v1mediaservice.MediaService, personService *v1personservice.PersonService) *Handler {
+	return &Handler{
+		service:       service,
+		personService: personService,
+		maxUploadSize: viper.GetInt64(consts.MEDIA_MAX_UPLOAD_SIZE),
+	}
+}
+
+// HandleMedia routes media requests based on HTTP method
+// @Summary Media operations
+// @Description Handle media upload, download and metadata operations
+// @Tags media
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/media [get]
+// @Router /v1/media [post]
+// @Router /v1/media/{id} [get]
+// @Router /v1/media/{id}/content [get]
+// @Router /v1/media/{id} [put]
+// @Router /v1/media/{id} [delete]
+func (h *Handler) HandleMedia(w http.ResponseWriter, r *http.Request) {
+	mediaID := strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1/media"), "/")
+
+	switch r.Method {
+	case http.MethodGet:
+		if id, found := strings.CutSuffix(mediaID, "/content"); found {
+			h.DownloadMedia(w, r, id)
+			return
+		}
+		if mediaID != "" {
+			h.GetMedia(w, r, mediaID)
+			return
+		}
+		h.ListMedia(w, r)
+	case http.MethodPost:
+		h.UploadMedia(w, r)
+	case http.MethodPut:
+		if mediaID == "" {
+			helpers.SendError(w, http.StatusBadRequest, "media ID is required")
+			return
+		}
+		h.UpdateMedia(w, r, mediaID)
+	case http.MethodDelete:
+		if mediaID == "" {
+			helpers.SendError(w, http.StatusBadRequest, "media ID is required")
+			return
+		}
+		h.DeleteMedia(w, r, mediaID)
+	default:
+		helpers.SendError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// ListMedia returns all media, or the media attached to one person, event or source
+// @Summary List media
+// @Description Get the metadata of all photos, scanned documents and audio recordings, optionally only those attached to a subject.
+// @Description Media attached to persons the user may not see are left out.
+// @Tags media
+// @Accept json
+// @Produce json
+// @Param subjectId query string false "Only list media attached to this person, event or source" example(persons/123)
+// @Success 200 {object} interfaces.MediaListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/media [get]
+func (h *Handler) ListMedia(w http.ResponseWriter, r *http.Request) {
+	if subjectID := r.URL.Query().Get("subjectId"); subjectID != "" {
+		h.sendSubjectMedia(w, r, subjectID, "subject not found")
+		return
+	}
+
+	media, err := h.service.ListMedia(r.Context())
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to list media: %v", err))
+		return
+	}
+
+	media, err = h.visible(r.Context(), media)
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply privacy: %v", err))
+		return
+	}
+
+	response := interfaces.MediaListResponse{
+		Media: media,
+		Count: len(media),
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// GetMedia returns the metadata of a media file by ID
+// @Summary Get media metadata
+// @Description Get the metadata of a media file by ID, with the persons, events and sources it is attached to.
+// @Description Media attached to persons the user may not see are not found.
+// @Tags media
+// @Accept json
+// @Produce json
+// @Param id path string true "Media ID"
+// @Success 200 {object} interfaces.MediaResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/media/{id} [get]
+func (h *Handler) GetMedia(w http.ResponseWriter, r *http.Request, mediaID string) {
+	media, err := h.service.GetMedia(r.Context(), mediaID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "media not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get media: %v", err))
+		return
+	}
+
+	visible, err := h.visible(r.Context(), []interfaces.Media{*media})
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply privacy: %v", err))
+		return
+	}
+	if len(visible) == 0 {
+		helpers.SendError(w, http.StatusNotFound, "media not found")
+		return
+	}
+
+	response := interfaces.MediaResponse{
+		Media: media,
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// DownloadMedia streams the content of a media file
+// @Summary Download media content
+// @Description Download the photo, scanned document or audio recording stored for a media file. Files whose content does not confirm their type are sent as attachments.
+// @Description Media attached to persons the user may not see are not found.
+// @Tags media
+// @Produce application/octet-stream
+// @Param id path string true "Media ID"
+// @Success 200 {file} file
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/media/{id}/content [get]
+func (h *Handler) DownloadMedia(w http.ResponseWriter, r *http.Request, mediaID string) {
+	media, content, err := h.service.OpenMediaContent(r.Context(), mediaID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "media not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to open media: %v", err))
+		return
+	}
+	defer func() {
+		_ = content.Close()
+	}()
+
+	visible, err := h.visible(r.Context(), []interfaces.Media{*media})
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply privacy: %v", err))
+		return
+	}
+	if len(visible) == 0 {
+		helpers.SendError(w, http.StatusNotFound, "media not found")
+		return
+	}
+
+	// Only content that sniffs as the stored photo, document or audio type is shown in the browser
+	reader, confirmed := v1mediaservice.ConfirmMimeType(content, media.MimeType)
+	disposition := "attachment"
+	if confirmed {
+		disposition = "inline"
+	}
+	params := map[string]string{}
+	if media.FileName != "" {
+		params["filename"] = media.FileName
+	}
+
+	w.Header().Set("Content-Type", media.MimeType)
+	w.Header().Set("Content-Length", strconv.FormatInt(media.Size, 10))
+	w.Header().Set("ETag", `"`+media.Checksum+`"`)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, params))
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, reader); err != nil {
+		vlog.Errorf("Failed to send content of media %s: %v", mediaID, err)
+	}
+}
+
+// UploadMedia uploads a media file
+// @Summary Upload media
+// @Description Upload a photo, scanned document (image or PDF) or audio recording, optionally attached to persons, events and sources
+// @Tags media
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "The file to upload"
+// @Param caption formData string false "Caption"
+// @Param date formData string false "Date the photo was taken or the document written" example(ABT 1857)
+// @Param subjects formData []string false "IDs of the persons, events and sources the file is attached to" collectionFormat(multi)
+// @Success 201 {object} interfaces.MediaResponse
+// @Failure 400 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/media [post]
+func (h *Handler) UploadMedia(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxUploadSize+maxFormMemory)
+	if err := r.ParseMultipartForm(maxFormMemory); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			helpers.SendError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("files may be at most %d bytes", h.maxUploadSize))
+			return
+		}
+		helpers.SendError(w, http.StatusBadRequest, fmt.Sprintf("invalid multipart form: %v", err))
+		return
+	}
+	defer func() {
+		_ = r.MultipartForm.RemoveAll()
+	}()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		helpers.SendError(w, http.StatusBadRequest, "file is required")
+		return
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	if header.Size > h.maxUploadSize {
+		helpers.SendError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("files may be at most %d bytes", h.maxUploadSize))
+		return
+	}
+
+	date, err := interfaces.ParseGenealogicalDate(r.FormValue("date"))
+	if err != nil {
+		helpers.SendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	req := interfaces.MediaUploadRequest{
+		FileName: header.Filename,
+		MimeType: header.Header.Get("Content-Type"),
+		Caption:  r.FormValue("caption"),
+		Date:     date,
+		Subjects: formList(r.MultipartForm.Value["subjects"]),
+	}
+
+	media, err := h.service.UploadMedia(ctx, &req, file)
+	if err != nil {
+		if errors.Is(err, v1mediaservice.ErrInvalidMedia) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to upload media: %v", err))
+		return
+	}
+
+	response := interfaces.MediaResponse{
+		Media:   media,
+		Message: "Media uploaded successfully",
+	}
+
+	helpers.SendJSON(w, http.StatusCreated, response)
+}
+
+// UpdateMedia updates the metadata of a media file
+// @Summary Update media metadata
+// @Description Update the file name, caption, date or subjects of a media file. Subjects, when given, replace the current subjects.
+// @Tags media
+// @Accept json
+// @Produce json
+// @Param id path string true "Media ID"
+// @Param media body interfaces.MediaUpdateRequest true "Media metadata"
+// @Success 200 {object} interfaces.MediaResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/media/{id} [put]
+func (h *Handler) UpdateMedia(w http.ResponseWriter, r *http.Request, mediaID string) {
+	ctx := r.Context()
+
+	var req interfaces.MediaUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.SendError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	media, err := h.service.UpdateMedia(ctx, mediaID, &req)
+	if err != nil {
+		if errors.Is(err, v1mediaservice.ErrInvalidMedia) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "media not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to update media: %v", err))
+		return
+	}
+
+	response := interfaces.MediaResponse{
+		Media:   media,
+		Message: "Media updated successfully",
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// DeleteMedia deletes a media file
+// @Summary Delete media
+// @Description Delete a media file with its content and its links to persons, events and sources
+// @Tags media
+// @Accept json
+// @Produce json
+// @Param id path string true "Media ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/media/{id} [delete]
+func (h *Handler) DeleteMedia(w http.ResponseWriter, r *http.Request, mediaID string) {
+	if err := h.service.DeleteMedia(r.Context(), mediaID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "media not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to delete media: %v", err))
+		return
+	}
+
+	helpers.SendJSON(w, http.StatusOK, map[string]string{
+		"message": "Media deleted successfully",
+	})
+}
+
+// GetPersonMedia returns the media attached to a person
+// @Summary Get the media of a person
+// @Description Get the metadata of the photos, scanned documents and audio recordings attached to a person.
+// @Description Media attached to persons the user may not see are left out.
+// @Tags media
+// @Accept json
+// @Produce json
+// @Param id path string true "Person ID"
+// @Success 200 {object} interfaces.MediaListResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/persons/{id}/media [get]
+func (h *Handler) GetPersonMedia(w http.ResponseWriter, r *http.Request, personID string) {
+	subjectID := personID
+	if !strings.Contains(subjectID, "/") {
+		subjectID = "persons/" + subjectID
+	}
+
+	h.sendSubjectMedia(w, r, subjectID, "person not found")
+}
+
+// sendSubjectMedia sends the media attached to a person, event or source
+func (h *Handler) sendSubjectMedia(w http.ResponseWriter, r *http.Request, subjectID, notFoundMessage string) {
+	media, err := h.service.GetSubjectMedia(r.Context(), subjectID)
+	if err != nil {
+		if errors.Is(err, v1mediaservice.ErrInvalidMedia) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, notFoundMessage)
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get media: %v", err))
+		return
+	}
+
+	media, err = h.visible(r.Context(), media)
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply privacy: %v", err))
+		return
+	}
+
+	response := interfaces.MediaListResponse{
+		Media: media,
+		Count: len(media),
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// visible returns the media not attached to any person the user may not see. A photo or document of a
+// private person tells as much about them as their dates and places do.
+func (h *Handler) visible(ctx context.Context, media []interfaces.Media) ([]interfaces.Media, error) {
+	var personIDs []string
+	for _, item := range media {
+		for _, subjectID := range item.Subjects {
+			if strings.HasPrefix(subjectID, "persons/") {
+				personIDs = append(personIDs, subjectID)
+			}
+		}
+	}
+
+	restricted, err := h.personService.RestrictedPersons(ctx, middleware.AllowedPrivacy(ctx), personIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]interfaces.Media, 0, len(media))
+	for _, item := range media {
+		if !slices.ContainsFunc(item.Subjects, func(subjectID string) bool {
+			return restricted[subjectID]
+		}) {
+			visible = append(visible, item)
+		}
+	}
+
+	return visible, nil
+}
+
+// formList splits form values that may be repeated or comma-separated into a single list
+func formList(values []string) []string {
+	var list []string
+	for _, value := range values {
+		for item := range strings.SplitSeq(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
@@ -0,0 +1,411 @@
+package v1noteshandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/internal/httpserver/helpers"
+	"github.com/rogerwesterbo/familytree/internal/httpserver/middleware"
+	"github.com/rogerwesterbo/familytree/internal/services/v1noteservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1personservice"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// Handler handles HTTP requests for note and research task operations
+type Handler struct {
+	service       *v1noteservice.NoteService
+	personService *v1personservice.PersonService
+}
+
+// NewHandler creates a new note handler
+func NewHandler(service *v1noteservice.NoteService, personService *v1personservice.PersonService) *Handler {
+	return &Handler{
+		service:       service,
+		personService: personService,
+	}
+}
+
+// HandleNotes routes note requests based on HTTP method
+// @Summary Note operations
+// @Description Handle note CRUD operations
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/notes [get]
+// @Router /v1/notes [post]
+// @Router /v1/notes/{id} [get]
+// @Router /v1/notes/{id} [put]
+// @Router /v1/notes/{id} [delete]
+func (h *Handler) HandleNotes(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		if strings.HasPrefix(r.URL.Path, "/v1/notes/") {
+			noteID := strings.TrimPrefix(r.URL.Path, "/v1/notes/")
+			if noteID != "" {
+				h.GetNote(w, r, noteID)
+				return
+			}
+		}
+		h.ListNotes(w, r)
+	case http.MethodPost:
+		h.CreateNote(w, r)
+	case http.MethodPut:
+		noteID := strings.TrimPrefix(r.URL.Path, "/v1/notes/")
+		if noteID == "" {
+			helpers.SendError(w, http.StatusBadRequest, "note ID is required")
+			return
+		}
+		h.UpdateNote(w, r, noteID)
+	case http.MethodDelete:
+		noteID := strings.TrimPrefix(r.URL.Path, "/v1/notes/")
+		if noteID == "" {
+			helpers.SendError(w, http.StatusBadRequest, "note ID is required")
+			return
+		}
+		h.DeleteNote(w, r, noteID)
+	default:
+		helpers.SendError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// ListNotes returns all notes, or the notes attached to one person, relationship or event
+// @Summary List notes
+// @Description Get all research notes and tasks, optionally only those attached to a subject. Notes about persons the user may not see are left out.
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param subjectId query string false "Only list notes attached to this person, relationship or event" example(persons/123)
+// @Success 200 {object} interfaces.NotesListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/notes [get]
+func (h *Handler) ListNotes(w http.ResponseWriter, r *http.Request) {
+	if subjectID := r.URL.Query().Get("subjectId"); subjectID != "" {
+		h.sendSubjectNotes(w, r, subjectID, "subject not found")
+		return
+	}
+
+	notes, err := h.service.ListNotes(r.Context())
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to list notes: %v", err))
+		return
+	}
+
+	notes, err = h.visible(r.Context(), notes)
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply privacy: %v", err))
+		return
+	}
+
+	response := interfaces.NotesListResponse{
+		Notes: notes,
+		Count: len(notes),
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// GetNote returns a specific note by ID
+// @Summary Get a note
+// @Description Get a research note or task by ID. Notes about persons the user may not see are not found.
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param id path string true "Note ID"
+// @Success 200 {object} interfaces.NoteResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/notes/{id} [get]
+func (h *Handler) GetNote(w http.ResponseWriter, r *http.Request, noteID string) {
+	ctx := r.Context()
+
+	note, err := h.service.GetNote(ctx, noteID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "note not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get note: %v", err))
+		return
+	}
+
+	// A note the user may not see is not found, so its existence is not given away
+	visible, err := h.visible(ctx, []interfaces.Note{*note})
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply privacy: %v", err))
+		return
+	}
+	if len(visible) == 0 {
+		helpers.SendError(w, http.StatusNotFound, "note not found")
+		return
+	}
+
+	response := interfaces.NoteResponse{
+		Note: note,
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// CreateNote creates a new note
+// @Summary Create a note
+// @Description Create a Markdown research note on a person, relationship or event, written by the authenticated user. Give it a status, assignee or due date to make it a research task.
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param note body interfaces.NoteCreateRequest true "Note data"
+// @Success 201 {object} interfaces.NoteResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/notes [post]
+func (h *Handler) CreateNote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req interfaces.NoteCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.SendError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	userID, username, email := middleware.GetUserFromContext(ctx)
+	author := interfaces.NoteAuthor{
+		UserID:   userID,
+		Username: username,
+		Email:    email,
+	}
+
+	note, err := h.service.CreateNote(ctx, &req, author)
+	if err != nil {
+		if errors.Is(err, v1noteservice.ErrInvalidNote) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to create note: %v", err))
+		return
+	}
+
+	response := interfaces.NoteResponse{
+		Note:    note,
+		Message: "Note created successfully",
+	}
+
+	helpers.SendJSON(w, http.StatusCreated, response)
+}
+
+// UpdateNote updates an existing note
+// @Summary Update a note
+// @Description Update an existing note or task. An empty status turns a task back into a plain note; an empty assignee or due date removes it.
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param id path string true "Note ID"
+// @Param note body interfaces.NoteUpdateRequest true "Note data"
+// @Success 200 {object} interfaces.NoteResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/notes/{id} [put]
+func (h *Handler) UpdateNote(w http.ResponseWriter, r *http.Request, noteID string) {
+	ctx := r.Context()
+
+	var req interfaces.NoteUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.SendError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	note, err := h.service.UpdateNote(ctx, noteID, &req)
+	if err != nil {
+		if errors.Is(err, v1noteservice.ErrInvalidNote) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "note not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to update note: %v", err))
+		return
+	}
+
+	response := interfaces.NoteResponse{
+		Note:    note,
+		Message: "Note updated successfully",
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// DeleteNote deletes a note
+// @Summary Delete a note
+// @Description Delete a research note or task
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param id path string true "Note ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/notes/{id} [delete]
+func (h *Handler) DeleteNote(w http.ResponseWriter, r *http.Request, noteID string) {
+	ctx := r.Context()
+
+	err := h.service.DeleteNote(ctx, noteID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "note not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to delete note: %v", err))
+		return
+	}
+
+	helpers.SendJSON(w, http.StatusOK, map[string]string{
+		"message": "Note deleted successfully",
+	})
+}
+
+// ListTasks returns the research tasks
+// @Summary List research tasks
+// @Description Get the research tasks ordered by due date, by default the open and in-progress ones. Notes about persons the user may not see are left out.
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param status query string false "Task statuses, comma-separated" default(open,in_progress)
+// @Param assignee query string false "Only list tasks assigned to this user"
+// @Param subjectId query string false "Only list tasks attached to this person, relationship or event" example(persons/123)
+// @Success 200 {object} interfaces.TasksListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/tasks [get]
+func (h *Handler) ListTasks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		helpers.SendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	statuses := helpers.QueryList(r, "status")
+	tasks, err := h.service.ListTasks(r.Context(), statuses, r.URL.Query().Get("assignee"), r.URL.Query().Get("subjectId"))
+	if err != nil {
+		if errors.Is(err, v1noteservice.ErrInvalidNote) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to list tasks: %v", err))
+		return
+	}
+
+	tasks, err = h.visible(r.Context(), tasks)
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply privacy: %v", err))
+		return
+	}
+
+	response := interfaces.TasksListResponse{
+		Tasks: tasks,
+		Count: len(tasks),
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// GetPersonNotes returns the notes attached to a person
+// @Summary Get the notes of a person
+// @Description Get the research notes and tasks attached to a person, oldest first. Notes about persons the user may not see are left out.
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param id path string true "Person ID"
+// @Success 200 {object} interfaces.NotesListResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/persons/{id}/notes [get]
+func (h *Handler) GetPersonNotes(w http.ResponseWriter, r *http.Request, personID string) {
+	subjectID := personID
+	if !strings.Contains(subjectID, "/") {
+		subjectID = "persons/" + subjectID
+	}
+
+	h.sendSubjectNotes(w, r, subjectID, "person not found")
+}
+
+// sendSubjectNotes sends the notes attached to a person, relationship or event
+func (h *Handler) sendSubjectNotes(w http.ResponseWriter, r *http.Request, subjectID, notFoundMessage string) {
+	notes, err := h.service.GetSubjectNotes(r.Context(), subjectID)
+	if err != nil {
+		if errors.Is(err, v1noteservice.ErrInvalidNote) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, notFoundMessage)
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get notes: %v", err))
+		return
+	}
+
+	notes, err = h.visible(r.Context(), notes)
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply privacy: %v", err))
+		return
+	}
+
+	response := interfaces.NotesListResponse{
+		Notes: notes,
+		Count: len(notes),
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// visible returns the notes the user may see. Notes about a person the user may not see, or about their
+// relationships and events, are left out.
+func (h *Handler) visible(ctx context.Context, notes []interfaces.Note) ([]interfaces.Note, error) {
+	subjectPersons, err := h.service.SubjectPersons(ctx, notes)
+	if err != nil {
+		return nil, err
+	}
+
+	var personIDs []string
+	for _, persons := range subjectPersons {
+		personIDs = append(personIDs, persons...)
+	}
+	restricted, err := h.personService.RestrictedPersons(ctx, middleware.AllowedPrivacy(ctx), personIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]interfaces.Note, 0, len(notes))
+	for _, note := range notes {
+		if !slices.ContainsFunc(subjectPersons[note.SubjectID], func(personID string) bool {
+			return restricted[personID]
+		}) {
+			visible = append(visible, note)
+		}
+	}
+
+	return visible, nil
+}
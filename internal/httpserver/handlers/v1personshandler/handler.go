@@ -1,13 +1,15 @@
 package v1personshandler
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/rogerwesterbo/familytree/internal/httpserver/helpers"
+	"github.com/rogerwesterbo/familytree/internal/httpserver/middleware"
 	"github.com/rogerwesterbo/familytree/internal/services/v1personservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1placeservice"
 	"github.com/rogerwesterbo/familytree/pkg/interfaces"
 )
 
@@ -70,11 +72,21 @@ func (h *Handler) HandlePersons(w http.ResponseWriter, r *http.Request) {
 
 // ListPersons returns all persons
 // @Summary List all persons
-// @Description Get a list of all persons in the family tree
+// @Description Get a list of all persons in the family tree, or of the persons with a name matching firstName and/or lastName.
+// @Description Every name of a person is searched, such as birth, married, patronymic and farm names.
+// @Description Persons can also be filtered on custom attributes with attr.{name}={value} parameters, e.g. attr.occupation=Farmer.
+// @Description Persons more restricted than the privacy level, or than what the user may see, are redacted or hidden. Living persons without a privacy override are family.
 // @Tags persons
 // @Accept json
 // @Produce json
+// @Param firstName query string false "Given name to search for"
+// @Param lastName query string false "Surname to search for"
+// @Param attr.{name} query string false "Value a custom attribute must have"
+// @Param includeCitations query bool false "Include the citations supporting every person" default(false)
+// @Param privacy query string false "Most restricted privacy level to show, e.g. public to leave out living persons" Enums(public, family, private)
+// @Param restricted query string false "Whether persons above the privacy level are redacted or left out" Enums(redact, hide) default(redact)
 // @Success 200 {object} interfaces.PersonsListResponse
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Security BearerAuth
 // @Security OAuth2Password
@@ -82,12 +94,52 @@ func (h *Handler) HandlePersons(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) ListPersons(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	persons, err := h.service.ListPersons(ctx)
+	includeCitations, err := helpers.QueryBool(r, "includeCitations", false)
+	if err != nil {
+		helpers.SendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	access, hide, err := middleware.PrivacyView(r)
+	if err != nil {
+		helpers.SendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var persons []interfaces.Person
+	firstName, lastName := r.URL.Query().Get("firstName"), r.URL.Query().Get("lastName")
+	attributes := attributeFilters(r)
+	if firstName != "" || lastName != "" || len(attributes) > 0 {
+		persons, err = h.service.SearchPersons(ctx, firstName, lastName, attributes)
+	} else {
+		persons, err = h.service.ListPersons(ctx)
+	}
 	if err != nil {
+		if errors.Is(err, v1personservice.ErrInvalidAttribute) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to list persons: %v", err))
 		return
 	}
 
+	persons, err = h.service.ApplyPrivacy(ctx, persons, access, hide)
+	if err != nil {
+		if errors.Is(err, v1personservice.ErrInvalidPrivacy) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply privacy: %v", err))
+		return
+	}
+
+	if includeCitations {
+		if err := h.service.IncludeCitations(ctx, persons); err != nil {
+			helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get citations: %v", err))
+			return
+		}
+	}
+
 	response := interfaces.PersonsListResponse{
 		Persons: persons,
 		Count:   len(persons),
@@ -98,12 +150,14 @@ func (h *Handler) ListPersons(w http.ResponseWriter, r *http.Request) {
 
 // GetPerson returns a specific person by ID
 // @Summary Get a person
-// @Description Get a person by ID
+// @Description Get a person by ID. A person the user may not see is redacted.
 // @Tags persons
 // @Accept json
 // @Produce json
 // @Param id path string true "Person ID"
+// @Param includeCitations query bool false "Include the citations supporting the person" default(false)
 // @Success 200 {object} interfaces.PersonResponse
+// @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Security BearerAuth
@@ -112,6 +166,12 @@ func (h *Handler) ListPersons(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) GetPerson(w http.ResponseWriter, r *http.Request, personID string) {
 	ctx := r.Context()
 
+	includeCitations, err := helpers.QueryBool(r, "includeCitations", false)
+	if err != nil {
+		helpers.SendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	person, err := h.service.GetPerson(ctx, personID)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
@@ -122,6 +182,20 @@ func (h *Handler) GetPerson(w http.ResponseWriter, r *http.Request, personID str
 		return
 	}
 
+	persons, err := h.service.ApplyPrivacy(ctx, []interfaces.Person{*person}, middleware.AllowedPrivacy(ctx), false)
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply privacy: %v", err))
+		return
+	}
+	person = &persons[0]
+
+	if includeCitations && !person.Redacted {
+		if err := h.service.IncludeCitations(ctx, persons); err != nil {
+			helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get citations: %v", err))
+			return
+		}
+	}
+
 	response := interfaces.PersonResponse{
 		Person: person,
 	}
@@ -131,7 +205,7 @@ func (h *Handler) GetPerson(w http.ResponseWriter, r *http.Request, personID str
 
 // CreatePerson creates a new person
 // @Summary Create a person
-// @Description Create a new person in the family tree
+// @Description Create a new person in the family tree. Custom facts go in attributes, keyed by attribute type name; unknown fields are rejected.
 // @Tags persons
 // @Accept json
 // @Produce json
@@ -146,7 +220,7 @@ func (h *Handler) CreatePerson(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var req interfaces.PersonCreateRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := helpers.DecodeJSON(r.Body, &req); err != nil {
 		helpers.SendError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
 		return
 	}
@@ -167,7 +241,8 @@ func (h *Handler) CreatePerson(w http.ResponseWriter, r *http.Request) {
 
 // UpdatePerson updates an existing person
 // @Summary Update a person
-// @Description Update an existing person in the family tree
+// @Description Update an existing person in the family tree. Attributes are merged, and an attribute set to null is removed; unknown fields are rejected.
+// @Description Persons the user may not see cannot be updated, and the updated person is redacted when the user may no longer see them.
 // @Tags persons
 // @Accept json
 // @Produce json
@@ -175,6 +250,7 @@ func (h *Handler) CreatePerson(w http.ResponseWriter, r *http.Request) {
 // @Param person body interfaces.PersonUpdateRequest true "Person data"
 // @Success 200 {object} interfaces.PersonResponse
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Security BearerAuth
@@ -184,23 +260,47 @@ func (h *Handler) UpdatePerson(w http.ResponseWriter, r *http.Request, personID
 	ctx := r.Context()
 
 	var req interfaces.PersonUpdateRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := helpers.DecodeJSON(r.Body, &req); err != nil {
 		helpers.SendError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
 		return
 	}
 
+	// Users may only change the persons they may see, or they could lift the privacy of anyone
+	restricted, err := h.service.RestrictedPersons(ctx, middleware.AllowedPrivacy(ctx), []string{v1personservice.PersonDocumentID(personID)})
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply privacy: %v", err))
+		return
+	}
+	if len(restricted) > 0 {
+		helpers.SendError(w, http.StatusForbidden, "you may not update this person")
+		return
+	}
+
 	person, err := h.service.UpdatePerson(ctx, personID, &req)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			helpers.SendError(w, http.StatusNotFound, "person not found")
 			return
 		}
+		if strings.Contains(err.Error(), "must not be before") || errors.Is(err, v1placeservice.ErrInvalidPlace) ||
+			errors.Is(err, v1personservice.ErrInvalidName) ||
+			errors.Is(err, v1personservice.ErrInvalidAttribute) ||
+			errors.Is(err, v1personservice.ErrInvalidPrivacy) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to update person: %v", err))
 		return
 	}
 
+	persons, err := h.service.ApplyPrivacy(ctx, []interfaces.Person{*person}, middleware.AllowedPrivacy(ctx), false)
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply privacy: %v", err))
+		return
+	}
+
 	response := interfaces.PersonResponse{
-		Person:  person,
+		Person:  &persons[0],
 		Message: "Person updated successfully",
 	}
 
@@ -237,3 +337,14 @@ func (h *Handler) DeletePerson(w http.ResponseWriter, r *http.Request, personID
 		"message": "Person deleted successfully",
 	})
 }
+
+// attributeFilters collects the attr.{name}={value} query parameters
+func attributeFilters(r *http.Request) map[string]string {
+	filters := make(map[string]string)
+	for key, values := range r.URL.Query() {
+		if name, found := strings.CutPrefix(key, "attr."); found && name != "" && len(values) > 0 {
+			filters[name] = values[0]
+		}
+	}
+	return filters
+}
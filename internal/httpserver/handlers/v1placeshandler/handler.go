@@ -0,0 +1,297 @@
+package v1placeshandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/internal/httpserver/helpers"
+	"github.com/rogerwesterbo/familytree/internal/services/v1placeservice"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// Handler handles HTTP requests for place operations
+type Handler struct {
+	service *v1placeservice.PlaceService
+}
+
+// NewHandler creates a new place handler
+func NewHandler(service *v1placeservice.PlaceService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// HandlePlaces routes place requests based on HTTP method
+// @Summary Place operations
+// @Description Handle place gazetteer CRUD operations
+// @Tags places
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/places [get]
+// @Router /v1/places [post]
+// @Router /v1/places/{id} [get]
+// @Router /v1/places/{id} [put]
+// @Router /v1/places/{id} [delete]
+// @Router /v1/places/{id}/children [get]
+func (h *Handler) HandlePlaces(w http.ResponseWriter, r *http.Request) {
+	placeID := strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1/places"), "/")
+
+	switch r.Method {
+	case http.MethodGet:
+		if parentID, found := strings.CutSuffix(placeID, "/children"); found {
+			h.GetChildPlaces(w, r, parentID)
+			return
+		}
+		if placeID != "" {
+			h.GetPlace(w, r, placeID)
+			return
+		}
+		h.ListPlaces(w, r)
+	case http.MethodPost:
+		h.CreatePlace(w, r)
+	case http.MethodPut:
+		if placeID == "" {
+			helpers.SendError(w, http.StatusBadRequest, "place ID is required")
+			return
+		}
+		h.UpdatePlace(w, r, placeID)
+	case http.MethodDelete:
+		if placeID == "" {
+			helpers.SendError(w, http.StatusBadRequest, "place ID is required")
+			return
+		}
+		h.DeletePlace(w, r, placeID)
+	default:
+		helpers.SendError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// ListPlaces returns all places, or the places matching a name
+// @Summary List places
+// @Description Get a list of all places, or search places by current, alternate and historical names
+// @Tags places
+// @Accept json
+// @Produce json
+// @Param name query string false "Part of a current, alternate or historical name"
+// @Success 200 {object} interfaces.PlacesListResponse
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/places [get]
+func (h *Handler) ListPlaces(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var places []interfaces.Place
+	var err error
+	if name := r.URL.Query().Get("name"); name != "" {
+		places, err = h.service.SearchPlacesByName(ctx, name)
+	} else {
+		places, err = h.service.ListPlaces(ctx)
+	}
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to list places: %v", err))
+		return
+	}
+
+	response := interfaces.PlacesListResponse{
+		Places: places,
+		Count:  len(places),
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// GetPlace returns a specific place by ID
+// @Summary Get a place
+// @Description Get a place by ID with the hierarchy of places it lies within
+// @Tags places
+// @Accept json
+// @Produce json
+// @Param id path string true "Place ID"
+// @Success 200 {object} interfaces.PlaceResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/places/{id} [get]
+func (h *Handler) GetPlace(w http.ResponseWriter, r *http.Request, placeID string) {
+	ctx := r.Context()
+
+	place, hierarchy, err := h.service.GetPlace(ctx, placeID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "place not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get place: %v", err))
+		return
+	}
+
+	response := interfaces.PlaceResponse{
+		Place:     place,
+		Hierarchy: hierarchy,
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// GetChildPlaces returns the places directly within a place
+// @Summary Get the places within a place
+// @Description Get the places whose parent is the given place, e.g. the farms of a parish
+// @Tags places
+// @Accept json
+// @Produce json
+// @Param id path string true "Place ID"
+// @Success 200 {object} interfaces.PlacesListResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/places/{id}/children [get]
+func (h *Handler) GetChildPlaces(w http.ResponseWriter, r *http.Request, placeID string) {
+	ctx := r.Context()
+
+	places, err := h.service.GetChildPlaces(ctx, placeID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "place not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get child places: %v", err))
+		return
+	}
+
+	response := interfaces.PlacesListResponse{
+		Places: places,
+		Count:  len(places),
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// CreatePlace creates a new place
+// @Summary Create a place
+// @Description Create a new place in the gazetteer
+// @Tags places
+// @Accept json
+// @Produce json
+// @Param place body interfaces.PlaceCreateRequest true "Place data"
+// @Success 201 {object} interfaces.PlaceResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/places [post]
+func (h *Handler) CreatePlace(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req interfaces.PlaceCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.SendError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	place, err := h.service.CreatePlace(ctx, &req)
+	if err != nil {
+		if errors.Is(err, v1placeservice.ErrInvalidPlace) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to create place: %v", err))
+		return
+	}
+
+	response := interfaces.PlaceResponse{
+		Place:   place,
+		Message: "Place created successfully",
+	}
+
+	helpers.SendJSON(w, http.StatusCreated, response)
+}
+
+// UpdatePlace updates an existing place
+// @Summary Update a place
+// @Description Update an existing place. Alternate names, when given, replace the current alternate names.
+// @Tags places
+// @Accept json
+// @Produce json
+// @Param id path string true "Place ID"
+// @Param place body interfaces.PlaceUpdateRequest true "Place data"
+// @Success 200 {object} interfaces.PlaceResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/places/{id} [put]
+func (h *Handler) UpdatePlace(w http.ResponseWriter, r *http.Request, placeID string) {
+	ctx := r.Context()
+
+	var req interfaces.PlaceUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.SendError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	place, err := h.service.UpdatePlace(ctx, placeID, &req)
+	if err != nil {
+		if errors.Is(err, v1placeservice.ErrInvalidPlace) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "place not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to update place: %v", err))
+		return
+	}
+
+	response := interfaces.PlaceResponse{
+		Place:   place,
+		Message: "Place updated successfully",
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// DeletePlace deletes a place
+// @Summary Delete a place
+// @Description Delete a place that no other place, person or event refers to
+// @Tags places
+// @Accept json
+// @Produce json
+// @Param id path string true "Place ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/places/{id} [delete]
+func (h *Handler) DeletePlace(w http.ResponseWriter, r *http.Request, placeID string) {
+	ctx := r.Context()
+
+	err := h.service.DeletePlace(ctx, placeID)
+	if err != nil {
+		if errors.Is(err, v1placeservice.ErrPlaceInUse) {
+			helpers.SendError(w, http.StatusConflict, err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "place not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to delete place: %v", err))
+		return
+	}
+
+	helpers.SendJSON(w, http.StatusOK, map[string]string{
+		"message": "Place deleted successfully",
+	})
+}
@@ -2,24 +2,29 @@ package v1relationshipshandler
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/rogerwesterbo/familytree/internal/httpserver/helpers"
+	"github.com/rogerwesterbo/familytree/internal/httpserver/middleware"
+	"github.com/rogerwesterbo/familytree/internal/services/v1personservice"
 	"github.com/rogerwesterbo/familytree/internal/services/v1relationshipservice"
 	"github.com/rogerwesterbo/familytree/pkg/interfaces"
 )
 
 // Handler handles HTTP requests for relationship operations
 type Handler struct {
-	service *v1relationshipservice.RelationshipService
+	service       *v1relationshipservice.RelationshipService
+	personService *v1personservice.PersonService
 }
 
 // NewHandler creates a new relationship handler
-func NewHandler(service *v1relationshipservice.RelationshipService) *Handler {
+func NewHandler(service *v1relationshipservice.RelationshipService, personService *v1personservice.PersonService) *Handler {
 	return &Handler{
-		service: service,
+		service:       service,
+		personService: personService,
 	}
 }
 
@@ -138,6 +143,7 @@ func (h *Handler) GetRelationship(w http.ResponseWriter, r *http.Request, relati
 // @Param relationship body interfaces.RelationshipCreateRequest true "Relationship data"
 // @Success 201 {object} interfaces.RelationshipResponse
 // @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Security BearerAuth
 // @Security OAuth2Password
@@ -153,7 +159,15 @@ func (h *Handler) CreateRelationship(w http.ResponseWriter, r *http.Request) {
 
 	relationship, err := h.service.CreateRelationship(ctx, &req)
 	if err != nil {
-		helpers.SendError(w, http.StatusBadRequest, err.Error())
+		if errors.Is(err, v1relationshipservice.ErrConflictingRelationship) {
+			helpers.SendError(w, http.StatusConflict, err.Error())
+			return
+		}
+		if errors.Is(err, v1relationshipservice.ErrInvalidRelationship) || errors.Is(err, v1relationshipservice.ErrInvalidQualifier) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to create relationship: %v", err))
 		return
 	}
 
@@ -176,6 +190,7 @@ func (h *Handler) CreateRelationship(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {object} interfaces.RelationshipResponse
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Security BearerAuth
 // @Security OAuth2Password
@@ -195,6 +210,14 @@ func (h *Handler) UpdateRelationship(w http.ResponseWriter, r *http.Request, rel
 			helpers.SendError(w, http.StatusNotFound, "relationship not found")
 			return
 		}
+		if errors.Is(err, v1relationshipservice.ErrConflictingRelationship) {
+			helpers.SendError(w, http.StatusConflict, err.Error())
+			return
+		}
+		if errors.Is(err, v1relationshipservice.ErrInvalidRelationship) || errors.Is(err, v1relationshipservice.ErrInvalidQualifier) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to update relationship: %v", err))
 		return
 	}
@@ -237,3 +260,457 @@ func (h *Handler) DeleteRelationship(w http.ResponseWriter, r *http.Request, rel
 		"message": "Relationship deleted successfully",
 	})
 }
+
+// GetAncestors returns the ancestors of a person
+// @Summary Get ancestors of a person
+// @Description Walk parent edges upward from a person and return every ancestor with its generation and the path used to reach it. Persons the user may not see are redacted.
+// @Tags relationships
+// @Accept json
+// @Produce json
+// @Param id path string true "Person ID"
+// @Param depth query int false "Maximum number of generations" default(10)
+// @Param qualifier query string false "Only follow parent relationships with these qualifiers, comma-separated, e.g. biological. Relationships without a qualifier count as biological"
+// @Success 200 {object} interfaces.AncestorsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/persons/{id}/ancestors [get]
+func (h *Handler) GetAncestors(w http.ResponseWriter, r *http.Request, personID string) {
+	ctx := r.Context()
+
+	depth, err := helpers.QueryInt(r, "depth", v1relationshipservice.DefaultTraversalDepth)
+	if err != nil {
+		helpers.SendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	qualifiers := helpers.QueryList(r, "qualifier")
+
+	ancestors, err := h.service.GetAncestors(ctx, personID, depth, qualifiers)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "person not found")
+			return
+		}
+		if strings.Contains(err.Error(), "depth") || errors.Is(err, v1relationshipservice.ErrInvalidQualifier) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get ancestors: %v", err))
+		return
+	}
+
+	persons := make([]*interfaces.Person, 0, len(ancestors))
+	for i := range ancestors {
+		persons = append(persons, &ancestors[i].Person)
+	}
+	if !h.redact(w, r, persons...) {
+		return
+	}
+
+	response := interfaces.AncestorsResponse{
+		PersonID:  personID,
+		Depth:     depth,
+		Ancestors: ancestors,
+		Count:     len(ancestors),
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// GetDescendants returns a nested tree of the descendants of a person
+// @Summary Get descendants of a person
+// @Description Walk parent edges downward from a person and return a nested tree of descendants, optionally with their spouses. Persons the user may not see are redacted.
+// @Tags relationships
+// @Accept json
+// @Produce json
+// @Param id path string true "Person ID"
+// @Param depth query int false "Maximum number of generations" default(10)
+// @Param includeSpouses query bool false "Include the spouses of every person in the tree" default(true)
+// @Param qualifier query string false "Only follow parent relationships with these qualifiers, comma-separated, e.g. biological. Relationships without a qualifier count as biological"
+// @Success 200 {object} interfaces.DescendantsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/persons/{id}/descendants [get]
+func (h *Handler) GetDescendants(w http.ResponseWriter, r *http.Request, personID string) {
+	ctx := r.Context()
+
+	depth, err := helpers.QueryInt(r, "depth", v1relationshipservice.DefaultTraversalDepth)
+	if err != nil {
+		helpers.SendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	includeSpouses, err := helpers.QueryBool(r, "includeSpouses", true)
+	if err != nil {
+		helpers.SendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	qualifiers := helpers.QueryList(r, "qualifier")
+
+	tree, count, err := h.service.GetDescendants(ctx, personID, depth, includeSpouses, qualifiers)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "person not found")
+			return
+		}
+		if strings.Contains(err.Error(), "depth") || errors.Is(err, v1relationshipservice.ErrInvalidQualifier) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get descendants: %v", err))
+		return
+	}
+
+	var persons []*interfaces.Person
+	var walk func(node *interfaces.DescendantNode)
+	walk = func(node *interfaces.DescendantNode) {
+		persons = append(persons, &node.Person)
+		for i := range node.Spouses {
+			persons = append(persons, &node.Spouses[i].Person)
+		}
+		for i := range node.Children {
+			walk(&node.Children[i])
+		}
+	}
+	walk(tree)
+	if !h.redact(w, r, persons...) {
+		return
+	}
+
+	response := interfaces.DescendantsResponse{
+		PersonID: personID,
+		Depth:    depth,
+		Tree:     tree,
+		Count:    count,
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// GetKinship returns how one person is related to another
+// @Summary Get kinship between two persons
+// @Description Find the nearest common ancestors of two persons and name the relationship, e.g. "second cousin once removed". Relations by marriage are found through spouse edges. The relationship is read as "B is A's relationship". Persons the user may not see are redacted.
+// @Tags relationships
+// @Accept json
+// @Produce json
+// @Param a path string true "Person A ID"
+// @Param b path string true "Person B ID"
+// @Param depth query int false "Maximum number of generations to search" default(10)
+// @Success 200 {object} interfaces.KinshipResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/persons/{a}/kinship/{b} [get]
+func (h *Handler) GetKinship(w http.ResponseWriter, r *http.Request, personA, personB string) {
+	ctx := r.Context()
+
+	depth, err := helpers.QueryInt(r, "depth", v1relationshipservice.DefaultTraversalDepth)
+	if err != nil {
+		helpers.SendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	kinship, err := h.service.GetKinship(ctx, personA, personB, depth)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "person not found")
+			return
+		}
+		if strings.Contains(err.Error(), "depth") {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get kinship: %v", err))
+		return
+	}
+
+	var persons []*interfaces.Person
+	if kinship != nil {
+		for i := range kinship.CommonAncestors {
+			persons = append(persons, &kinship.CommonAncestors[i])
+		}
+		if kinship.Via != nil {
+			persons = append(persons, kinship.Via)
+		}
+	}
+	if !h.redact(w, r, persons...) {
+		return
+	}
+
+	response := interfaces.KinshipResponse{
+		PersonA: personA,
+		PersonB: personB,
+		Kinship: kinship,
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// GetPaths returns the shortest relationship paths between two persons
+// @Summary Get relationship paths between two persons
+// @Description Find the shortest chain of persons and relationships connecting two persons, optionally with the k shortest alternatives. Persons the user may not see are redacted.
+// @Tags relationships
+// @Accept json
+// @Produce json
+// @Param a path string true "Person A ID"
+// @Param b path string true "Person B ID"
+// @Param k query int false "Number of shortest paths to return" default(1)
+// @Success 200 {object} interfaces.PathsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/persons/{a}/path/{b} [get]
+func (h *Handler) GetPaths(w http.ResponseWriter, r *http.Request, personA, personB string) {
+	ctx := r.Context()
+
+	k, err := helpers.QueryInt(r, "k", 1)
+	if err != nil {
+		helpers.SendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	paths, err := h.service.GetPaths(ctx, personA, personB, k)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "person not found")
+			return
+		}
+		if strings.Contains(err.Error(), "must be") {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get paths: %v", err))
+		return
+	}
+
+	var persons []*interfaces.Person
+	for i := range paths {
+		for j := range paths[i].Persons {
+			persons = append(persons, &paths[i].Persons[j])
+		}
+	}
+	if !h.redact(w, r, persons...) {
+		return
+	}
+
+	response := interfaces.PathsResponse{
+		PersonA: personA,
+		PersonB: personB,
+		Paths:   paths,
+		Count:   len(paths),
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// GetPedigreeCollapse returns the pedigree collapse report of a person
+// @Summary Get pedigree collapse report
+// @Description Find the ancestors of a person reached through more than one path, how often they appear, and the unions of related partners (e.g. cousin marriages) that cause them. Persons the user may not see are redacted. Every path is followed, so when more than 10000 paths lead up to the ancestors the request is refused and fewer generations must be asked for.
+// @Tags relationships
+// @Accept json
+// @Produce json
+// @Param id path string true "Person ID"
+// @Param depth query int false "Maximum number of generations" default(10)
+// @Param qualifier query string false "Only follow parent relationships with these qualifiers, comma-separated, e.g. biological. Relationships without a qualifier count as biological"
+// @Success 200 {object} interfaces.PedigreeCollapseResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/persons/{id}/pedigree-collapse [get]
+func (h *Handler) GetPedigreeCollapse(w http.ResponseWriter, r *http.Request, personID string) {
+	ctx := r.Context()
+
+	depth, err := helpers.QueryInt(r, "depth", v1relationshipservice.DefaultTraversalDepth)
+	if err != nil {
+		helpers.SendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	qualifiers := helpers.QueryList(r, "qualifier")
+
+	ancestors, unions, err := h.service.GetPedigreeCollapse(ctx, personID, depth, qualifiers)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "person not found")
+			return
+		}
+		if strings.Contains(err.Error(), "depth") || errors.Is(err, v1relationshipservice.ErrInvalidQualifier) ||
+			errors.Is(err, v1relationshipservice.ErrTooManyAncestorPaths) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get pedigree collapse: %v", err))
+		return
+	}
+
+	persons := make([]*interfaces.Person, 0, len(ancestors))
+	for i := range ancestors {
+		persons = append(persons, &ancestors[i].Person)
+	}
+	for i := range unions {
+		for j := range unions[i].Partners {
+			persons = append(persons, &unions[i].Partners[j])
+		}
+	}
+	if !h.redact(w, r, persons...) {
+		return
+	}
+
+	response := interfaces.PedigreeCollapseResponse{
+		PersonID:            personID,
+		Depth:               depth,
+		DuplicatedAncestors: ancestors,
+		Unions:              unions,
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// GetAhnentafel returns the Ahnentafel numbering of a person's ancestors
+// @Summary Get Ahnentafel numbering
+// @Description Number a person and their ancestors with Sosa-Stradonitz numbers: 1 for the person, 2n for the father and 2n+1 for the mother of n. Missing parents are listed as missing slots. Persons the user may not see are redacted.
+// @Tags relationships
+// @Accept json
+// @Produce json
+// @Param id path string true "Person ID"
+// @Param generations query int false "Number of generations, the person being generation 1" default(5)
+// @Param qualifier query string false "Only follow parent relationships with these qualifiers, comma-separated, e.g. biological. Relationships without a qualifier count as biological"
+// @Success 200 {object} interfaces.AhnentafelResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/persons/{id}/ahnentafel [get]
+func (h *Handler) GetAhnentafel(w http.ResponseWriter, r *http.Request, personID string) {
+	ctx := r.Context()
+
+	generations, err := helpers.QueryInt(r, "generations", v1relationshipservice.DefaultAhnentafelGenerations)
+	if err != nil {
+		helpers.SendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	qualifiers := helpers.QueryList(r, "qualifier")
+
+	entries, err := h.service.GetAhnentafel(ctx, personID, generations, qualifiers)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "person not found")
+			return
+		}
+		if strings.Contains(err.Error(), "generations") || errors.Is(err, v1relationshipservice.ErrInvalidQualifier) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get ahnentafel: %v", err))
+		return
+	}
+
+	persons := make([]*interfaces.Person, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Person != nil {
+			persons = append(persons, entry.Person)
+		}
+	}
+	if !h.redact(w, r, persons...) {
+		return
+	}
+
+	response := interfaces.AhnentafelResponse{
+		PersonID:    personID,
+		Generations: generations,
+		Entries:     entries,
+		Count:       len(entries),
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// GetDescendantNumbers returns the descendants of a person labelled with a descendant numbering system
+// @Summary Get descendant numbering
+// @Description Label a person and their descendants with d'Aboville (1.2.3) or Henry (123) numbers, with children ordered by birth date. Persons the user may not see are redacted.
+// @Tags relationships
+// @Accept json
+// @Produce json
+// @Param id path string true "Person ID"
+// @Param system query string false "Numbering system" Enums(daboville, henry) default(daboville)
+// @Param depth query int false "Maximum number of generations" default(10)
+// @Param qualifier query string false "Only follow parent relationships with these qualifiers, comma-separated, e.g. biological. Relationships without a qualifier count as biological"
+// @Success 200 {object} interfaces.DescendantNumbersResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/persons/{id}/descendant-numbers [get]
+func (h *Handler) GetDescendantNumbers(w http.ResponseWriter, r *http.Request, personID string) {
+	ctx := r.Context()
+
+	depth, err := helpers.QueryInt(r, "depth", v1relationshipservice.DefaultTraversalDepth)
+	if err != nil {
+		helpers.SendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	system := r.URL.Query().Get("system")
+	if system == "" {
+		system = interfaces.NumberingDAboville
+	}
+
+	qualifiers := helpers.QueryList(r, "qualifier")
+
+	descendants, err := h.service.GetDescendantNumbers(ctx, personID, depth, system, qualifiers)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "person not found")
+			return
+		}
+		if strings.Contains(err.Error(), "depth") || strings.Contains(err.Error(), "numbering system") ||
+			errors.Is(err, v1relationshipservice.ErrInvalidQualifier) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get descendant numbers: %v", err))
+		return
+	}
+
+	persons := make([]*interfaces.Person, 0, len(descendants))
+	for i := range descendants {
+		persons = append(persons, &descendants[i].Person)
+	}
+	if !h.redact(w, r, persons...) {
+		return
+	}
+
+	response := interfaces.DescendantNumbersResponse{
+		PersonID:    personID,
+		System:      system,
+		Depth:       depth,
+		Descendants: descendants,
+		Count:       len(descendants),
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// redact redacts the persons of a traversal the user may not see, as GetPerson does. It sends an error and
+// returns false when that fails.
+func (h *Handler) redact(w http.ResponseWriter, r *http.Request, persons ...*interfaces.Person) bool {
+	if err := h.personService.RedactPersons(r.Context(), middleware.AllowedPrivacy(r.Context()), persons...); err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply privacy: %v", err))
+		return false
+	}
+	return true
+}
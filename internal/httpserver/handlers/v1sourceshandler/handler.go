@@ -0,0 +1,253 @@
+package v1sourceshandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/internal/httpserver/helpers"
+	"github.com/rogerwesterbo/familytree/internal/services/v1sourceservice"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// Handler handles HTTP requests for source operations
+type Handler struct {
+	service *v1sourceservice.SourceService
+}
+
+// NewHandler creates a new source handler
+func NewHandler(service *v1sourceservice.SourceService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// HandleSources routes source requests based on HTTP method
+// @Summary Source operations
+// @Description Handle source CRUD operations
+// @Tags sources
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/sources [get]
+// @Router /v1/sources [post]
+// @Router /v1/sources/{id} [get]
+// @Router /v1/sources/{id} [put]
+// @Router /v1/sources/{id} [delete]
+func (h *Handler) HandleSources(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		if strings.HasPrefix(r.URL.Path, "/v1/sources/") {
+			sourceID := strings.TrimPrefix(r.URL.Path, "/v1/sources/")
+			if sourceID != "" {
+				h.GetSource(w, r, sourceID)
+				return
+			}
+		}
+		h.ListSources(w, r)
+	case http.MethodPost:
+		h.CreateSource(w, r)
+	case http.MethodPut:
+		sourceID := strings.TrimPrefix(r.URL.Path, "/v1/sources/")
+		if sourceID == "" {
+			helpers.SendError(w, http.StatusBadRequest, "source ID is required")
+			return
+		}
+		h.UpdateSource(w, r, sourceID)
+	case http.MethodDelete:
+		sourceID := strings.TrimPrefix(r.URL.Path, "/v1/sources/")
+		if sourceID == "" {
+			helpers.SendError(w, http.StatusBadRequest, "source ID is required")
+			return
+		}
+		h.DeleteSource(w, r, sourceID)
+	default:
+		helpers.SendError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// ListSources returns all sources
+// @Summary List all sources
+// @Description Get a list of all sources
+// @Tags sources
+// @Accept json
+// @Produce json
+// @Success 200 {object} interfaces.SourcesListResponse
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/sources [get]
+func (h *Handler) ListSources(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	sources, err := h.service.ListSources(ctx)
+	if err != nil {
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to list sources: %v", err))
+		return
+	}
+
+	response := interfaces.SourcesListResponse{
+		Sources: sources,
+		Count:   len(sources),
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// GetSource returns a specific source by ID
+// @Summary Get a source
+// @Description Get a source by ID
+// @Tags sources
+// @Accept json
+// @Produce json
+// @Param id path string true "Source ID"
+// @Success 200 {object} interfaces.SourceResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/sources/{id} [get]
+func (h *Handler) GetSource(w http.ResponseWriter, r *http.Request, sourceID string) {
+	ctx := r.Context()
+
+	source, err := h.service.GetSource(ctx, sourceID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "source not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get source: %v", err))
+		return
+	}
+
+	response := interfaces.SourceResponse{
+		Source: source,
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// CreateSource creates a new source
+// @Summary Create a source
+// @Description Create a new source such as a church book, census, gravestone or interview
+// @Tags sources
+// @Accept json
+// @Produce json
+// @Param source body interfaces.SourceCreateRequest true "Source data"
+// @Success 201 {object} interfaces.SourceResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/sources [post]
+func (h *Handler) CreateSource(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req interfaces.SourceCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.SendError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	source, err := h.service.CreateSource(ctx, &req)
+	if err != nil {
+		if errors.Is(err, v1sourceservice.ErrInvalidSource) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to create source: %v", err))
+		return
+	}
+
+	response := interfaces.SourceResponse{
+		Source:  source,
+		Message: "Source created successfully",
+	}
+
+	helpers.SendJSON(w, http.StatusCreated, response)
+}
+
+// UpdateSource updates an existing source
+// @Summary Update a source
+// @Description Update an existing source
+// @Tags sources
+// @Accept json
+// @Produce json
+// @Param id path string true "Source ID"
+// @Param source body interfaces.SourceUpdateRequest true "Source data"
+// @Success 200 {object} interfaces.SourceResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/sources/{id} [put]
+func (h *Handler) UpdateSource(w http.ResponseWriter, r *http.Request, sourceID string) {
+	ctx := r.Context()
+
+	var req interfaces.SourceUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.SendError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	source, err := h.service.UpdateSource(ctx, sourceID, &req)
+	if err != nil {
+		if errors.Is(err, v1sourceservice.ErrInvalidSource) {
+			helpers.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "source not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to update source: %v", err))
+		return
+	}
+
+	response := interfaces.SourceResponse{
+		Source:  source,
+		Message: "Source updated successfully",
+	}
+
+	helpers.SendJSON(w, http.StatusOK, response)
+}
+
+// DeleteSource deletes a source
+// @Summary Delete a source
+// @Description Delete a source that no citation refers to
+// @Tags sources
+// @Accept json
+// @Produce json
+// @Param id path string true "Source ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Security OAuth2Password
+// @Router /v1/sources/{id} [delete]
+func (h *Handler) DeleteSource(w http.ResponseWriter, r *http.Request, sourceID string) {
+	ctx := r.Context()
+
+	err := h.service.DeleteSource(ctx, sourceID)
+	if err != nil {
+		if errors.Is(err, v1sourceservice.ErrSourceInUse) {
+			helpers.SendError(w, http.StatusConflict, err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			helpers.SendError(w, http.StatusNotFound, "source not found")
+			return
+		}
+		helpers.SendError(w, http.StatusInternalServerError, fmt.Sprintf("failed to delete source: %v", err))
+		return
+	}
+
+	helpers.SendJSON(w, http.StatusOK, map[string]string{
+		"message": "Source deleted successfully",
+	})
+}
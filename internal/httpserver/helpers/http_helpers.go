@@ -2,8 +2,11 @@ package helpers
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/vitistack/common/pkg/loggers/vlog"
 )
@@ -37,3 +40,46 @@ func SendError(w http.ResponseWriter, status int, message string) {
 	w.WriteHeader(status)
 	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
 }
+
+// QueryInt reads an integer query parameter, returning defaultValue when it is absent
+func QueryInt(r *http.Request, name string, defaultValue int) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an integer", name)
+	}
+
+	return value, nil
+}
+
+// QueryBool reads a boolean query parameter, returning defaultValue when it is absent
+func QueryBool(r *http.Request, name string, defaultValue bool) (bool, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("%s must be true or false", name)
+	}
+
+	return value, nil
+}
+
+// QueryList reads a query parameter that may be repeated or comma-separated into a single list
+func QueryList(r *http.Request, name string) []string {
+	var list []string
+	for _, value := range r.URL.Query()[name] {
+		for item := range strings.SplitSeq(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
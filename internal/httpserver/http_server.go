@@ -53,6 +53,16 @@ func (s *HTTPServer) Start() error {
 		s.corsMiddleware,
 		clients.PersonService,
 		clients.RelationshipService,
+		clients.FamilyService,
+		clients.EventService,
+		clients.PlaceService,
+		clients.SourceService,
+		clients.CitationService,
+		clients.MediaService,
+		clients.AttributeService,
+		clients.NoteService,
+		clients.ImportService,
+		clients.ExportService,
 	)
 
 	// Wrap router with CORS middleware
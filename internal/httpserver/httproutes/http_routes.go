@@ -4,13 +4,34 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/rogerwesterbo/familytree/internal/httpserver/handlers/v1attributetypeshandler"
+	"github.com/rogerwesterbo/familytree/internal/httpserver/handlers/v1citationshandler"
+	"github.com/rogerwesterbo/familytree/internal/httpserver/handlers/v1eventshandler"
+	"github.com/rogerwesterbo/familytree/internal/httpserver/handlers/v1exporthandler"
+	"github.com/rogerwesterbo/familytree/internal/httpserver/handlers/v1familieshandler"
+	"github.com/rogerwesterbo/familytree/internal/httpserver/handlers/v1importhandler"
+	"github.com/rogerwesterbo/familytree/internal/httpserver/handlers/v1mediahandler"
+	"github.com/rogerwesterbo/familytree/internal/httpserver/handlers/v1noteshandler"
 	"github.com/rogerwesterbo/familytree/internal/httpserver/handlers/v1personshandler"
+	"github.com/rogerwesterbo/familytree/internal/httpserver/handlers/v1placeshandler"
 	"github.com/rogerwesterbo/familytree/internal/httpserver/handlers/v1relationshipshandler"
+	"github.com/rogerwesterbo/familytree/internal/httpserver/handlers/v1sourceshandler"
+	"github.com/rogerwesterbo/familytree/internal/httpserver/helpers"
 	"github.com/rogerwesterbo/familytree/internal/httpserver/middleware"
 	_ "github.com/rogerwesterbo/familytree/internal/httpserver/swaggerdocs" // swagger docs
+	"github.com/rogerwesterbo/familytree/internal/services/v1attributeservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1citationservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1eventservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1exportservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1familyservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1importservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1mediaservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1noteservice"
 	"github.com/rogerwesterbo/familytree/internal/services/v1personservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1placeservice"
 	"github.com/rogerwesterbo/familytree/internal/services/v1ratelimitservice"
 	"github.com/rogerwesterbo/familytree/internal/services/v1relationshipservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1sourceservice"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
@@ -21,6 +42,16 @@ type Router struct {
 	corsMiddleware       *middleware.CORSMiddleware
 	personsHandler       *v1personshandler.Handler
 	relationshipsHandler *v1relationshipshandler.Handler
+	familiesHandler      *v1familieshandler.Handler
+	eventsHandler        *v1eventshandler.Handler
+	placesHandler        *v1placeshandler.Handler
+	sourcesHandler       *v1sourceshandler.Handler
+	citationsHandler     *v1citationshandler.Handler
+	mediaHandler         *v1mediahandler.Handler
+	attributesHandler    *v1attributetypeshandler.Handler
+	notesHandler         *v1noteshandler.Handler
+	importHandler        *v1importhandler.Handler
+	exportHandler        *v1exporthandler.Handler
 }
 
 // NewRouter creates a new HTTP router with all routes configured
@@ -30,11 +61,31 @@ func NewRouter(
 	corsMiddleware *middleware.CORSMiddleware,
 	personService *v1personservice.PersonService,
 	relationshipService *v1relationshipservice.RelationshipService,
+	familyService *v1familyservice.FamilyService,
+	eventService *v1eventservice.EventService,
+	placeService *v1placeservice.PlaceService,
+	sourceService *v1sourceservice.SourceService,
+	citationService *v1citationservice.CitationService,
+	mediaService *v1mediaservice.MediaService,
+	attributeService *v1attributeservice.AttributeService,
+	noteService *v1noteservice.NoteService,
+	importService *v1importservice.ImportService,
+	exportService *v1exportservice.ExportService,
 ) *http.ServeMux {
 
 	// Initialize handlers with services
 	personsHandler := v1personshandler.NewHandler(personService)
-	relationshipsHandler := v1relationshipshandler.NewHandler(relationshipService)
+	relationshipsHandler := v1relationshipshandler.NewHandler(relationshipService, personService)
+	familiesHandler := v1familieshandler.NewHandler(familyService, personService)
+	eventsHandler := v1eventshandler.NewHandler(eventService, personService)
+	placesHandler := v1placeshandler.NewHandler(placeService)
+	sourcesHandler := v1sourceshandler.NewHandler(sourceService)
+	citationsHandler := v1citationshandler.NewHandler(citationService, personService)
+	mediaHandler := v1mediahandler.NewHandler(mediaService, personService)
+	attributesHandler := v1attributetypeshandler.NewHandler(attributeService)
+	notesHandler := v1noteshandler.NewHandler(noteService, personService)
+	importHandler := v1importhandler.NewHandler(importService)
+	exportHandler := v1exporthandler.NewHandler(exportService)
 
 	r := &Router{
 		mux:                  http.NewServeMux(),
@@ -42,6 +93,16 @@ func NewRouter(
 		authMiddleware:       authMiddleware,
 		personsHandler:       personsHandler,
 		relationshipsHandler: relationshipsHandler,
+		familiesHandler:      familiesHandler,
+		eventsHandler:        eventsHandler,
+		placesHandler:        placesHandler,
+		sourcesHandler:       sourcesHandler,
+		citationsHandler:     citationsHandler,
+		mediaHandler:         mediaHandler,
+		attributesHandler:    attributesHandler,
+		notesHandler:         notesHandler,
+		importHandler:        importHandler,
+		exportHandler:        exportHandler,
 	}
 
 	r.registerRoutes()
@@ -78,10 +139,80 @@ func (r *Router) handleAPIRoutes(w http.ResponseWriter, req *http.Request) {
 
 	// Route to appropriate handler
 	switch {
+	case isPersonSubresource(path):
+		r.handlePersonSubresources(w, req)
 	case path == "/v1/persons" || strings.HasPrefix(path, "/v1/persons/"):
 		r.personsHandler.HandlePersons(w, req)
 	case path == "/v1/relationships" || strings.HasPrefix(path, "/v1/relationships/"):
 		r.relationshipsHandler.HandleRelationships(w, req)
+	case path == "/v1/families":
+		r.familiesHandler.HandleFamilies(w, req)
+	case path == "/v1/events" || strings.HasPrefix(path, "/v1/events/"):
+		r.eventsHandler.HandleEvents(w, req)
+	case path == "/v1/places" || strings.HasPrefix(path, "/v1/places/"):
+		r.placesHandler.HandlePlaces(w, req)
+	case path == "/v1/sources" || strings.HasPrefix(path, "/v1/sources/"):
+		r.sourcesHandler.HandleSources(w, req)
+	case path == "/v1/citations" || strings.HasPrefix(path, "/v1/citations/"):
+		r.citationsHandler.HandleCitations(w, req)
+	case path == "/v1/media" || strings.HasPrefix(path, "/v1/media/"):
+		r.mediaHandler.HandleMedia(w, req)
+	case path == "/v1/attribute-types" || strings.HasPrefix(path, "/v1/attribute-types/"):
+		r.attributesHandler.HandleAttributeTypes(w, req)
+	case path == "/v1/notes" || strings.HasPrefix(path, "/v1/notes/"):
+		r.notesHandler.HandleNotes(w, req)
+	case path == "/v1/tasks":
+		r.notesHandler.ListTasks(w, req)
+	case path == "/v1/import":
+		r.importHandler.HandleImport(w, req)
+	case path == "/v1/export" || strings.HasPrefix(path, "/v1/export/person/"):
+		r.exportHandler.HandleExport(w, req)
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+// isPersonSubresource reports whether the path addresses a resource below a person, e.g. /v1/persons/{id}/ancestors
+func isPersonSubresource(path string) bool {
+	rest, found := strings.CutPrefix(path, "/v1/persons/")
+	return found && strings.Contains(strings.Trim(rest, "/"), "/")
+}
+
+// handlePersonSubresources routes /v1/persons/{id}/{resource} requests to the handler owning the resource
+func (r *Router) handlePersonSubresources(w http.ResponseWriter, req *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(req.URL.Path, "/v1/persons/"), "/"), "/")
+	personID, resource := parts[0], parts[1]
+
+	if req.Method != http.MethodGet {
+		helpers.SendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	switch {
+	case resource == "ancestors" && len(parts) == 2:
+		r.relationshipsHandler.GetAncestors(w, req, personID)
+	case resource == "descendants" && len(parts) == 2:
+		r.relationshipsHandler.GetDescendants(w, req, personID)
+	case resource == "descendant-numbers" && len(parts) == 2:
+		r.relationshipsHandler.GetDescendantNumbers(w, req, personID)
+	case resource == "ahnentafel" && len(parts) == 2:
+		r.relationshipsHandler.GetAhnentafel(w, req, personID)
+	case resource == "pedigree-collapse" && len(parts) == 2:
+		r.relationshipsHandler.GetPedigreeCollapse(w, req, personID)
+	case resource == "families" && len(parts) == 2:
+		r.familiesHandler.GetPersonFamilies(w, req, personID)
+	case resource == "events" && len(parts) == 2:
+		r.eventsHandler.GetPersonEvents(w, req, personID)
+	case resource == "citations" && len(parts) == 2:
+		r.citationsHandler.GetPersonCitations(w, req, personID)
+	case resource == "media" && len(parts) == 2:
+		r.mediaHandler.GetPersonMedia(w, req, personID)
+	case resource == "notes" && len(parts) == 2:
+		r.notesHandler.GetPersonNotes(w, req, personID)
+	case resource == "kinship" && len(parts) == 3:
+		r.relationshipsHandler.GetKinship(w, req, personID, parts[2])
+	case resource == "path" && len(parts) == 3:
+		r.relationshipsHandler.GetPaths(w, req, personID, parts[2])
 	default:
 		http.NotFound(w, req)
 	}
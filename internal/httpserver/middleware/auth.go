@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/rogerwesterbo/familytree/pkg/consts"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
 	"github.com/spf13/viper"
 	"github.com/vitistack/common/pkg/loggers/vlog"
 )
@@ -21,8 +23,12 @@ const (
 	userNameKey  contextKey = "user_name"
 	usernameKey  contextKey = "username"
 	userIDKey    contextKey = "user_id"
+	userRolesKey contextKey = "user_roles"
 )
 
+// RoleAdmin is the realm role allowed to manage deployment-wide settings such as attribute types
+const RoleAdmin = "familytree-admin"
+
 // AuthMiddleware validates JWT tokens from Keycloak
 type AuthMiddleware struct {
 	verifier *oidc.IDTokenVerifier
@@ -113,11 +119,13 @@ func (am *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 
 		// Extract claims
 		var claims struct {
-			Email         string   `json:"email"`
-			EmailVerified bool     `json:"email_verified"`
-			Name          string   `json:"name"`
-			PreferredUser string   `json:"preferred_username"`
-			RealmRoles    []string `json:"realm_access.roles"`
+			Email         string `json:"email"`
+			EmailVerified bool   `json:"email_verified"`
+			Name          string `json:"name"`
+			PreferredUser string `json:"preferred_username"`
+			RealmAccess   struct {
+				Roles []string `json:"roles"`
+			} `json:"realm_access"`
 		}
 
 		if err := token.Claims(&claims); err != nil {
@@ -131,6 +139,7 @@ func (am *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 		ctx = context.WithValue(ctx, userNameKey, claims.Name)
 		ctx = context.WithValue(ctx, usernameKey, claims.PreferredUser)
 		ctx = context.WithValue(ctx, userIDKey, token.Subject)
+		ctx = context.WithValue(ctx, userRolesKey, claims.RealmAccess.Roles)
 
 		// Log successful authentication
 		vlog.Debugf("Authenticated user: %s (%s)", claims.PreferredUser, claims.Email)
@@ -160,3 +169,38 @@ func GetUserFromContext(ctx context.Context) (userID, username, email string) {
 	}
 	return
 }
+
+// HasRole reports whether the authenticated user has a realm role
+func HasRole(ctx context.Context, role string) bool {
+	roles, _ := ctx.Value(userRolesKey).([]string)
+	return slices.Contains(roles, role)
+}
+
+// AllowedPrivacy returns the most restricted privacy level of the persons the authenticated user may see.
+// Admins see private persons, every other user sees family persons, living persons included.
+func AllowedPrivacy(ctx context.Context) string {
+	if HasRole(ctx, RoleAdmin) {
+		return interfaces.PrivacyPrivate
+	}
+	return interfaces.PrivacyFamily
+}
+
+// PrivacyView reads the privacy level to show and whether restricted persons are hidden rather than redacted.
+// The level defaults to, and is capped at, what the user may see.
+func PrivacyView(r *http.Request) (access string, hide bool, err error) {
+	// Users may narrow what they see, but not widen it
+	access = AllowedPrivacy(r.Context())
+	if privacy := r.URL.Query().Get("privacy"); privacy != "" && (privacy != interfaces.PrivacyPrivate || access == interfaces.PrivacyPrivate) {
+		access = privacy
+	}
+
+	switch restricted := r.URL.Query().Get("restricted"); restricted {
+	case "", "redact":
+	case "hide":
+		hide = true
+	default:
+		return "", false, fmt.Errorf("restricted must be redact or hide")
+	}
+
+	return access, hide, nil
+}
@@ -26,7 +26,7 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/v1/persons": {
+        "/v1/attribute-types": {
             "get": {
                 "security": [
                     {
@@ -36,7 +36,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Get a list of all persons in the family tree",
+                "description": "Get the custom person attributes defined for this deployment",
                 "consumes": [
                     "application/json"
                 ],
@@ -44,14 +44,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "persons"
+                    "attribute-types"
                 ],
-                "summary": "List all persons",
+                "summary": "List all attribute types",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PersonsListResponse"
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.AttributeTypesListResponse"
                         }
                     },
                     "500": {
@@ -74,7 +74,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Create a new person in the family tree",
+                "description": "Define a custom person attribute with a string, date, number, enum or place value. Requires the familytree-admin role.",
                 "consumes": [
                     "application/json"
                 ],
@@ -82,17 +82,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "persons"
+                    "attribute-types"
                 ],
-                "summary": "Create a person",
+                "summary": "Create an attribute type",
                 "parameters": [
                     {
-                        "description": "Person data",
-                        "name": "person",
+                        "description": "Attribute type data",
+                        "name": "attributeType",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PersonCreateRequest"
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.AttributeTypeCreateRequest"
                         }
                     }
                 ],
@@ -100,7 +100,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PersonResponse"
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.AttributeTypeResponse"
                         }
                     },
                     "400": {
@@ -112,6 +112,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -124,7 +133,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/persons/{id}": {
+        "/v1/attribute-types/{id}": {
             "get": {
                 "security": [
                     {
@@ -134,7 +143,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Get a person by ID",
+                "description": "Get an attribute type by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -142,13 +151,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "persons"
+                    "attribute-types"
                 ],
-                "summary": "Get a person",
+                "summary": "Get an attribute type",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Person ID",
+                        "description": "Attribute type ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -158,7 +167,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PersonResponse"
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.AttributeTypeResponse"
                         }
                     },
                     "404": {
@@ -190,7 +199,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Update an existing person in the family tree",
+                "description": "Update the label, enum values or description of an attribute type. Requires the familytree-admin role.",
                 "consumes": [
                     "application/json"
                 ],
@@ -198,24 +207,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "persons"
+                    "attribute-types"
                 ],
-                "summary": "Update a person",
+                "summary": "Update an attribute type",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Person ID",
+                        "description": "Attribute type ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Person data",
-                        "name": "person",
+                        "description": "Attribute type data",
+                        "name": "attributeType",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PersonUpdateRequest"
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.AttributeTypeUpdateRequest"
                         }
                     }
                 ],
@@ -223,7 +232,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PersonResponse"
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.AttributeTypeResponse"
                         }
                     },
                     "400": {
@@ -235,6 +244,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -264,7 +282,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Delete a person from the family tree",
+                "description": "Delete an attribute type that no person has a value for. Requires the familytree-admin role.",
                 "consumes": [
                     "application/json"
                 ],
@@ -272,13 +290,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "persons"
+                    "attribute-types"
                 ],
-                "summary": "Delete a person",
+                "summary": "Delete an attribute type",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Person ID",
+                        "description": "Attribute type ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -294,6 +312,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -303,6 +330,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -315,7 +351,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/relationships": {
+        "/v1/citations": {
             "get": {
                 "security": [
                     {
@@ -325,7 +361,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Get a list of all relationships in the family tree",
+                "description": "Get a list of all citations with the persons, relationships and events they support",
                 "consumes": [
                     "application/json"
                 ],
@@ -333,14 +369,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "relationships"
+                    "citations"
                 ],
-                "summary": "List all relationships",
+                "summary": "List all citations",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.RelationshipsListResponse"
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.CitationsListResponse"
                         }
                     },
                     "500": {
@@ -363,7 +399,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Create a new relationship in the family tree",
+                "description": "Create a new citation of a source and link it to the persons, relationships and events it supports",
                 "consumes": [
                     "application/json"
                 ],
@@ -371,17 +407,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "relationships"
+                    "citations"
                 ],
-                "summary": "Create a relationship",
+                "summary": "Create a citation",
                 "parameters": [
                     {
-                        "description": "Relationship data",
-                        "name": "relationship",
+                        "description": "Citation data",
+                        "name": "citation",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.RelationshipCreateRequest"
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.CitationCreateRequest"
                         }
                     }
                 ],
@@ -389,7 +425,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.RelationshipResponse"
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.CitationResponse"
                         }
                     },
                     "400": {
@@ -413,7 +449,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/relationships/{id}": {
+        "/v1/citations/{id}": {
             "get": {
                 "security": [
                     {
@@ -423,7 +459,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Get a relationship by ID",
+                "description": "Get a citation by ID with the persons, relationships and events it supports",
                 "consumes": [
                     "application/json"
                 ],
@@ -431,13 +467,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "relationships"
+                    "citations"
                 ],
-                "summary": "Get a relationship",
+                "summary": "Get a citation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Relationship ID",
+                        "description": "Citation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -447,7 +483,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.RelationshipResponse"
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.CitationResponse"
                         }
                     },
                     "404": {
@@ -479,7 +515,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Update an existing relationship in the family tree",
+                "description": "Update an existing citation. Subjects, when given, replace the current subjects.",
                 "consumes": [
                     "application/json"
                 ],
@@ -487,24 +523,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "relationships"
+                    "citations"
                 ],
-                "summary": "Update a relationship",
+                "summary": "Update a citation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Relationship ID",
+                        "description": "Citation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Relationship data",
-                        "name": "relationship",
+                        "description": "Citation data",
+                        "name": "citation",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.RelationshipUpdateRequest"
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.CitationUpdateRequest"
                         }
                     }
                 ],
@@ -512,7 +548,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.RelationshipResponse"
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.CitationResponse"
                         }
                     },
                     "400": {
@@ -553,7 +589,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Delete a relationship from the family tree",
+                "description": "Delete a citation and its links to the facts it supports",
                 "consumes": [
                     "application/json"
                 ],
@@ -561,13 +597,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "relationships"
+                    "citations"
                 ],
-                "summary": "Delete a relationship",
+                "summary": "Delete a citation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Relationship ID",
+                        "description": "Citation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -603,145 +639,5474 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_rogerwesterbo_familytree_pkg_interfaces.Person": {
-            "type": "object",
-            "required": [
-                "firstName",
-                "lastName"
-            ],
-            "properties": {
-                "_id": {
-                    "type": "string"
-                },
-                "_key": {
-                    "type": "string"
-                },
-                "_rev": {
-                    "type": "string"
-                },
-                "birthDate": {
-                    "type": "string"
-                },
-                "createdAt": {
-                    "type": "string"
-                },
-                "deathDate": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "firstName": {
-                    "type": "string"
-                },
-                "gender": {
-                    "type": "string"
-                },
-                "lastName": {
-                    "type": "string"
-                },
-                "phone": {
-                    "type": "string"
-                },
-                "updatedAt": {
-                    "type": "string"
-                }
-            }
         },
-        "github_com_rogerwesterbo_familytree_pkg_interfaces.PersonCreateRequest": {
-            "type": "object",
-            "required": [
-                "firstName",
-                "lastName"
-            ],
-            "properties": {
-                "birthDate": {
-                    "type": "string",
-                    "example": "1980-01-15T00:00:00Z"
-                },
-                "deathDate": {
-                    "type": "string",
-                    "example": "2050-05-20T00:00:00Z"
-                },
-                "email": {
-                    "type": "string",
-                    "example": "john.doe@example.com"
-                },
-                "firstName": {
-                    "type": "string",
-                    "example": "John"
-                },
-                "gender": {
-                    "type": "string",
-                    "example": "male"
-                },
-                "lastName": {
-                    "type": "string",
-                    "example": "Doe"
-                },
-                "phone": {
-                    "type": "string",
-                    "example": "+1234567890"
+        "/v1/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get a list of all life events in the family tree with their participants. Events of persons the user may not see are left out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "List all events",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.EventsListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
                 }
-            }
-        },
-        "github_com_rogerwesterbo_familytree_pkg_interfaces.PersonResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string"
-                },
-                "person": {
-                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Person"
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Create a new life event and link its participants",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Create an event",
+                "parameters": [
+                    {
+                        "description": "Event data",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.EventCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.EventResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
                 }
             }
         },
-        "github_com_rogerwesterbo_familytree_pkg_interfaces.PersonUpdateRequest": {
+        "/v1/events/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get a life event by ID with its participants. Events of persons the user may not see are not found.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Get an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.EventResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Update an existing life event. Participants, when given, replace the current participants.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Update an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Event data",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.EventUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.EventResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Delete a life event and its participant links",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Delete an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Export every person with the spouse and parent relationships between them and the places they were born and died in. GEDCOM exports are GEDCOM 5.5.1 files in UTF-8 with an INDI record for every person and a FAM record for every couple or single parent, and gedcom7 exports the same as GEDCOM 7.0. GEDZIP exports are zip archives of a GEDCOM 7.0 file and the media files attached to the persons. GEDCOM X exports (gedcomx) are JSON documents that also hold the events of the persons, the sources cited for them and the places. Persons the user may not see are redacted, or left out with restricted=hide.",
+                "produces": [
+                    "application/json",
+                    "text/plain",
+                    "application/zip",
+                    "application/x-gedcomx-v1+json"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Export the family tree",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "gedcom",
+                            "gedcom7",
+                            "gedzip",
+                            "gedcomx"
+                        ],
+                        "type": "string",
+                        "description": "Format of the export",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "public",
+                            "family",
+                            "private"
+                        ],
+                        "type": "string",
+                        "description": "Most restricted privacy level to export, capped at what the user may see",
+                        "name": "privacy",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "redact",
+                            "hide"
+                        ],
+                        "type": "string",
+                        "default": "redact",
+                        "description": "Whether persons above the privacy level are redacted or hidden",
+                        "name": "restricted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.ExportData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/export/person/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Export a person with their ancestors, their descendants and the spouses of the descendants, or both, up to depth generations, in the same formats as the whole tree.",
+                "produces": [
+                    "application/json",
+                    "text/plain",
+                    "application/zip",
+                    "application/x-gedcomx-v1+json"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Export a person and their relatives",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "gedcom",
+                            "gedcom7",
+                            "gedzip",
+                            "gedcomx"
+                        ],
+                        "type": "string",
+                        "description": "Format of the export",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "ancestors",
+                            "descendants",
+                            "both"
+                        ],
+                        "type": "string",
+                        "default": "both",
+                        "description": "Relatives to export with the person",
+                        "name": "scope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of generations",
+                        "name": "depth",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "daboville",
+                            "henry"
+                        ],
+                        "type": "string",
+                        "description": "Descendant numbering system to label the descendants with, written as REFN in GEDCOM files and as descendantNumbers in JSON",
+                        "name": "numbering",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "public",
+                            "family",
+                            "private"
+                        ],
+                        "type": "string",
+                        "description": "Most restricted privacy level to export, capped at what the user may see",
+                        "name": "privacy",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "redact",
+                            "hide"
+                        ],
+                        "type": "string",
+                        "default": "redact",
+                        "description": "Whether persons above the privacy level are redacted or hidden",
+                        "name": "restricted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.ExportData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/families": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Derive every family unit in the tree from the spouse and parent edges. Persons the user may not see are redacted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "families"
+                ],
+                "summary": "List all families",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.FamiliesListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Create the spouse edge between the partners and the parent edges to every child in one request. A family needs married partners or children, and the marriage dates and notes need married to be true.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "families"
+                ],
+                "summary": "Create a family",
+                "parameters": [
+                    {
+                        "description": "Family data",
+                        "name": "family",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.FamilyCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.FamilyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Import the persons, families, sources and notes of a file from another family tree program. The file is sent as the file field of a multipart form or as the request body. GEDCOM 5.5.1 files may be in ANSEL, UTF-8, UTF-16, ANSI or ASCII; GEDCOM 7.0 files (gedcom7) are UTF-8, GEDZIP archives (gedzip) also import the media files they bundle, and GEDCOM X documents (gedcomx) are read from their JSON form. CSV files (csv) are spreadsheets of persons with a header row, separated by commas, semicolons or tabs. Their columns are mapped to the fields id, firstName, lastName, gender, birthDate, birthPlace, deathDate, deathPlace, email, phone, privacy and notes by mapping, or by a header naming the field. A second spreadsheet, the relationships field of the form, may join the persons with the columns from, to, relationType, qualifier, startDate, endDate and notes, where from and to are ids of person rows. With dryRun=true every row is validated and nothing is written: places are looked up without being created, and relationships are not checked against each other. Records that fail validation are left out; the report lists what was created, the tags that were not imported, the rows that were left out and warnings.",
+                "consumes": [
+                    "multipart/form-data",
+                    "application/octet-stream"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Import a file",
+                "parameters": [
+                    {
+                        "enum": [
+                            "gedcom",
+                            "gedcom7",
+                            "gedzip",
+                            "gedcomx",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Format of the file",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "The file to import",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Spreadsheet of the relationships between the persons of a CSV import",
+                        "name": "relationships",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON object mapping the column headers of a CSV import to fields, such as {\\",
+                        "name": "mapping",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Only validate the rows of a CSV import and report the errors, without checking the relationships against each other",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.ImportResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/media": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get the metadata of all photos, scanned documents and audio recordings, optionally only those attached to a subject.\nMedia attached to persons the user may not see are left out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "List media",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "persons/123",
+                        "description": "Only list media attached to this person, event or source",
+                        "name": "subjectId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.MediaListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Upload a photo, scanned document (image or PDF) or audio recording, optionally attached to persons, events and sources",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Upload media",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "The file to upload",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caption",
+                        "name": "caption",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "ABT 1857",
+                        "description": "Date the photo was taken or the document written",
+                        "name": "date",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "IDs of the persons, events and sources the file is attached to",
+                        "name": "subjects",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.MediaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/media/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get the metadata of a media file by ID, with the persons, events and sources it is attached to.\nMedia attached to persons the user may not see are not found.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Get media metadata",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Media ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.MediaResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Update the file name, caption, date or subjects of a media file. Subjects, when given, replace the current subjects.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Update media metadata",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Media ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Media metadata",
+                        "name": "media",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.MediaUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.MediaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Delete a media file with its content and its links to persons, events and sources",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Delete media",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Media ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/media/{id}/content": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Download the photo, scanned document or audio recording stored for a media file. Files whose content does not confirm their type are sent as attachments.\nMedia attached to persons the user may not see are not found.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Download media content",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Media ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/notes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get all research notes and tasks, optionally only those attached to a subject. Notes about persons the user may not see are left out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "List notes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "persons/123",
+                        "description": "Only list notes attached to this person, relationship or event",
+                        "name": "subjectId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.NotesListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Create a Markdown research note on a person, relationship or event, written by the authenticated user. Give it a status, assignee or due date to make it a research task.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Create a note",
+                "parameters": [
+                    {
+                        "description": "Note data",
+                        "name": "note",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.NoteCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.NoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/notes/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get a research note or task by ID. Notes about persons the user may not see are not found.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Get a note",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.NoteResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Update an existing note or task. An empty status turns a task back into a plain note; an empty assignee or due date removes it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Update a note",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note data",
+                        "name": "note",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.NoteUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.NoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Delete a research note or task",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Delete a note",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/persons": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get a list of all persons in the family tree, or of the persons with a name matching firstName and/or lastName.\nEvery name of a person is searched, such as birth, married, patronymic and farm names.\nPersons can also be filtered on custom attributes with attr.{name}={value} parameters, e.g. attr.occupation=Farmer.\nPersons more restricted than the privacy level, or than what the user may see, are redacted or hidden. Living persons without a privacy override are family.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "persons"
+                ],
+                "summary": "List all persons",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Given name to search for",
+                        "name": "firstName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Surname to search for",
+                        "name": "lastName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Value a custom attribute must have",
+                        "name": "attr.{name}",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Include the citations supporting every person",
+                        "name": "includeCitations",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "public",
+                            "family",
+                            "private"
+                        ],
+                        "type": "string",
+                        "description": "Most restricted privacy level to show, e.g. public to leave out living persons",
+                        "name": "privacy",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "redact",
+                            "hide"
+                        ],
+                        "type": "string",
+                        "default": "redact",
+                        "description": "Whether persons above the privacy level are redacted or left out",
+                        "name": "restricted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PersonsListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Create a new person in the family tree. Custom facts go in attributes, keyed by attribute type name; unknown fields are rejected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "persons"
+                ],
+                "summary": "Create a person",
+                "parameters": [
+                    {
+                        "description": "Person data",
+                        "name": "person",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PersonCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PersonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/persons/{a}/kinship/{b}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Find the nearest common ancestors of two persons and name the relationship, e.g. \"second cousin once removed\". Relations by marriage are found through spouse edges. The relationship is read as \"B is A's relationship\". Persons the user may not see are redacted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "relationships"
+                ],
+                "summary": "Get kinship between two persons",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Person A ID",
+                        "name": "a",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Person B ID",
+                        "name": "b",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of generations to search",
+                        "name": "depth",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.KinshipResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/persons/{a}/path/{b}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Find the shortest chain of persons and relationships connecting two persons, optionally with the k shortest alternatives. Persons the user may not see are redacted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "relationships"
+                ],
+                "summary": "Get relationship paths between two persons",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Person A ID",
+                        "name": "a",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Person B ID",
+                        "name": "b",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Number of shortest paths to return",
+                        "name": "k",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PathsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/persons/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get a person by ID. A person the user may not see is redacted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "persons"
+                ],
+                "summary": "Get a person",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Include the citations supporting the person",
+                        "name": "includeCitations",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PersonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Update an existing person in the family tree. Attributes are merged, and an attribute set to null is removed; unknown fields are rejected.\nPersons the user may not see cannot be updated, and the updated person is redacted when the user may no longer see them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "persons"
+                ],
+                "summary": "Update a person",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Person data",
+                        "name": "person",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PersonUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PersonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Delete a person from the family tree",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "persons"
+                ],
+                "summary": "Delete a person",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/persons/{id}/ahnentafel": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Number a person and their ancestors with Sosa-Stradonitz numbers: 1 for the person, 2n for the father and 2n+1 for the mother of n. Missing parents are listed as missing slots. Persons the user may not see are redacted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "relationships"
+                ],
+                "summary": "Get Ahnentafel numbering",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Number of generations, the person being generation 1",
+                        "name": "generations",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only follow parent relationships with these qualifiers, comma-separated, e.g. biological. Relationships without a qualifier count as biological",
+                        "name": "qualifier",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.AhnentafelResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/persons/{id}/ancestors": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Walk parent edges upward from a person and return every ancestor with its generation and the path used to reach it. Persons the user may not see are redacted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "relationships"
+                ],
+                "summary": "Get ancestors of a person",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of generations",
+                        "name": "depth",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only follow parent relationships with these qualifiers, comma-separated, e.g. biological. Relationships without a qualifier count as biological",
+                        "name": "qualifier",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.AncestorsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/persons/{id}/citations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get the citations supporting a person, with the fact each supports and its source. The citations of a person the user may not see are left out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "citations"
+                ],
+                "summary": "Get the citations of a person",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.SubjectCitationsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/persons/{id}/descendant-numbers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Label a person and their descendants with d'Aboville (1.2.3) or Henry (123) numbers, with children ordered by birth date. Persons the user may not see are redacted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "relationships"
+                ],
+                "summary": "Get descendant numbering",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "daboville",
+                            "henry"
+                        ],
+                        "type": "string",
+                        "default": "daboville",
+                        "description": "Numbering system",
+                        "name": "system",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of generations",
+                        "name": "depth",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only follow parent relationships with these qualifiers, comma-separated, e.g. biological. Relationships without a qualifier count as biological",
+                        "name": "qualifier",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.DescendantNumbersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/persons/{id}/descendants": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Walk parent edges downward from a person and return a nested tree of descendants, optionally with their spouses. Persons the user may not see are redacted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "relationships"
+                ],
+                "summary": "Get descendants of a person",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of generations",
+                        "name": "depth",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Include the spouses of every person in the tree",
+                        "name": "includeSpouses",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only follow parent relationships with these qualifiers, comma-separated, e.g. biological. Relationships without a qualifier count as biological",
+                        "name": "qualifier",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.DescendantsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/persons/{id}/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get the life events a person takes part in, in any role, ordered by date. Events of persons the user may not see are left out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Get the events of a person",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PersonEventsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/persons/{id}/families": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get the families a person is a partner in and the families they are a child in. Persons the user may not see are redacted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "families"
+                ],
+                "summary": "Get the families of a person",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PersonFamiliesResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/persons/{id}/media": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get the metadata of the photos, scanned documents and audio recordings attached to a person.\nMedia attached to persons the user may not see are left out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Get the media of a person",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.MediaListResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/persons/{id}/notes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get the research notes and tasks attached to a person, oldest first. Notes about persons the user may not see are left out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Get the notes of a person",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.NotesListResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/persons/{id}/pedigree-collapse": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Find the ancestors of a person reached through more than one path, how often they appear, and the unions of related partners (e.g. cousin marriages) that cause them. Persons the user may not see are redacted. Every path is followed, so when more than 10000 paths lead up to the ancestors the request is refused and fewer generations must be asked for.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "relationships"
+                ],
+                "summary": "Get pedigree collapse report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of generations",
+                        "name": "depth",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only follow parent relationships with these qualifiers, comma-separated, e.g. biological. Relationships without a qualifier count as biological",
+                        "name": "qualifier",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PedigreeCollapseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/places": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get a list of all places, or search places by current, alternate and historical names",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "places"
+                ],
+                "summary": "List places",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Part of a current, alternate or historical name",
+                        "name": "name",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PlacesListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Create a new place in the gazetteer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "places"
+                ],
+                "summary": "Create a place",
+                "parameters": [
+                    {
+                        "description": "Place data",
+                        "name": "place",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PlaceCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PlaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/places/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get a place by ID with the hierarchy of places it lies within",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "places"
+                ],
+                "summary": "Get a place",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Place ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PlaceResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Update an existing place. Alternate names, when given, replace the current alternate names.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "places"
+                ],
+                "summary": "Update a place",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Place ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Place data",
+                        "name": "place",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PlaceUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PlaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Delete a place that no other place, person or event refers to",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "places"
+                ],
+                "summary": "Delete a place",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Place ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/places/{id}/children": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get the places whose parent is the given place, e.g. the farms of a parish",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "places"
+                ],
+                "summary": "Get the places within a place",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Place ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PlacesListResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/relationships": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get a list of all relationships in the family tree",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "relationships"
+                ],
+                "summary": "List all relationships",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.RelationshipsListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Create a new relationship in the family tree",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "relationships"
+                ],
+                "summary": "Create a relationship",
+                "parameters": [
+                    {
+                        "description": "Relationship data",
+                        "name": "relationship",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.RelationshipCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.RelationshipResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/relationships/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get a relationship by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "relationships"
+                ],
+                "summary": "Get a relationship",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Relationship ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.RelationshipResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Update an existing relationship in the family tree",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "relationships"
+                ],
+                "summary": "Update a relationship",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Relationship ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Relationship data",
+                        "name": "relationship",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.RelationshipUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.RelationshipResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Delete a relationship from the family tree",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "relationships"
+                ],
+                "summary": "Delete a relationship",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Relationship ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/sources": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get a list of all sources",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sources"
+                ],
+                "summary": "List all sources",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.SourcesListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Create a new source such as a church book, census, gravestone or interview",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sources"
+                ],
+                "summary": "Create a source",
+                "parameters": [
+                    {
+                        "description": "Source data",
+                        "name": "source",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.SourceCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.SourceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/sources/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get a source by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sources"
+                ],
+                "summary": "Get a source",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Source ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.SourceResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Update an existing source",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sources"
+                ],
+                "summary": "Update a source",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Source ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Source data",
+                        "name": "source",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.SourceUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.SourceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Delete a source that no citation refers to",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sources"
+                ],
+                "summary": "Delete a source",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Source ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tasks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get the research tasks ordered by due date, by default the open and in-progress ones. Notes about persons the user may not see are left out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "List research tasks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "open,in_progress",
+                        "description": "Task statuses, comma-separated",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list tasks assigned to this user",
+                        "name": "assignee",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "persons/123",
+                        "description": "Only list tasks attached to this person, relationship or event",
+                        "name": "subjectId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.TasksListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.AhnentafelEntry": {
+            "type": "object",
+            "properties": {
+                "generation": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "missing": {
+                    "type": "boolean"
+                },
+                "number": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "person": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Person"
+                },
+                "sameAs": {
+                    "description": "SameAs is the first number held by the same person when pedigree collapse places them more than once",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.AhnentafelResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.AhnentafelEntry"
+                    }
+                },
+                "generations": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "personId": {
+                    "type": "string",
+                    "example": "persons/123"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.Ancestor": {
+            "type": "object",
+            "properties": {
+                "generation": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "path": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Relationship"
+                    }
+                },
+                "person": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Person"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.AncestorsResponse": {
+            "type": "object",
+            "properties": {
+                "ancestors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Ancestor"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "depth": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "personId": {
+                    "type": "string",
+                    "example": "persons/123"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.AttributeType": {
+            "type": "object",
+            "required": [
+                "name",
+                "valueType"
+            ],
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "_key": {
+                    "type": "string"
+                },
+                "_rev": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enumValues": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "label": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "valueType": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.AttributeTypeCreateRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "valueType"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Religious denomination"
+                },
+                "enumValues": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Lutheran",
+                        "Catholic",
+                        "Quaker"
+                    ]
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Religion"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "religion"
+                },
+                "valueType": {
+                    "type": "string",
+                    "example": "enum"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.AttributeTypeResponse": {
+            "type": "object",
+            "properties": {
+                "attributeType": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.AttributeType"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.AttributeTypeUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Religious denomination"
+                },
+                "enumValues": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Lutheran",
+                        "Catholic",
+                        "Quaker"
+                    ]
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Religion"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.AttributeTypesListResponse": {
+            "type": "object",
+            "properties": {
+                "attributeTypes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.AttributeType"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.Citation": {
+            "type": "object",
+            "required": [
+                "sourceId"
+            ],
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "_key": {
+                    "type": "string"
+                },
+                "_rev": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "entry": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "string"
+                },
+                "quality": {
+                    "description": "Quality rates the evidence from 0 (unreliable) to 3 (direct and primary), as the GEDCOM QUAY tag does",
+                    "type": "integer"
+                },
+                "sourceId": {
+                    "type": "string"
+                },
+                "subjects": {
+                    "description": "Subjects are stored as citation link edges and filled in when the citation is read",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.CitationSubject"
+                    }
+                },
+                "transcription": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.CitationCreateRequest": {
+            "type": "object",
+            "required": [
+                "sourceId"
+            ],
+            "properties": {
+                "entry": {
+                    "type": "string",
+                    "example": "Baptism no. 17"
+                },
+                "page": {
+                    "type": "string",
+                    "example": "42"
+                },
+                "quality": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "sourceId": {
+                    "type": "string",
+                    "example": "sources/123"
+                },
+                "subjects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.CitationSubject"
+                    }
+                },
+                "transcription": {
+                    "type": "string",
+                    "example": "Døbt: Ole, søn af Hans Olsen"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://www.digitalarkivet.no/view/255/pg00000000000042"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.CitationReference": {
+            "type": "object",
+            "properties": {
+                "citation": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Citation"
+                },
+                "fact": {
+                    "type": "string",
+                    "example": "birth"
+                },
+                "source": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Source"
+                },
+                "subjectId": {
+                    "type": "string",
+                    "example": "persons/123"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.CitationResponse": {
+            "type": "object",
+            "properties": {
+                "citation": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Citation"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.CitationSubject": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "fact": {
+                    "type": "string",
+                    "example": "birth"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "persons/123"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.CitationUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "entry": {
+                    "type": "string",
+                    "example": "Baptism no. 17"
+                },
+                "page": {
+                    "type": "string",
+                    "example": "42"
+                },
+                "quality": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "sourceId": {
+                    "type": "string",
+                    "example": "sources/123"
+                },
+                "subjects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.CitationSubject"
+                    }
+                },
+                "transcription": {
+                    "type": "string",
+                    "example": "Døbt: Ole, søn af Hans Olsen"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://www.digitalarkivet.no/view/255/pg00000000000042"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.CitationsListResponse": {
+            "type": "object",
+            "properties": {
+                "citations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Citation"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.CollapseUnion": {
+            "type": "object",
+            "properties": {
+                "childId": {
+                    "type": "string",
+                    "example": "persons/123"
+                },
+                "partners": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Person"
+                    }
+                },
+                "relationship": {
+                    "type": "string",
+                    "example": "first cousin"
+                },
+                "sharedAncestors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.CollapsedAncestor": {
+            "type": "object",
+            "properties": {
+                "generations": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "multiplicity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "paths": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "person": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Person"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.DatePart": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "integer"
+                },
+                "month": {
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.DescendantNode": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.DescendantNode"
+                    }
+                },
+                "generation": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "person": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Person"
+                },
+                "spouses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Spouse"
+                    }
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.DescendantNumbersResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "depth": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "descendants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.NumberedDescendant"
+                    }
+                },
+                "personId": {
+                    "type": "string",
+                    "example": "persons/123"
+                },
+                "system": {
+                    "type": "string",
+                    "example": "daboville"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.DescendantsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "depth": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "personId": {
+                    "type": "string",
+                    "example": "persons/123"
+                },
+                "tree": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.DescendantNode"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.Event": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "_key": {
+                    "type": "string"
+                },
+                "_rev": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "date": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.GenealogicalDate"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "participants": {
+                    "description": "Participants are stored as participant edges and filled in when the event is read",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.EventParticipant"
+                    }
+                },
+                "placeId": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.EventCreateRequest": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "ABT 1843"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Baptised by the parish priest"
+                },
+                "participants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.EventParticipant"
+                    }
+                },
+                "placeId": {
+                    "type": "string",
+                    "example": "places/123"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "baptism"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.EventParticipant": {
+            "type": "object",
+            "required": [
+                "personId",
+                "role"
+            ],
+            "properties": {
+                "personId": {
+                    "type": "string",
+                    "example": "persons/123"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "principal"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.EventResponse": {
+            "type": "object",
+            "properties": {
+                "event": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Event"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.EventUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "1843-05-12"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Baptised by the parish priest"
+                },
+                "participants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.EventParticipant"
+                    }
+                },
+                "placeId": {
+                    "type": "string",
+                    "example": "places/123"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "baptism"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.EventsListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Event"
+                    }
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.ExportData": {
+            "type": "object",
+            "properties": {
+                "descendantNumbers": {
+                    "description": "DescendantNumbers holds the numbers of the descendants by person ID; a descendant reached through\ntwo parents has a number under each of them",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "exportedAt": {
+                    "type": "string"
+                },
+                "numbering": {
+                    "description": "Numbering is the descendant numbering system of DescendantNumbers",
+                    "type": "string",
+                    "example": "daboville"
+                },
+                "persons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Person"
+                    }
+                },
+                "places": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Place"
+                    }
+                },
+                "relationships": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Relationship"
+                    }
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.FamiliesListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "families": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Family"
+                    }
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.Family": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Person"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123+456"
+                },
+                "partners": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Person"
+                    }
+                },
+                "union": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Relationship"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.FamilyCreateRequest": {
+            "type": "object",
+            "required": [
+                "partners"
+            ],
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "persons/789"
+                    ]
+                },
+                "endDate": {
+                    "type": "string",
+                    "example": "2020-12-31"
+                },
+                "married": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Married in Oslo"
+                },
+                "partners": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "persons/123",
+                        "persons/456"
+                    ]
+                },
+                "startDate": {
+                    "type": "string",
+                    "example": "ABT 2000"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.FamilyResponse": {
+            "type": "object",
+            "properties": {
+                "family": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Family"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.GenealogicalDate": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.DatePart"
+                },
+                "end": {
+                    "description": "End is the end of the range for BET..AND dates",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.DatePart"
+                        }
+                    ]
+                },
+                "qualifier": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.ImportCounts": {
+            "type": "object",
+            "properties": {
+                "citations": {
+                    "type": "integer"
+                },
+                "events": {
+                    "type": "integer"
+                },
+                "media": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "integer"
+                },
+                "persons": {
+                    "type": "integer"
+                },
+                "places": {
+                    "type": "integer"
+                },
+                "relationships": {
+                    "type": "integer"
+                },
+                "sources": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.ImportReport": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.ImportCounts"
+                },
+                "dryRun": {
+                    "description": "DryRun is set when the file was only validated; Created then counts what importing it would create",
+                    "type": "boolean"
+                },
+                "encoding": {
+                    "description": "Encoding is the character set the file was read in",
+                    "type": "string",
+                    "example": "ANSEL"
+                },
+                "format": {
+                    "type": "string",
+                    "example": "gedcom"
+                },
+                "rowErrors": {
+                    "description": "RowErrors lists the rows of a spreadsheet that were left out, with why",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.ImportRowError"
+                    }
+                },
+                "skippedTags": {
+                    "description": "SkippedTags counts the tags that were not imported by their path, such as INDI.OCCU.AGE",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.ImportResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "report": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.ImportReport"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "invalid email format"
+                },
+                "row": {
+                    "description": "Row is the row number as a spreadsheet shows it, with the header as row 1",
+                    "type": "integer",
+                    "example": 7
+                },
+                "sheet": {
+                    "description": "Sheet is persons or relationships",
+                    "type": "string",
+                    "example": "persons"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.Kinship": {
+            "type": "object",
+            "properties": {
+                "commonAncestors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Person"
+                    }
+                },
+                "generationsFromA": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "generationsFromB": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "half": {
+                    "type": "boolean"
+                },
+                "inLaw": {
+                    "type": "boolean"
+                },
+                "related": {
+                    "type": "boolean"
+                },
+                "relationship": {
+                    "type": "string",
+                    "example": "second cousin once removed"
+                },
+                "via": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Person"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.KinshipResponse": {
+            "type": "object",
+            "properties": {
+                "kinship": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Kinship"
+                },
+                "personA": {
+                    "type": "string",
+                    "example": "persons/123"
+                },
+                "personB": {
+                    "type": "string",
+                    "example": "persons/456"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.Media": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "_key": {
+                    "type": "string"
+                },
+                "_rev": {
+                    "type": "string"
+                },
+                "caption": {
+                    "type": "string"
+                },
+                "checksum": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "date": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.GenealogicalDate"
+                },
+                "fileName": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "mimeType": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "storageKey": {
+                    "type": "string"
+                },
+                "subjects": {
+                    "description": "Subjects are stored as media link edges and filled in when the media is read",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.MediaListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "media": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Media"
+                    }
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.MediaResponse": {
+            "type": "object",
+            "properties": {
+                "media": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Media"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.MediaUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "caption": {
+                    "type": "string",
+                    "example": "Ole Hansen at his confirmation"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "ABT 1857"
+                },
+                "fileName": {
+                    "type": "string",
+                    "example": "confirmation-1857.jpg"
+                },
+                "subjects": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.Note": {
+            "type": "object",
+            "required": [
+                "body",
+                "subjectId"
+            ],
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "_key": {
+                    "type": "string"
+                },
+                "_rev": {
+                    "type": "string"
+                },
+                "assignee": {
+                    "type": "string"
+                },
+                "author": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.NoteAuthor"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "dueDate": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.GenealogicalDate"
+                },
+                "status": {
+                    "description": "Status, Assignee and DueDate are written even when empty, so clearing them on update removes the stored values",
+                    "type": "string"
+                },
+                "subjectId": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.NoteAuthor": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.NoteCreateRequest": {
+            "type": "object",
+            "required": [
+                "body",
+                "subjectId"
+            ],
+            "properties": {
+                "assignee": {
+                    "type": "string",
+                    "example": "kari"
+                },
+                "body": {
+                    "type": "string",
+                    "example": "Two candidates in the **1875 census**, check the emigrant protocols."
+                },
+                "dueDate": {
+                    "type": "string",
+                    "example": "2026-12-01"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "open"
+                },
+                "subjectId": {
+                    "type": "string",
+                    "example": "persons/123"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Which Ole Hansen emigrated in 1882?"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.NoteResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "note": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Note"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.NoteUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "assignee": {
+                    "type": "string",
+                    "example": "kari"
+                },
+                "body": {
+                    "type": "string",
+                    "example": "Found him in the emigrant protocol, see the citation."
+                },
+                "dueDate": {
+                    "type": "string",
+                    "example": "2026-12-01"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "done"
+                },
+                "subjectId": {
+                    "type": "string",
+                    "example": "persons/123"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Which Ole Hansen emigrated in 1882?"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.NotesListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Note"
+                    }
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.NumberedDescendant": {
+            "type": "object",
+            "properties": {
+                "generation": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "number": {
+                    "type": "string",
+                    "example": "1.2.3"
+                },
+                "parentNumber": {
+                    "type": "string",
+                    "example": "1.2"
+                },
+                "person": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Person"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.PathsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "paths": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.RelationshipPath"
+                    }
+                },
+                "personA": {
+                    "type": "string",
+                    "example": "persons/123"
+                },
+                "personB": {
+                    "type": "string",
+                    "example": "persons/456"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.PedigreeCollapseResponse": {
+            "type": "object",
+            "properties": {
+                "depth": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "duplicatedAncestors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.CollapsedAncestor"
+                    }
+                },
+                "personId": {
+                    "type": "string",
+                    "example": "persons/123"
+                },
+                "unions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.CollapseUnion"
+                    }
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.Person": {
+            "type": "object",
+            "required": [
+                "firstName",
+                "lastName"
+            ],
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "_key": {
+                    "type": "string"
+                },
+                "_rev": {
+                    "type": "string"
+                },
+                "attributes": {
+                    "description": "Attributes holds the values of custom attribute types by attribute type name",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "birthDate": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.GenealogicalDate"
+                },
+                "birthPlaceId": {
+                    "type": "string"
+                },
+                "citations": {
+                    "description": "Citations are filled in from the citation links when requested",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.CitationReference"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deathDate": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.GenealogicalDate"
+                },
+                "deathPlaceId": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "firstName": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "lastName": {
+                    "type": "string"
+                },
+                "living": {
+                    "description": "Living is filled in with whether the person is probably living when requested",
+                    "type": "boolean"
+                },
+                "names": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PersonName"
+                    }
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "privacy": {
+                    "description": "Privacy overrides who may see the person; when empty, living persons are family and others public",
+                    "type": "string"
+                },
+                "redacted": {
+                    "description": "Redacted is set on persons whose details are hidden from the viewer",
+                    "type": "boolean"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.PersonCreateRequest": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "object"
+                },
+                "birthDate": {
+                    "type": "string",
+                    "example": "1980-01-15"
+                },
+                "birthPlaceId": {
+                    "type": "string",
+                    "example": "places/123"
+                },
+                "deathDate": {
+                    "type": "string",
+                    "example": "ABT 2050"
+                },
+                "deathPlaceId": {
+                    "type": "string",
+                    "example": "places/456"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "john.doe@example.com"
+                },
+                "firstName": {
+                    "type": "string",
+                    "example": "John"
+                },
+                "gender": {
+                    "type": "string",
+                    "example": "male"
+                },
+                "lastName": {
+                    "type": "string",
+                    "example": "Doe"
+                },
+                "names": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PersonName"
+                    }
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+1234567890"
+                },
+                "privacy": {
+                    "type": "string",
+                    "example": "family"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.PersonEventsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Event"
+                    }
+                },
+                "personId": {
+                    "type": "string",
+                    "example": "persons/123"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.PersonFamiliesResponse": {
+            "type": "object",
+            "properties": {
+                "asChild": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Family"
+                    }
+                },
+                "asPartner": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Family"
+                    }
+                },
+                "personId": {
+                    "type": "string",
+                    "example": "persons/123"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.PersonName": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "givenName": {
+                    "type": "string",
+                    "example": "Ole"
+                },
+                "preferred": {
+                    "type": "boolean"
+                },
+                "surname": {
+                    "type": "string",
+                    "example": "Haugen"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "farm"
+                },
+                "validFrom": {
+                    "type": "string",
+                    "example": "1850"
+                },
+                "validTo": {
+                    "type": "string",
+                    "example": "1870"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.PersonResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "person": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Person"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.PersonUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "object"
+                },
+                "birthDate": {
+                    "type": "string",
+                    "example": "1980-01-15"
+                },
+                "birthPlaceId": {
+                    "type": "string",
+                    "example": "places/123"
+                },
+                "deathDate": {
+                    "type": "string",
+                    "example": "ABT 2050"
+                },
+                "deathPlaceId": {
+                    "type": "string",
+                    "example": "places/456"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "john.doe@example.com"
+                },
+                "firstName": {
+                    "type": "string",
+                    "example": "John"
+                },
+                "gender": {
+                    "type": "string",
+                    "example": "male"
+                },
+                "lastName": {
+                    "type": "string",
+                    "example": "Doe"
+                },
+                "names": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PersonName"
+                    }
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+1234567890"
+                },
+                "privacy": {
+                    "type": "string",
+                    "example": "family"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.PersonsListResponse": {
             "type": "object",
             "properties": {
-                "birthDate": {
+                "count": {
+                    "type": "integer"
+                },
+                "persons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Person"
+                    }
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.Place": {
+            "type": "object",
+            "required": [
+                "name",
+                "type"
+            ],
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "_key": {
+                    "type": "string"
+                },
+                "_rev": {
+                    "type": "string"
+                },
+                "alternateNames": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PlaceName"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parentId": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.PlaceCreateRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "type"
+            ],
+            "properties": {
+                "alternateNames": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PlaceName"
+                    }
+                },
+                "latitude": {
+                    "type": "number",
+                    "example": 59.9139
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 10.7522
+                },
+                "name": {
                     "type": "string",
-                    "example": "1980-01-15T00:00:00Z"
+                    "example": "Oslo"
                 },
-                "deathDate": {
+                "parentId": {
                     "type": "string",
-                    "example": "2050-05-20T00:00:00Z"
+                    "example": "places/123"
                 },
-                "email": {
+                "type": {
                     "type": "string",
-                    "example": "john.doe@example.com"
+                    "example": "municipality"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.PlaceName": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "language": {
+                    "type": "string",
+                    "example": "da"
                 },
-                "firstName": {
+                "name": {
                     "type": "string",
-                    "example": "John"
+                    "example": "Christiania"
                 },
-                "gender": {
+                "validFrom": {
                     "type": "string",
-                    "example": "male"
+                    "example": "1624"
                 },
-                "lastName": {
+                "validTo": {
                     "type": "string",
-                    "example": "Doe"
+                    "example": "1924"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.PlaceResponse": {
+            "type": "object",
+            "properties": {
+                "hierarchy": {
+                    "description": "Hierarchy lists the parent places from the closest parent up to the country",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Place"
+                    }
                 },
-                "phone": {
+                "message": {
+                    "type": "string"
+                },
+                "place": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Place"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.PlaceUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "alternateNames": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.PlaceName"
+                    }
+                },
+                "latitude": {
+                    "type": "number",
+                    "example": 59.9139
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 10.7522
+                },
+                "name": {
                     "type": "string",
-                    "example": "+1234567890"
+                    "example": "Oslo"
+                },
+                "parentId": {
+                    "type": "string",
+                    "example": "places/123"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "municipality"
                 }
             }
         },
-        "github_com_rogerwesterbo_familytree_pkg_interfaces.PersonsListResponse": {
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.PlacesListResponse": {
             "type": "object",
             "properties": {
                 "count": {
                     "type": "integer"
                 },
-                "persons": {
+                "places": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Person"
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Place"
                     }
                 }
             }
@@ -773,16 +6138,19 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "endDate": {
-                    "type": "string"
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.GenealogicalDate"
                 },
                 "notes": {
                     "type": "string"
                 },
+                "qualifier": {
+                    "type": "string"
+                },
                 "relationType": {
                     "type": "string"
                 },
                 "startDate": {
-                    "type": "string"
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.GenealogicalDate"
                 },
                 "updatedAt": {
                     "type": "string"
@@ -799,7 +6167,7 @@ const docTemplate = `{
             "properties": {
                 "endDate": {
                     "type": "string",
-                    "example": "2020-12-31T00:00:00Z"
+                    "example": "BET 2019 AND 2020"
                 },
                 "from": {
                     "type": "string",
@@ -807,7 +6175,11 @@ const docTemplate = `{
                 },
                 "notes": {
                     "type": "string",
-                    "example": "Biological parent"
+                    "example": "Adopted after the death of his parents"
+                },
+                "qualifier": {
+                    "type": "string",
+                    "example": "adoptive"
                 },
                 "relationType": {
                     "type": "string",
@@ -815,7 +6187,7 @@ const docTemplate = `{
                 },
                 "startDate": {
                     "type": "string",
-                    "example": "2000-01-01T00:00:00Z"
+                    "example": "2000-01-01"
                 },
                 "to": {
                     "type": "string",
@@ -823,6 +6195,27 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.RelationshipPath": {
+            "type": "object",
+            "properties": {
+                "length": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "persons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Person"
+                    }
+                },
+                "relationships": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Relationship"
+                    }
+                }
+            }
+        },
         "github_com_rogerwesterbo_familytree_pkg_interfaces.RelationshipResponse": {
             "type": "object",
             "properties": {
@@ -839,7 +6232,7 @@ const docTemplate = `{
             "properties": {
                 "endDate": {
                     "type": "string",
-                    "example": "2020-12-31T00:00:00Z"
+                    "example": "BET 2019 AND 2020"
                 },
                 "from": {
                     "type": "string",
@@ -847,7 +6240,11 @@ const docTemplate = `{
                 },
                 "notes": {
                     "type": "string",
-                    "example": "Biological parent"
+                    "example": "Adopted after the death of his parents"
+                },
+                "qualifier": {
+                    "type": "string",
+                    "example": "adoptive"
                 },
                 "relationType": {
                     "type": "string",
@@ -855,7 +6252,7 @@ const docTemplate = `{
                 },
                 "startDate": {
                     "type": "string",
-                    "example": "2000-01-01T00:00:00Z"
+                    "example": "2000-01-01"
                 },
                 "to": {
                     "type": "string",
@@ -876,6 +6273,178 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.Source": {
+            "type": "object",
+            "required": [
+                "title",
+                "type"
+            ],
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "_key": {
+                    "type": "string"
+                },
+                "_rev": {
+                    "type": "string"
+                },
+                "author": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "repository": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.SourceCreateRequest": {
+            "type": "object",
+            "required": [
+                "title",
+                "type"
+            ],
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "Aker parish"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Baptisms and marriages"
+                },
+                "repository": {
+                    "type": "string",
+                    "example": "Arkivverket"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Ministerialbok for Aker prestegjeld 1836-1847"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "church_book"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://www.digitalarkivet.no/"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.SourceResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "source": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Source"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.SourceUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "Aker parish"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Baptisms and marriages"
+                },
+                "repository": {
+                    "type": "string",
+                    "example": "Arkivverket"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Ministerialbok for Aker prestegjeld 1836-1847"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "church_book"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://www.digitalarkivet.no/"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.SourcesListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Source"
+                    }
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.Spouse": {
+            "type": "object",
+            "properties": {
+                "person": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Person"
+                },
+                "relationship": {
+                    "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Relationship"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.SubjectCitationsResponse": {
+            "type": "object",
+            "properties": {
+                "citations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.CitationReference"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "subjectId": {
+                    "type": "string",
+                    "example": "persons/123"
+                }
+            }
+        },
+        "github_com_rogerwesterbo_familytree_pkg_interfaces.TasksListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rogerwesterbo_familytree_pkg_interfaces.Note"
+                    }
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -0,0 +1,82 @@
+package arangorepository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// AttributeTypeRepository implements the AttributeTypeRepository interface using ArangoDB
+type AttributeTypeRepository struct {
+	*BaseRepository[interfaces.AttributeType, *interfaces.AttributeType]
+}
+
+// NewAttributeTypeRepository creates a new attribute type repository
+func NewAttributeTypeRepository(db arangodb.Database, collection arangodb.Collection) *AttributeTypeRepository {
+	return &AttributeTypeRepository{
+		BaseRepository: NewBaseRepository[interfaces.AttributeType, *interfaces.AttributeType](db, collection, "attributeTypes"),
+	}
+}
+
+// FindByName finds the attribute type with the given name, or nil when there is none
+func (r *AttributeTypeRepository) FindByName(ctx context.Context, name string) (*interfaces.AttributeType, error) {
+	query := `
+		FOR attributeType IN attributeTypes
+		FILTER attributeType.name == @name
+		LIMIT 1
+		RETURN attributeType
+	`
+
+	bindVars := map[string]any{
+		"name": name,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query attribute type by name: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	if !cursor.HasMore() {
+		return nil, nil
+	}
+
+	var attributeType interfaces.AttributeType
+	if _, err := cursor.ReadDocument(ctx, &attributeType); err != nil {
+		return nil, fmt.Errorf("failed to read attribute type: %w", err)
+	}
+
+	return &attributeType, nil
+}
+
+// IsUsed reports whether any person has a value for the attribute
+func (r *AttributeTypeRepository) IsUsed(ctx context.Context, name string) (bool, error) {
+	query := `
+		RETURN LENGTH(FOR p IN persons FILTER HAS(p.attributes, @name) LIMIT 1 RETURN 1) > 0
+	`
+
+	bindVars := map[string]any{
+		"name": name,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return false, fmt.Errorf("failed to query persons with attribute: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	var used bool
+	if cursor.HasMore() {
+		if _, err := cursor.ReadDocument(ctx, &used); err != nil {
+			return false, fmt.Errorf("failed to read persons with attribute: %w", err)
+		}
+	}
+
+	return used, nil
+}
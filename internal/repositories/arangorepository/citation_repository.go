@@ -0,0 +1,169 @@
+package arangorepository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// SourceRepository implements the SourceRepository interface using ArangoDB
+type SourceRepository struct {
+	*BaseRepository[interfaces.Source, *interfaces.Source]
+}
+
+// NewSourceRepository creates a new source repository
+func NewSourceRepository(db arangodb.Database, collection arangodb.Collection) *SourceRepository {
+	return &SourceRepository{
+		BaseRepository: NewBaseRepository[interfaces.Source, *interfaces.Source](db, collection, "sources"),
+	}
+}
+
+// IsCited reports whether any citation refers to a source
+func (r *SourceRepository) IsCited(ctx context.Context, sourceID string) (bool, error) {
+	query := `
+		RETURN LENGTH(FOR citation IN citations FILTER citation.sourceId == @sourceID LIMIT 1 RETURN 1) > 0
+	`
+
+	bindVars := map[string]any{
+		"sourceID": sourceID,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return false, fmt.Errorf("failed to query citations of source: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	var cited bool
+	if cursor.HasMore() {
+		if _, err := cursor.ReadDocument(ctx, &cited); err != nil {
+			return false, fmt.Errorf("failed to read citations of source: %w", err)
+		}
+	}
+
+	return cited, nil
+}
+
+// CitationRepository implements the CitationRepository interface using ArangoDB
+type CitationRepository struct {
+	*BaseRepository[interfaces.Citation, *interfaces.Citation]
+}
+
+// NewCitationRepository creates a new citation repository
+func NewCitationRepository(db arangodb.Database, collection arangodb.Collection) *CitationRepository {
+	return &CitationRepository{
+		BaseRepository: NewBaseRepository[interfaces.Citation, *interfaces.Citation](db, collection, "citations"),
+	}
+}
+
+// FindBySubjects finds the citations supporting the given persons, relationships or events, with their sources
+func (r *CitationRepository) FindBySubjects(ctx context.Context, subjectIDs []string) ([]interfaces.CitationReference, error) {
+	query := `
+		FOR link IN supports
+		FILTER link._to IN @subjectIDs
+		LET citation = DOCUMENT(link._from)
+		FILTER citation != null
+		SORT link._to, citation.quality DESC, citation.createdAt
+		RETURN {
+			subjectId: link._to,
+			fact: link.fact,
+			citation: citation,
+			source: DOCUMENT(citation.sourceId)
+		}
+	`
+
+	bindVars := map[string]any{
+		"subjectIDs": subjectIDs,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query citations by subjects: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	var references []interfaces.CitationReference
+	for cursor.HasMore() {
+		var reference interfaces.CitationReference
+		_, err := cursor.ReadDocument(ctx, &reference)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read citation: %w", err)
+		}
+		references = append(references, reference)
+	}
+
+	return references, nil
+}
+
+// CitationLinkRepository implements the CitationLinkRepository interface using ArangoDB
+type CitationLinkRepository struct {
+	*BaseRepository[interfaces.CitationLink, *interfaces.CitationLink]
+}
+
+// NewCitationLinkRepository creates a new citation link repository
+func NewCitationLinkRepository(db arangodb.Database, collection arangodb.Collection) *CitationLinkRepository {
+	return &CitationLinkRepository{
+		BaseRepository: NewBaseRepository[interfaces.CitationLink, *interfaces.CitationLink](db, collection, "supports"),
+	}
+}
+
+// FindByCitations finds the citation link edges of the given citations
+func (r *CitationLinkRepository) FindByCitations(ctx context.Context, citationIDs []string) ([]interfaces.CitationLink, error) {
+	query := `
+		FOR link IN supports
+		FILTER link._from IN @citationIDs
+		SORT link.createdAt
+		RETURN link
+	`
+
+	bindVars := map[string]any{
+		"citationIDs": citationIDs,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query citation links by citations: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	var links []interfaces.CitationLink
+	for cursor.HasMore() {
+		var link interfaces.CitationLink
+		_, err := cursor.ReadDocument(ctx, &link)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read citation link: %w", err)
+		}
+		links = append(links, link)
+	}
+
+	return links, nil
+}
+
+// DeleteByCitation deletes the citation link edges of a citation
+func (r *CitationLinkRepository) DeleteByCitation(ctx context.Context, citationID string) error {
+	query := `
+		FOR link IN supports
+		FILTER link._from == @citationID
+		REMOVE link IN supports
+	`
+
+	bindVars := map[string]any{
+		"citationID": citationID,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return fmt.Errorf("failed to delete links of citation: %w", err)
+	}
+	_ = cursor.Close()
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package arangorepository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// MigrateDates rewrites date attributes stored as strings, such as the RFC 3339 timestamps written before
+// dates became genealogical, into the genealogical date form. Zero timestamps are removed.
+// It returns the number of migrated documents.
+func (r *BaseRepository[T, PT]) MigrateDates(ctx context.Context, fields ...string) (int, error) {
+	conditions := make([]string, 0, len(fields))
+	for _, field := range fields {
+		conditions = append(conditions, fmt.Sprintf("IS_STRING(doc.%s)", field))
+	}
+
+	query := fmt.Sprintf("FOR doc IN %s FILTER %s RETURN doc", r.collectionName, strings.Join(conditions, " OR "))
+	cursor, err := r.db.Query(ctx, query, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query %s with string dates: %w", r.collectionName, err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	var documents []map[string]any
+	for cursor.HasMore() {
+		var document map[string]any
+		if _, err := cursor.ReadDocument(ctx, &document); err != nil {
+			return 0, fmt.Errorf("failed to read %s document: %w", r.collectionName, err)
+		}
+		documents = append(documents, document)
+	}
+
+	update := fmt.Sprintf("UPDATE @key WITH @patch IN %s OPTIONS { keepNull: false }", r.collectionName)
+	for _, document := range documents {
+		patch := make(map[string]any)
+		for _, field := range fields {
+			value, ok := document[field].(string)
+			if !ok {
+				continue
+			}
+
+			date, err := interfaces.ParseGenealogicalDate(value)
+			if err != nil {
+				return 0, fmt.Errorf("failed to migrate %s of %v: %w", field, document["_id"], err)
+			}
+			if date.IsZero() {
+				patch[field] = nil
+			} else {
+				patch[field] = date
+			}
+		}
+
+		bindVars := map[string]any{
+			"key":   document["_key"],
+			"patch": patch,
+		}
+
+		updateCursor, err := r.db.Query(ctx, update, &arangodb.QueryOptions{BindVars: bindVars})
+		if err != nil {
+			return 0, fmt.Errorf("failed to migrate %v: %w", document["_id"], err)
+		}
+		_ = updateCursor.Close()
+	}
+
+	return len(documents), nil
+}
@@ -0,0 +1,186 @@
+package arangorepository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// EventRepository implements the EventRepository interface using ArangoDB
+type EventRepository struct {
+	*BaseRepository[interfaces.Event, *interfaces.Event]
+}
+
+// NewEventRepository creates a new event repository
+func NewEventRepository(db arangodb.Database, collection arangodb.Collection) *EventRepository {
+	return &EventRepository{
+		BaseRepository: NewBaseRepository[interfaces.Event, *interfaces.Event](db, collection, "events"),
+	}
+}
+
+// FindByPerson finds the events a person takes part in, ordered by date
+func (r *EventRepository) FindByPerson(ctx context.Context, personID string) ([]interfaces.Event, error) {
+	query := `
+		LET eventIDs = UNIQUE(
+			FOR part IN participants
+			FILTER part._from == @personID
+			RETURN part._to
+		)
+		FOR event IN events
+		FILTER event._id IN eventIDs
+		SORT event.date.sortKey, event.createdAt
+		RETURN event
+	`
+
+	bindVars := map[string]any{
+		"personID": personID,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events by person: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	var events []interfaces.Event
+	for cursor.HasMore() {
+		var event interfaces.Event
+		_, err := cursor.ReadDocument(ctx, &event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// MigratePlaces moves the place names of events stored before events referred to places by ID into a place ID,
+// resolving each name with resolve. Events that already have a place ID keep it. It returns the number of
+// migrated events.
+func (r *EventRepository) MigratePlaces(ctx context.Context, resolve func(ctx context.Context, name string) (string, error)) (int, error) {
+	query := `
+		FOR event IN events
+		FILTER HAS(event, "place")
+		RETURN { key: event._key, place: event.place, placeId: event.placeId }
+	`
+
+	cursor, err := r.db.Query(ctx, query, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query event places: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	type eventPlace struct {
+		Key     string `json:"key"`
+		Place   string `json:"place"`
+		PlaceID string `json:"placeId"`
+	}
+	var migrations []eventPlace
+	for cursor.HasMore() {
+		var event eventPlace
+		if _, err := cursor.ReadDocument(ctx, &event); err != nil {
+			return 0, fmt.Errorf("failed to read event place: %w", err)
+		}
+		if event.PlaceID == "" {
+			if event.PlaceID, err = resolve(ctx, event.Place); err != nil {
+				return 0, fmt.Errorf("failed to resolve place %q of event %s: %w", event.Place, event.Key, err)
+			}
+		}
+		migrations = append(migrations, event)
+	}
+	if len(migrations) == 0 {
+		return 0, nil
+	}
+
+	update := `
+		FOR migration IN @migrations
+		UPDATE migration.key WITH { place: null, placeId: migration.placeId == "" ? null : migration.placeId }
+		IN events OPTIONS { keepNull: false }
+	`
+
+	bindVars := map[string]any{
+		"migrations": migrations,
+	}
+
+	updateCursor, err := r.db.Query(ctx, update, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return 0, fmt.Errorf("failed to migrate event places: %w", err)
+	}
+	_ = updateCursor.Close()
+
+	return len(migrations), nil
+}
+
+// ParticipantRepository implements the ParticipantRepository interface using ArangoDB
+type ParticipantRepository struct {
+	*BaseRepository[interfaces.Participant, *interfaces.Participant]
+}
+
+// NewParticipantRepository creates a new participant repository
+func NewParticipantRepository(db arangodb.Database, collection arangodb.Collection) *ParticipantRepository {
+	return &ParticipantRepository{
+		BaseRepository: NewBaseRepository[interfaces.Participant, *interfaces.Participant](db, collection, "participants"),
+	}
+}
+
+// FindByEvents finds the participant edges of the given events
+func (r *ParticipantRepository) FindByEvents(ctx context.Context, eventIDs []string) ([]interfaces.Participant, error) {
+	query := `
+		FOR part IN participants
+		FILTER part._to IN @eventIDs
+		SORT part.createdAt
+		RETURN part
+	`
+
+	bindVars := map[string]any{
+		"eventIDs": eventIDs,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query participants by events: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	var participants []interfaces.Participant
+	for cursor.HasMore() {
+		var participant interfaces.Participant
+		_, err := cursor.ReadDocument(ctx, &participant)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read participant: %w", err)
+		}
+		participants = append(participants, participant)
+	}
+
+	return participants, nil
+}
+
+// DeleteByEvent deletes the participant edges of an event
+func (r *ParticipantRepository) DeleteByEvent(ctx context.Context, eventID string) error {
+	query := `
+		FOR part IN participants
+		FILTER part._to == @eventID
+		REMOVE part IN participants
+	`
+
+	bindVars := map[string]any{
+		"eventID": eventID,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return fmt.Errorf("failed to delete participants of event: %w", err)
+	}
+	_ = cursor.Close()
+
+	return nil
+}
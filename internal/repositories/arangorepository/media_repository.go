@@ -0,0 +1,124 @@
+package arangorepository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// MediaRepository implements the MediaRepository interface using ArangoDB
+type MediaRepository struct {
+	*BaseRepository[interfaces.Media, *interfaces.Media]
+}
+
+// NewMediaRepository creates a new media repository
+func NewMediaRepository(db arangodb.Database, collection arangodb.Collection) *MediaRepository {
+	return &MediaRepository{
+		BaseRepository: NewBaseRepository[interfaces.Media, *interfaces.Media](db, collection, "media"),
+	}
+}
+
+// FindBySubject finds the media attached to a person, event or source, oldest first
+func (r *MediaRepository) FindBySubject(ctx context.Context, subjectID string) ([]interfaces.Media, error) {
+	query := `
+		FOR link IN attachments
+		FILTER link._to == @subjectID
+		LET media = DOCUMENT(link._from)
+		FILTER media != null
+		SORT media.date.sortKey, media.createdAt
+		RETURN media
+	`
+
+	bindVars := map[string]any{
+		"subjectID": subjectID,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query media by subject: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	var media []interfaces.Media
+	for cursor.HasMore() {
+		var item interfaces.Media
+		_, err := cursor.ReadDocument(ctx, &item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read media: %w", err)
+		}
+		media = append(media, item)
+	}
+
+	return media, nil
+}
+
+// MediaLinkRepository implements the MediaLinkRepository interface using ArangoDB
+type MediaLinkRepository struct {
+	*BaseRepository[interfaces.MediaLink, *interfaces.MediaLink]
+}
+
+// NewMediaLinkRepository creates a new media link repository
+func NewMediaLinkRepository(db arangodb.Database, collection arangodb.Collection) *MediaLinkRepository {
+	return &MediaLinkRepository{
+		BaseRepository: NewBaseRepository[interfaces.MediaLink, *interfaces.MediaLink](db, collection, "attachments"),
+	}
+}
+
+// FindByMedia finds the media link edges of the given media
+func (r *MediaLinkRepository) FindByMedia(ctx context.Context, mediaIDs []string) ([]interfaces.MediaLink, error) {
+	query := `
+		FOR link IN attachments
+		FILTER link._from IN @mediaIDs
+		SORT link.createdAt
+		RETURN link
+	`
+
+	bindVars := map[string]any{
+		"mediaIDs": mediaIDs,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query media links by media: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	var links []interfaces.MediaLink
+	for cursor.HasMore() {
+		var link interfaces.MediaLink
+		_, err := cursor.ReadDocument(ctx, &link)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read media link: %w", err)
+		}
+		links = append(links, link)
+	}
+
+	return links, nil
+}
+
+// DeleteByMedia deletes the media link edges of a media file
+func (r *MediaLinkRepository) DeleteByMedia(ctx context.Context, mediaID string) error {
+	query := `
+		FOR link IN attachments
+		FILTER link._from == @mediaID
+		REMOVE link IN attachments
+	`
+
+	bindVars := map[string]any{
+		"mediaID": mediaID,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return fmt.Errorf("failed to delete links of media: %w", err)
+	}
+	_ = cursor.Close()
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package arangorepository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+)
+
+// runOnce runs a data migration that must only be applied once, such as one that guesses at what users meant,
+// and records it in the migrations collection under name. A migration recorded before is skipped and reports 0.
+func runOnce(ctx context.Context, db arangodb.Database, name string, migrate func() (int, error)) (int, error) {
+	query := `
+		FOR migration IN migrations
+		FILTER migration._key == @name
+		RETURN 1
+	`
+
+	cursor, err := db.Query(ctx, query, &arangodb.QueryOptions{BindVars: map[string]any{"name": name}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to query migration %s: %w", name, err)
+	}
+	done := cursor.HasMore()
+	_ = cursor.Close()
+	if done {
+		return 0, nil
+	}
+
+	migrated, err := migrate()
+	if err != nil {
+		return 0, err
+	}
+
+	record := `
+		INSERT { _key: @name, migrated: @migrated, appliedAt: DATE_ISO8601(DATE_NOW()) } INTO migrations
+	`
+
+	bindVars := map[string]any{
+		"name":     name,
+		"migrated": migrated,
+	}
+
+	cursor, err = db.Query(ctx, record, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return 0, fmt.Errorf("failed to record migration %s: %w", name, err)
+	}
+	_ = cursor.Close()
+
+	return migrated, nil
+}
@@ -0,0 +1,94 @@
+package arangorepository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// NoteRepository implements the NoteRepository interface using ArangoDB
+type NoteRepository struct {
+	*BaseRepository[interfaces.Note, *interfaces.Note]
+}
+
+// NewNoteRepository creates a new note repository
+func NewNoteRepository(db arangodb.Database, collection arangodb.Collection) *NoteRepository {
+	return &NoteRepository{
+		BaseRepository: NewBaseRepository[interfaces.Note, *interfaces.Note](db, collection, "notes"),
+	}
+}
+
+// FindBySubject finds the notes attached to a person, relationship or event, oldest first
+func (r *NoteRepository) FindBySubject(ctx context.Context, subjectID string) ([]interfaces.Note, error) {
+	query := `
+		FOR note IN notes
+		FILTER note.subjectId == @subjectID
+		SORT note.createdAt
+		RETURN note
+	`
+
+	bindVars := map[string]any{
+		"subjectID": subjectID,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notes by subject: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	var notes []interfaces.Note
+	for cursor.HasMore() {
+		var note interfaces.Note
+		_, err := cursor.ReadDocument(ctx, &note)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read note: %w", err)
+		}
+		notes = append(notes, note)
+	}
+
+	return notes, nil
+}
+
+// FindTasks finds the research tasks with one of the statuses, optionally only those assigned to assignee
+// or attached to subjectID. Tasks are ordered by due date, with tasks without one last.
+func (r *NoteRepository) FindTasks(ctx context.Context, statuses []string, assignee, subjectID string) ([]interfaces.Note, error) {
+	query := `
+		FOR note IN notes
+		FILTER note.status IN @statuses
+		FILTER @assignee == "" OR note.assignee == @assignee
+		FILTER @subjectID == "" OR note.subjectId == @subjectID
+		SORT note.dueDate == null, note.dueDate.sortKey, note.createdAt
+		RETURN note
+	`
+
+	bindVars := map[string]any{
+		"statuses":  statuses,
+		"assignee":  assignee,
+		"subjectID": subjectID,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tasks: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	var tasks []interfaces.Note
+	for cursor.HasMore() {
+		var task interfaces.Note
+		_, err := cursor.ReadDocument(ctx, &task)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
@@ -3,8 +3,12 @@ package arangorepository
 import (
 	"context"
 	"fmt"
+	"maps"
+	"strings"
+	"time"
 
 	"github.com/arangodb/go-driver/v2/arangodb"
+	"github.com/arangodb/go-driver/v2/arangodb/shared"
 	"github.com/rogerwesterbo/familytree/pkg/interfaces"
 )
 
@@ -20,23 +24,93 @@ func NewPersonRepository(db arangodb.Database, collection arangodb.Collection) *
 	}
 }
 
-// FindByName finds persons by first name and/or last name
-func (r *PersonRepository) FindByName(ctx context.Context, firstName, lastName string) ([]interfaces.Person, error) {
+// Update updates an existing person. Attributes set to nil are removed from the stored person.
+func (r *PersonRepository) Update(ctx context.Context, id string, person *interfaces.Person) error {
+	now := time.Now()
+	person.SetTimestamps(person.GetUpdatedAt(), now)
+
+	keepNull := false
+	meta, err := r.collection.UpdateDocumentWithOptions(ctx, id, person, &arangodb.CollectionDocumentUpdateOptions{
+		KeepNull: &keepNull,
+	})
+	if err != nil {
+		if shared.IsNotFound(err) {
+			return fmt.Errorf("entity not found: %w", err)
+		}
+		return fmt.Errorf("failed to update entity: %w", err)
+	}
+
+	person.SetMetadata(meta.Key, string(meta.ID), meta.Rev)
+	maps.DeleteFunc(person.Attributes, func(_ string, value any) bool { return value == nil })
+
+	return nil
+}
+
+// Search finds persons with a name matching the given name and/or surname and with all the given attribute values.
+// Both names have to match the same name, so a birth name and a farm name are never combined.
+func (r *PersonRepository) Search(ctx context.Context, firstName, lastName string, attributes []interfaces.AttributeFilter) ([]interfaces.Person, error) {
+	var query strings.Builder
+	query.WriteString(`
+		FOR p IN persons
+		FILTER (@firstName == "" AND @lastName == "")
+		   OR LENGTH(p.names[* FILTER (@firstName == "" || CURRENT.givenName == @firstName)
+		      AND (@lastName == "" || CURRENT.surname == @lastName)]) > 0
+	`)
+
+	bindVars := map[string]any{
+		"firstName": firstName,
+		"lastName":  lastName,
+	}
+
+	for i, attribute := range attributes {
+		nameVar, valueVar := fmt.Sprintf("attribute%d", i), fmt.Sprintf("value%d", i)
+		if date, ok := attribute.Value.(interfaces.GenealogicalDate); ok {
+			fmt.Fprintf(&query, "FILTER p.attributes[@%s].value == @%s\n", nameVar, valueVar)
+			bindVars[valueVar] = date.String()
+		} else {
+			fmt.Fprintf(&query, "FILTER p.attributes[@%s] == @%s\n", nameVar, valueVar)
+			bindVars[valueVar] = attribute.Value
+		}
+		bindVars[nameVar] = attribute.Name
+	}
+	query.WriteString("RETURN p")
+
+	cursor, err := r.db.Query(ctx, query.String(), &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search persons: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	var persons []interfaces.Person
+	for cursor.HasMore() {
+		var person interfaces.Person
+		_, err := cursor.ReadDocument(ctx, &person)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read person: %w", err)
+		}
+		persons = append(persons, person)
+	}
+
+	return persons, nil
+}
+
+// FindByIDs finds the persons with the given document IDs
+func (r *PersonRepository) FindByIDs(ctx context.Context, ids []string) ([]interfaces.Person, error) {
 	query := `
 		FOR p IN persons
-		FILTER (@firstName == "" || p.firstName == @firstName)
-		   AND (@lastName == "" || p.lastName == @lastName)
+		FILTER p._id IN @ids
 		RETURN p
 	`
 
 	bindVars := map[string]any{
-		"firstName": firstName,
-		"lastName":  lastName,
+		"ids": ids,
 	}
 
 	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
 	if err != nil {
-		return nil, fmt.Errorf("failed to query persons by name: %w", err)
+		return nil, fmt.Errorf("failed to query persons by IDs: %w", err)
 	}
 	defer func() {
 		_ = cursor.Close()
@@ -54,3 +128,39 @@ func (r *PersonRepository) FindByName(ctx context.Context, firstName, lastName s
 
 	return persons, nil
 }
+
+// MigrateNames gives every person stored before persons had several names their first and last name
+// as preferred birth name. It returns the number of migrated persons.
+func (r *PersonRepository) MigrateNames(ctx context.Context) (int, error) {
+	query := `
+		FOR p IN persons
+		FILTER p.names == null OR LENGTH(p.names) == 0
+		UPDATE p WITH {
+			names: [{ givenName: p.firstName, surname: p.lastName, type: @type, preferred: true }]
+		} IN persons
+		RETURN 1
+	`
+
+	bindVars := map[string]any{
+		"type": interfaces.NameTypeBirth,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return 0, fmt.Errorf("failed to migrate person names: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	migrated := 0
+	for cursor.HasMore() {
+		var one int
+		if _, err := cursor.ReadDocument(ctx, &one); err != nil {
+			return 0, fmt.Errorf("failed to read migrated person: %w", err)
+		}
+		migrated++
+	}
+
+	return migrated, nil
+}
@@ -0,0 +1,113 @@
+package arangorepository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// PlaceRepository implements the PlaceRepository interface using ArangoDB
+type PlaceRepository struct {
+	*BaseRepository[interfaces.Place, *interfaces.Place]
+}
+
+// NewPlaceRepository creates a new place repository
+func NewPlaceRepository(db arangodb.Database, collection arangodb.Collection) *PlaceRepository {
+	return &PlaceRepository{
+		BaseRepository: NewBaseRepository[interfaces.Place, *interfaces.Place](db, collection, "places"),
+	}
+}
+
+// FindByName finds places whose name or one of whose alternate names contains the given text, ignoring case
+func (r *PlaceRepository) FindByName(ctx context.Context, name string) ([]interfaces.Place, error) {
+	query := `
+		FOR place IN places
+		FILTER CONTAINS(LOWER(place.name), LOWER(@name))
+		   OR LENGTH(place.alternateNames[* FILTER CONTAINS(LOWER(CURRENT.name), LOWER(@name))]) > 0
+		SORT place.name
+		RETURN place
+	`
+
+	bindVars := map[string]any{
+		"name": name,
+	}
+
+	return r.queryPlaces(ctx, query, bindVars, "failed to query places by name")
+}
+
+// FindChildren finds the places directly within a parent place
+func (r *PlaceRepository) FindChildren(ctx context.Context, parentID string) ([]interfaces.Place, error) {
+	query := `
+		FOR place IN places
+		FILTER place.parentId == @parentID
+		SORT place.name
+		RETURN place
+	`
+
+	bindVars := map[string]any{
+		"parentID": parentID,
+	}
+
+	return r.queryPlaces(ctx, query, bindVars, "failed to query child places")
+}
+
+// IsReferenced reports whether a place is the parent of another place or is referred to by a person or an event
+func (r *PlaceRepository) IsReferenced(ctx context.Context, placeID string) (bool, error) {
+	query := `
+		RETURN LENGTH(FOR place IN places FILTER place.parentId == @placeID LIMIT 1 RETURN 1) > 0
+		    OR LENGTH(FOR event IN events FILTER event.placeId == @placeID LIMIT 1 RETURN 1) > 0
+		    OR LENGTH(
+		        FOR person IN persons
+		        FILTER person.birthPlaceId == @placeID OR person.deathPlaceId == @placeID
+		           OR @placeID IN VALUES(person.attributes || {})
+		        LIMIT 1
+		        RETURN 1
+		    ) > 0
+	`
+
+	bindVars := map[string]any{
+		"placeID": placeID,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return false, fmt.Errorf("failed to query place references: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	var referenced bool
+	if cursor.HasMore() {
+		if _, err := cursor.ReadDocument(ctx, &referenced); err != nil {
+			return false, fmt.Errorf("failed to read place references: %w", err)
+		}
+	}
+
+	return referenced, nil
+}
+
+// queryPlaces runs a query returning places
+func (r *PlaceRepository) queryPlaces(ctx context.Context, query string, bindVars map[string]any, errorMessage string) ([]interfaces.Place, error) {
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errorMessage, err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	var places []interfaces.Place
+	for cursor.HasMore() {
+		var place interfaces.Place
+		_, err := cursor.ReadDocument(ctx, &place)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read place: %w", err)
+		}
+		places = append(places, place)
+	}
+
+	return places, nil
+}
@@ -3,21 +3,81 @@ package arangorepository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/arangodb/go-driver/v2/arangodb"
+	"github.com/arangodb/go-driver/v2/arangodb/shared"
 	"github.com/rogerwesterbo/familytree/pkg/interfaces"
 )
 
+// Traversal step conditions for the edge e leading to the vertex v.
+// A parent edge points from the parent to the child, a child edge from the child to the parent.
+const (
+	aqlStepToParent = `((e.relationType == @parentType AND e._from == v._id) OR (e.relationType == @childType AND e._to == v._id))`
+	aqlStepToChild  = `((e.relationType == @parentType AND e._to == v._id) OR (e.relationType == @childType AND e._from == v._id))`
+	// aqlStepQualified holds when no qualifiers are asked for or the edge has one of them, an edge without one being biological
+	aqlStepQualified = `(LENGTH(@qualifiers) == 0 OR (e.qualifier || @biological) IN @qualifiers)`
+	// aqlParentEdgesOnly keeps a traversal on parent and child edges. The optimizer applies it while traversing,
+	// so with global uniqueness a spouse or sibling edge can't reach a person before their parent edges do.
+	aqlParentEdgesOnly = `p.edges[*].relationType ALL IN [@parentType, @childType]`
+)
+
 // RelationshipRepository implements the RelationshipRepository interface using ArangoDB
+// Relationships are written through the family graph and traversed with GRAPH queries
 type RelationshipRepository struct {
 	*BaseRepository[interfaces.Relationship, *interfaces.Relationship]
+	graph arangodb.Graph
 }
 
 // NewRelationshipRepository creates a new relationship repository
-func NewRelationshipRepository(db arangodb.Database, collection arangodb.Collection) *RelationshipRepository {
+func NewRelationshipRepository(db arangodb.Database, collection arangodb.Collection, graph arangodb.Graph) *RelationshipRepository {
 	return &RelationshipRepository{
 		BaseRepository: NewBaseRepository[interfaces.Relationship, *interfaces.Relationship](db, collection, "relationships"),
+		graph:          graph,
+	}
+}
+
+// Create creates a new relationship through the family graph, so the server checks it against the edge definition
+func (r *RelationshipRepository) Create(ctx context.Context, relationship *interfaces.Relationship) error {
+	now := time.Now()
+	relationship.SetTimestamps(now, now)
+
+	edges, err := r.graph.EdgeDefinition(ctx, r.collectionName)
+	if err != nil {
+		return fmt.Errorf("failed to get relationships edge definition: %w", err)
+	}
+
+	meta, err := edges.CreateEdge(ctx, relationship, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create entity: %w", err)
+	}
+
+	relationship.SetMetadata(meta.Key, string(meta.ID), meta.Rev)
+
+	return nil
+}
+
+// Update updates an existing relationship through the family graph, so the server checks it against the edge definition
+func (r *RelationshipRepository) Update(ctx context.Context, id string, relationship *interfaces.Relationship) error {
+	now := time.Now()
+	relationship.SetTimestamps(relationship.GetUpdatedAt(), now)
+
+	edges, err := r.graph.EdgeDefinition(ctx, r.collectionName)
+	if err != nil {
+		return fmt.Errorf("failed to get relationships edge definition: %w", err)
 	}
+
+	meta, err := edges.UpdateEdge(ctx, id, relationship, nil)
+	if err != nil {
+		if shared.IsNotFound(err) {
+			return fmt.Errorf("entity not found: %w", err)
+		}
+		return fmt.Errorf("failed to update entity: %w", err)
+	}
+
+	relationship.SetMetadata(meta.Key, string(meta.ID), meta.Rev)
+
+	return nil
 }
 
 // FindByPerson finds all relationships for a person (as either from or to)
@@ -85,3 +145,441 @@ func (r *RelationshipRepository) FindByType(ctx context.Context, relationType st
 
 	return relationships, nil
 }
+
+// FindFamilyEdges finds the parent, child and spouse edges of a person, their parents and their children,
+// which is every edge needed to derive the families the person is a partner or a child in
+func (r *RelationshipRepository) FindFamilyEdges(ctx context.Context, personID string) ([]interfaces.Relationship, error) {
+	query := `
+		LET relatives = (
+			FOR v, e IN 1..1 ANY @personID GRAPH @graphName
+			FILTER e.relationType IN [@parentType, @childType]
+			RETURN v._id
+		)
+		FOR memberID IN UNIQUE(APPEND([@personID], relatives))
+		FOR v, e IN 1..1 ANY memberID GRAPH @graphName
+		FILTER e.relationType IN [@parentType, @childType, @spouseType]
+		RETURN DISTINCT e
+	`
+
+	bindVars := map[string]any{
+		"graphName":  r.graph.Name(),
+		"personID":   personID,
+		"parentType": interfaces.RelationTypeParent,
+		"childType":  interfaces.RelationTypeChild,
+		"spouseType": interfaces.RelationTypeSpouse,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query family edges: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	var relationships []interfaces.Relationship
+	for cursor.HasMore() {
+		var relationship interfaces.Relationship
+		_, err := cursor.ReadDocument(ctx, &relationship)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read relationship: %w", err)
+		}
+		relationships = append(relationships, relationship)
+	}
+
+	return relationships, nil
+}
+
+// FindAncestors walks parent edges upward from a person, up to maxDepth generations.
+// The traversal follows both edge directions of the family graph and prunes every step that does not lead to a parent,
+// or to a parent with one of the qualifiers when they are given.
+// Each person is visited once, breadth first, so ancestors reached through several paths are returned once,
+// with the shortest path, and the search stays linear in the size of the tree.
+func (r *RelationshipRepository) FindAncestors(ctx context.Context, personID string, maxDepth int, qualifiers []string) ([]interfaces.Ancestor, error) {
+	query := `
+		FOR v, e, p IN 1..@maxDepth ANY @personID GRAPH @graphName
+		OPTIONS { order: "bfs", uniqueVertices: "global" }
+		PRUNE e != null AND NOT (` + aqlStepToParent + ` AND ` + aqlStepQualified + `)
+		FILTER ` + aqlParentEdgesOnly + `
+		FILTER ` + aqlStepToParent + ` AND ` + aqlStepQualified + `
+		SORT LENGTH(p.edges), v.lastName, v.firstName
+		RETURN { person: v, generation: LENGTH(p.edges), path: p.edges }
+	`
+
+	bindVars := map[string]any{
+		"graphName":  r.graph.Name(),
+		"personID":   personID,
+		"maxDepth":   maxDepth,
+		"parentType": interfaces.RelationTypeParent,
+		"childType":  interfaces.RelationTypeChild,
+		"qualifiers": qualifiers,
+		"biological": interfaces.RelationQualifierBiological,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ancestors: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	var ancestors []interfaces.Ancestor
+	for cursor.HasMore() {
+		var ancestor interfaces.Ancestor
+		_, err := cursor.ReadDocument(ctx, &ancestor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ancestor: %w", err)
+		}
+		ancestors = append(ancestors, ancestor)
+	}
+
+	return ancestors, nil
+}
+
+// FindAncestorLinks finds every distinct child-to-parent step above a person, up to maxDepth generations.
+// Each person is visited once, breadth first, and the parent steps of everyone reached are returned, so unlike
+// FindAncestors an ancestor reached through several paths is linked to every child on those paths.
+// When qualifiers are given, only parent edges with one of them are followed.
+func (r *RelationshipRepository) FindAncestorLinks(ctx context.Context, personID string, maxDepth int, qualifiers []string) ([]interfaces.AncestorLink, error) {
+	query := `
+		LET children = (
+			FOR v, e, p IN 1..@maxDepth ANY @personID GRAPH @graphName
+			OPTIONS { order: "bfs", uniqueVertices: "global" }
+			PRUNE e != null AND NOT (` + aqlStepToParent + ` AND ` + aqlStepQualified + `)
+			FILTER ` + aqlParentEdgesOnly + `
+			FILTER ` + aqlStepToParent + ` AND ` + aqlStepQualified + `
+			FILTER LENGTH(p.edges) < @maxDepth
+			RETURN v._id
+		)
+		FOR childID IN APPEND([@personID], children)
+		FOR v, e IN 1..1 ANY childID GRAPH @graphName
+		FILTER ` + aqlStepToParent + ` AND ` + aqlStepQualified + `
+		RETURN DISTINCT { childId: childID, person: v, relationship: e }
+	`
+
+	bindVars := map[string]any{
+		"graphName":  r.graph.Name(),
+		"personID":   personID,
+		"maxDepth":   maxDepth,
+		"parentType": interfaces.RelationTypeParent,
+		"childType":  interfaces.RelationTypeChild,
+		"qualifiers": qualifiers,
+		"biological": interfaces.RelationQualifierBiological,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ancestor links: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	var links []interfaces.AncestorLink
+	for cursor.HasMore() {
+		var link interfaces.AncestorLink
+		_, err := cursor.ReadDocument(ctx, &link)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ancestor link: %w", err)
+		}
+		links = append(links, link)
+	}
+
+	return links, nil
+}
+
+// FindDuplicatedAncestors finds the ancestors of a person reached through more than one path, up to maxDepth generations.
+// Paths are compared by the persons they pass, so a parent recorded with both a parent and a child edge counts once.
+// When qualifiers are given, only parent edges with one of them are followed.
+// Every path is enumerated, so at most maxPaths paths are followed; complete is false when there are more.
+func (r *RelationshipRepository) FindDuplicatedAncestors(ctx context.Context, personID string, maxDepth, maxPaths int, qualifiers []string) ([]interfaces.AncestorPaths, bool, error) {
+	query := `
+		LET found = (
+			FOR v, e, p IN 1..@maxDepth ANY @personID GRAPH @graphName
+			OPTIONS { order: "bfs", uniqueVertices: "path" }
+			PRUNE e != null AND NOT (` + aqlStepToParent + ` AND ` + aqlStepQualified + `)
+			FILTER ` + aqlParentEdgesOnly + `
+			FILTER ` + aqlStepToParent + ` AND ` + aqlStepQualified + `
+			LIMIT @maxPaths + 1
+			RETURN { person: v, path: p.vertices[*]._id }
+		)
+		LET ancestors = (
+			FOR f IN found
+			COLLECT ancestorID = f.person._id INTO group = f
+			LET paths = UNIQUE(group[*].path)
+			FILTER LENGTH(paths) > 1
+			SORT LENGTH(paths) DESC, ancestorID
+			RETURN { person: FIRST(group).person, paths: paths }
+		)
+		RETURN { complete: LENGTH(found) <= @maxPaths, ancestors: ancestors }
+	`
+
+	bindVars := map[string]any{
+		"graphName":  r.graph.Name(),
+		"personID":   personID,
+		"maxDepth":   maxDepth,
+		"maxPaths":   maxPaths,
+		"parentType": interfaces.RelationTypeParent,
+		"childType":  interfaces.RelationTypeChild,
+		"qualifiers": qualifiers,
+		"biological": interfaces.RelationQualifierBiological,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query duplicated ancestors: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	var result struct {
+		Complete  bool                       `json:"complete"`
+		Ancestors []interfaces.AncestorPaths `json:"ancestors"`
+	}
+	if _, err := cursor.ReadDocument(ctx, &result); err != nil {
+		return nil, false, fmt.Errorf("failed to read duplicated ancestors: %w", err)
+	}
+
+	return result.Ancestors, result.Complete, nil
+}
+
+// FindDescendants walks parent edges downward from a person, up to maxDepth generations.
+// Each person is visited once, breadth first, and the result holds the parent/child steps of everyone reached,
+// so a descendant of two parents in the tree (e.g. after a cousin marriage) appears under both of them.
+// When qualifiers are given, only parent edges with one of them are followed.
+func (r *RelationshipRepository) FindDescendants(ctx context.Context, personID string, maxDepth int, includeSpouses bool, qualifiers []string) (*interfaces.DescendantGraph, error) {
+	query := `
+		LET parents = (
+			FOR v, e, p IN 1..@maxDepth ANY @personID GRAPH @graphName
+			OPTIONS { order: "bfs", uniqueVertices: "global" }
+			PRUNE e != null AND NOT (` + aqlStepToChild + ` AND ` + aqlStepQualified + `)
+			FILTER ` + aqlParentEdgesOnly + `
+			FILTER ` + aqlStepToChild + ` AND ` + aqlStepQualified + `
+			FILTER LENGTH(p.edges) < @maxDepth
+			RETURN v._id
+		)
+		LET links = (
+			FOR parentID IN APPEND([@personID], parents)
+			FOR v, e IN 1..1 ANY parentID GRAPH @graphName
+			FILTER ` + aqlStepToChild + ` AND ` + aqlStepQualified + `
+			RETURN DISTINCT { parentId: parentID, person: v, relationship: e }
+		)
+		LET spouses = (
+			FILTER @includeSpouses
+			FOR memberID IN UNIQUE(APPEND([@personID], links[*].person._id))
+			FOR s, se IN 1..1 ANY memberID GRAPH @graphName
+			FILTER se.relationType == @spouseType
+			RETURN { personId: memberID, spouse: s, relationship: se }
+		)
+		RETURN { links: links, spouses: spouses }
+	`
+
+	bindVars := map[string]any{
+		"graphName":      r.graph.Name(),
+		"personID":       personID,
+		"maxDepth":       maxDepth,
+		"includeSpouses": includeSpouses,
+		"parentType":     interfaces.RelationTypeParent,
+		"childType":      interfaces.RelationTypeChild,
+		"spouseType":     interfaces.RelationTypeSpouse,
+		"qualifiers":     qualifiers,
+		"biological":     interfaces.RelationQualifierBiological,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query descendants: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	var graph interfaces.DescendantGraph
+	if _, err := cursor.ReadDocument(ctx, &graph); err != nil {
+		return nil, fmt.Errorf("failed to read descendants: %w", err)
+	}
+
+	return &graph, nil
+}
+
+// FindPaths finds up to k shortest paths between two persons over any relationship edge,
+// ignoring edge direction. The paths are ordered from shortest to longest.
+func (r *RelationshipRepository) FindPaths(ctx context.Context, fromID, toID string, k int) ([]interfaces.RelationshipPath, error) {
+	query := `
+		FOR p IN ANY K_SHORTEST_PATHS @fromID TO @toID GRAPH @graphName
+		LIMIT @k
+		RETURN { persons: p.vertices, relationships: p.edges, length: LENGTH(p.edges) }
+	`
+
+	bindVars := map[string]any{
+		"graphName": r.graph.Name(),
+		"fromID":    fromID,
+		"toID":      toID,
+		"k":         k,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query relationship paths: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	var paths []interfaces.RelationshipPath
+	for cursor.HasMore() {
+		var path interfaces.RelationshipPath
+		_, err := cursor.ReadDocument(ctx, &path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read relationship path: %w", err)
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+// FindAncestorPath finds the shortest chain of parent edges leading from a person up to one of their ancestors,
+// leaving out the relationship with excludeID. It returns an empty path when ancestorID is not an ancestor.
+// Every path up the tree is followed, as a person first reached through another path must not hide the ancestor,
+// so the search grows with the number of paths and maxDepth should be kept small.
+func (r *RelationshipRepository) FindAncestorPath(ctx context.Context, personID, ancestorID string, maxDepth int, excludeID string) ([]interfaces.Relationship, error) {
+	query := `
+		FOR v, e, p IN 1..@maxDepth ANY @personID GRAPH @graphName
+		OPTIONS { order: "bfs", uniqueVertices: "path" }
+		PRUNE e != null AND (e._id == @excludeID OR v._id == @ancestorID OR NOT ` + aqlStepToParent + `)
+		FILTER ` + aqlParentEdgesOnly + `
+		FILTER v._id == @ancestorID AND ` + aqlStepToParent + ` AND e._id != @excludeID
+		LIMIT 1
+		RETURN p.edges
+	`
+
+	bindVars := map[string]any{
+		"graphName":  r.graph.Name(),
+		"personID":   personID,
+		"ancestorID": ancestorID,
+		"maxDepth":   maxDepth,
+		"excludeID":  excludeID,
+		"parentType": interfaces.RelationTypeParent,
+		"childType":  interfaces.RelationTypeChild,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ancestor path: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	var path []interfaces.Relationship
+	if cursor.HasMore() {
+		if _, err := cursor.ReadDocument(ctx, &path); err != nil {
+			return nil, fmt.Errorf("failed to read ancestor path: %w", err)
+		}
+	}
+
+	return path, nil
+}
+
+// FindSpousesAndDescendants finds the spouses and the descendants, up to maxDepth generations, of each of the persons,
+// keyed by person ID. Only the dates of the relatives are read, and a descendant reached through several paths is
+// returned once, with the fewest generations.
+func (r *RelationshipRepository) FindSpousesAndDescendants(ctx context.Context, personIDs []string, maxDepth int) (map[string][]interfaces.Relative, error) {
+	query := `
+		FOR personID IN @personIDs
+		LET spouses = (
+			FOR v, e IN 1..1 ANY personID GRAPH @graphName
+			FILTER e.relationType == @spouseType
+			RETURN { person: KEEP(v, "_id", "birthDate", "deathDate"), generation: 0 }
+		)
+		LET descendants = (
+			FOR v, e, p IN 1..@maxDepth ANY personID GRAPH @graphName
+			OPTIONS { order: "bfs", uniqueVertices: "global" }
+			PRUNE e != null AND NOT ` + aqlStepToChild + `
+			FILTER ` + aqlStepToChild + `
+			RETURN { person: KEEP(v, "_id", "birthDate", "deathDate"), generation: LENGTH(p.edges) }
+		)
+		RETURN { personId: personID, relatives: APPEND(spouses, descendants) }
+	`
+
+	bindVars := map[string]any{
+		"graphName":  r.graph.Name(),
+		"personIDs":  personIDs,
+		"maxDepth":   maxDepth,
+		"parentType": interfaces.RelationTypeParent,
+		"childType":  interfaces.RelationTypeChild,
+		"spouseType": interfaces.RelationTypeSpouse,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query spouses and descendants: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	relatives := make(map[string][]interfaces.Relative, len(personIDs))
+	for cursor.HasMore() {
+		var found struct {
+			PersonID  string                `json:"personId"`
+			Relatives []interfaces.Relative `json:"relatives"`
+		}
+		if _, err := cursor.ReadDocument(ctx, &found); err != nil {
+			return nil, fmt.Errorf("failed to read spouses and descendants: %w", err)
+		}
+		relatives[found.PersonID] = found.Relatives
+	}
+
+	return relatives, nil
+}
+
+// MigrateAdoptions qualifies parent and child edges stored without a qualifier as adoptive when their notes
+// mention an adoption, which is how adoptions were recorded before relationships had qualifiers.
+// The migration runs once, so qualifiers users set afterwards are left alone.
+// It returns the number of migrated relationships.
+func (r *RelationshipRepository) MigrateAdoptions(ctx context.Context) (int, error) {
+	return runOnce(ctx, r.db, "adoptions", func() (int, error) {
+		return r.migrateAdoptions(ctx)
+	})
+}
+
+// migrateAdoptions qualifies the parent and child edges whose notes mention an adoption as adoptive
+func (r *RelationshipRepository) migrateAdoptions(ctx context.Context) (int, error) {
+	query := `
+		FOR rel IN relationships
+		FILTER rel.relationType IN [@parentType, @childType]
+		FILTER rel.qualifier == null OR rel.qualifier == ""
+		FILTER CONTAINS(LOWER(rel.notes || ""), "adopt")
+		UPDATE rel WITH { qualifier: @qualifier } IN relationships
+		RETURN 1
+	`
+
+	bindVars := map[string]any{
+		"parentType": interfaces.RelationTypeParent,
+		"childType":  interfaces.RelationTypeChild,
+		"qualifier":  interfaces.RelationQualifierAdoptive,
+	}
+
+	cursor, err := r.db.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return 0, fmt.Errorf("failed to migrate adoptions: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	migrated := 0
+	for cursor.HasMore() {
+		var one int
+		if _, err := cursor.ReadDocument(ctx, &one); err != nil {
+			return 0, fmt.Errorf("failed to read migrated relationship: %w", err)
+		}
+		migrated++
+	}
+
+	return migrated, nil
+}
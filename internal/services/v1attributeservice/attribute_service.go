@@ -0,0 +1,216 @@
+package v1attributeservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+var (
+	// ErrInvalidAttributeType is returned when an attribute type fails validation
+	ErrInvalidAttributeType = errors.New("invalid attribute type")
+
+	// ErrAttributeTypeInUse is returned when deleting an attribute type that persons have values for
+	ErrAttributeTypeInUse = errors.New("attribute type is in use")
+)
+
+// namePattern restricts attribute names to identifiers, since they are used as keys of Person.Attributes and in query parameters
+var namePattern = regexp.MustCompile(`^[a-z][a-zA-Z0-9_]{0,63}$`)
+
+// AttributeService handles business logic for attribute type operations
+type AttributeService struct {
+	repo interfaces.AttributeTypeRepository
+}
+
+// NewAttributeService creates a new attribute type service
+func NewAttributeService(repo interfaces.AttributeTypeRepository) *AttributeService {
+	return &AttributeService{
+		repo: repo,
+	}
+}
+
+// CreateAttributeType creates a new attribute type with validation
+func (s *AttributeService) CreateAttributeType(ctx context.Context, req *interfaces.AttributeTypeCreateRequest) (*interfaces.AttributeType, error) {
+	attributeType := &interfaces.AttributeType{
+		Name:        strings.TrimSpace(req.Name),
+		Label:       strings.TrimSpace(req.Label),
+		ValueType:   strings.TrimSpace(req.ValueType),
+		EnumValues:  trimValues(req.EnumValues),
+		Description: strings.TrimSpace(req.Description),
+	}
+
+	if err := validateAttributeType(attributeType); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.FindByName(ctx, attributeType.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: an attribute type named %s already exists", ErrInvalidAttributeType, attributeType.Name)
+	}
+
+	// Create in repository
+	if err := s.repo.Create(ctx, attributeType); err != nil {
+		return nil, fmt.Errorf("failed to create attribute type: %w", err)
+	}
+
+	return attributeType, nil
+}
+
+// GetAttributeType retrieves an attribute type by ID
+func (s *AttributeService) GetAttributeType(ctx context.Context, id string) (*interfaces.AttributeType, error) {
+	if id == "" {
+		return nil, fmt.Errorf("attribute type ID is required")
+	}
+
+	attributeType, err := s.repo.GetByID(ctx, attributeTypeKey(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return attributeType, nil
+}
+
+// UpdateAttributeType updates the label, enum values or description of an attribute type
+func (s *AttributeService) UpdateAttributeType(ctx context.Context, id string, req *interfaces.AttributeTypeUpdateRequest) (*interfaces.AttributeType, error) {
+	if id == "" {
+		return nil, fmt.Errorf("attribute type ID is required")
+	}
+
+	// Get existing attribute type
+	attributeType, err := s.repo.GetByID(ctx, attributeTypeKey(id))
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields if provided
+	if req.Label != "" {
+		attributeType.Label = strings.TrimSpace(req.Label)
+	}
+	if req.EnumValues != nil {
+		attributeType.EnumValues = trimValues(*req.EnumValues)
+	}
+	if req.Description != "" {
+		attributeType.Description = strings.TrimSpace(req.Description)
+	}
+
+	if err := validateAttributeType(attributeType); err != nil {
+		return nil, err
+	}
+
+	// Update in repository
+	if err := s.repo.Update(ctx, attributeType.Key, attributeType); err != nil {
+		return nil, fmt.Errorf("failed to update attribute type: %w", err)
+	}
+
+	return attributeType, nil
+}
+
+// DeleteAttributeType deletes an attribute type that no person has a value for
+func (s *AttributeService) DeleteAttributeType(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("attribute type ID is required")
+	}
+
+	attributeType, err := s.repo.GetByID(ctx, attributeTypeKey(id))
+	if err != nil {
+		return err
+	}
+
+	used, err := s.repo.IsUsed(ctx, attributeType.Name)
+	if err != nil {
+		return err
+	}
+	if used {
+		return fmt.Errorf("%w: persons have values for %s", ErrAttributeTypeInUse, attributeType.Name)
+	}
+
+	if err := s.repo.Delete(ctx, attributeType.Key); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListAttributeTypes retrieves all attribute types
+func (s *AttributeService) ListAttributeTypes(ctx context.Context) ([]interfaces.AttributeType, error) {
+	attributeTypes, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return attributeTypes, nil
+}
+
+// AttributeTypesByName retrieves all attribute types keyed by name
+func (s *AttributeService) AttributeTypesByName(ctx context.Context) (map[string]interfaces.AttributeType, error) {
+	attributeTypes, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]interfaces.AttributeType, len(attributeTypes))
+	for _, attributeType := range attributeTypes {
+		byName[attributeType.Name] = attributeType
+	}
+
+	return byName, nil
+}
+
+// validateAttributeType validates the name and value type of an attribute type, and the values of enums
+func validateAttributeType(attributeType *interfaces.AttributeType) error {
+	if !namePattern.MatchString(attributeType.Name) {
+		return fmt.Errorf("%w: name must start with a lowercase letter and contain only letters, digits and underscores", ErrInvalidAttributeType)
+	}
+
+	switch attributeType.ValueType {
+	case interfaces.AttributeValueTypeEnum:
+		if len(attributeType.EnumValues) == 0 {
+			return fmt.Errorf("%w: enum attribute types need enumValues", ErrInvalidAttributeType)
+		}
+		seen := make(map[string]bool)
+		for _, value := range attributeType.EnumValues {
+			if value == "" {
+				return fmt.Errorf("%w: enumValues must not be empty", ErrInvalidAttributeType)
+			}
+			if seen[value] {
+				return fmt.Errorf("%w: enum value %s is listed more than once", ErrInvalidAttributeType, value)
+			}
+			seen[value] = true
+		}
+	case interfaces.AttributeValueTypeString, interfaces.AttributeValueTypeDate,
+		interfaces.AttributeValueTypeNumber, interfaces.AttributeValueTypePlace:
+		if len(attributeType.EnumValues) > 0 {
+			return fmt.Errorf("%w: only enum attribute types have enumValues", ErrInvalidAttributeType)
+		}
+	default:
+		return fmt.Errorf("%w: valueType must be one of: %s, %s, %s, %s, %s", ErrInvalidAttributeType,
+			interfaces.AttributeValueTypeString, interfaces.AttributeValueTypeDate, interfaces.AttributeValueTypeNumber,
+			interfaces.AttributeValueTypeEnum, interfaces.AttributeValueTypePlace)
+	}
+
+	return nil
+}
+
+// trimValues trims every enum value
+func trimValues(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	trimmed := make([]string, 0, len(values))
+	for _, value := range values {
+		trimmed = append(trimmed, strings.TrimSpace(value))
+	}
+	return trimmed
+}
+
+// attributeTypeKey returns the document key for an attribute type key or ID
+func attributeTypeKey(attributeTypeID string) string {
+	return strings.TrimPrefix(attributeTypeID, "attributeTypes/")
+}
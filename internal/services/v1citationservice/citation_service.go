@@ -0,0 +1,354 @@
+package v1citationservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/internal/services/v1sourceservice"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// ErrInvalidCitation is returned when a citation or its subjects fail validation
+var ErrInvalidCitation = errors.New("invalid citation")
+
+// CitationService handles business logic for citation operations
+type CitationService struct {
+	repo             interfaces.CitationRepository
+	linkRepo         interfaces.CitationLinkRepository
+	sourceRepo       interfaces.SourceRepository
+	personRepo       interfaces.PersonRepository
+	relationshipRepo interfaces.RelationshipRepository
+	eventRepo        interfaces.EventRepository
+}
+
+// NewCitationService creates a new citation service
+func NewCitationService(
+	repo interfaces.CitationRepository,
+	linkRepo interfaces.CitationLinkRepository,
+	sourceRepo interfaces.SourceRepository,
+	personRepo interfaces.PersonRepository,
+	relationshipRepo interfaces.RelationshipRepository,
+	eventRepo interfaces.EventRepository,
+) *CitationService {
+	return &CitationService{
+		repo:             repo,
+		linkRepo:         linkRepo,
+		sourceRepo:       sourceRepo,
+		personRepo:       personRepo,
+		relationshipRepo: relationshipRepo,
+		eventRepo:        eventRepo,
+	}
+}
+
+// CreateCitation creates a new citation and links it to the facts it supports
+func (s *CitationService) CreateCitation(ctx context.Context, req *interfaces.CitationCreateRequest) (*interfaces.Citation, error) {
+	citation := &interfaces.Citation{
+		SourceID:      v1sourceservice.SourceDocumentID(strings.TrimSpace(req.SourceID)),
+		Page:          strings.TrimSpace(req.Page),
+		Entry:         strings.TrimSpace(req.Entry),
+		URL:           strings.TrimSpace(req.URL),
+		Transcription: strings.TrimSpace(req.Transcription),
+		Quality:       req.Quality,
+	}
+
+	if err := s.validateCitation(ctx, citation); err != nil {
+		return nil, err
+	}
+	subjects, err := s.validateSubjects(ctx, req.Subjects)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create in repository
+	if err := s.repo.Create(ctx, citation); err != nil {
+		return nil, fmt.Errorf("failed to create citation: %w", err)
+	}
+
+	if err := s.linkSubjects(ctx, citation.ID, subjects); err != nil {
+		_ = s.repo.Delete(ctx, citation.Key)
+		return nil, err
+	}
+	citation.Subjects = subjects
+
+	return citation, nil
+}
+
+// GetCitation retrieves a citation by ID with its subjects
+func (s *CitationService) GetCitation(ctx context.Context, id string) (*interfaces.Citation, error) {
+	if id == "" {
+		return nil, fmt.Errorf("citation ID is required")
+	}
+
+	citation, err := s.repo.GetByID(ctx, citationKey(id))
+	if err != nil {
+		return nil, err
+	}
+
+	citations := []interfaces.Citation{*citation}
+	if err := s.fillSubjects(ctx, citations); err != nil {
+		return nil, err
+	}
+
+	return &citations[0], nil
+}
+
+// UpdateCitation updates an existing citation, replacing its subjects when they are given
+func (s *CitationService) UpdateCitation(ctx context.Context, id string, req *interfaces.CitationUpdateRequest) (*interfaces.Citation, error) {
+	if id == "" {
+		return nil, fmt.Errorf("citation ID is required")
+	}
+
+	// Get existing citation
+	citation, err := s.repo.GetByID(ctx, citationKey(id))
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields if provided
+	if req.SourceID != "" {
+		citation.SourceID = v1sourceservice.SourceDocumentID(strings.TrimSpace(req.SourceID))
+	}
+	if req.Page != "" {
+		citation.Page = strings.TrimSpace(req.Page)
+	}
+	if req.Entry != "" {
+		citation.Entry = strings.TrimSpace(req.Entry)
+	}
+	if req.URL != "" {
+		citation.URL = strings.TrimSpace(req.URL)
+	}
+	if req.Transcription != "" {
+		citation.Transcription = strings.TrimSpace(req.Transcription)
+	}
+	if req.Quality != nil {
+		citation.Quality = *req.Quality
+	}
+
+	if err := s.validateCitation(ctx, citation); err != nil {
+		return nil, err
+	}
+	var subjects []interfaces.CitationSubject
+	if req.Subjects != nil {
+		if subjects, err = s.validateSubjects(ctx, *req.Subjects); err != nil {
+			return nil, err
+		}
+	}
+
+	// Update in repository
+	if err := s.repo.Update(ctx, citation.Key, citation); err != nil {
+		return nil, fmt.Errorf("failed to update citation: %w", err)
+	}
+
+	if req.Subjects != nil {
+		if err := s.linkRepo.DeleteByCitation(ctx, citation.ID); err != nil {
+			return nil, err
+		}
+		if err := s.linkSubjects(ctx, citation.ID, subjects); err != nil {
+			return nil, err
+		}
+	}
+
+	citations := []interfaces.Citation{*citation}
+	if err := s.fillSubjects(ctx, citations); err != nil {
+		return nil, err
+	}
+
+	return &citations[0], nil
+}
+
+// DeleteCitation deletes a citation and its links
+func (s *CitationService) DeleteCitation(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("citation ID is required")
+	}
+
+	citation, err := s.repo.GetByID(ctx, citationKey(id))
+	if err != nil {
+		return err
+	}
+
+	if err := s.linkRepo.DeleteByCitation(ctx, citation.ID); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, citation.Key); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListCitations retrieves all citations with their subjects
+func (s *CitationService) ListCitations(ctx context.Context) ([]interfaces.Citation, error) {
+	citations, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.fillSubjects(ctx, citations); err != nil {
+		return nil, err
+	}
+
+	return citations, nil
+}
+
+// FindCitations finds the citations supporting the given persons, relationships or events, grouped by subject ID
+func (s *CitationService) FindCitations(ctx context.Context, subjectIDs []string) (map[string][]interfaces.CitationReference, error) {
+	bySubject := make(map[string][]interfaces.CitationReference)
+	if len(subjectIDs) == 0 {
+		return bySubject, nil
+	}
+
+	references, err := s.repo.FindBySubjects(ctx, subjectIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, reference := range references {
+		bySubject[reference.SubjectID] = append(bySubject[reference.SubjectID], reference)
+	}
+
+	return bySubject, nil
+}
+
+// GetSubjectCitations retrieves the citations supporting a person, relationship or event
+func (s *CitationService) GetSubjectCitations(ctx context.Context, subjectID string) ([]interfaces.CitationReference, error) {
+	if err := s.subjectExists(ctx, subjectID); err != nil {
+		return nil, err
+	}
+
+	bySubject, err := s.FindCitations(ctx, []string{subjectID})
+	if err != nil {
+		return nil, err
+	}
+
+	citations := bySubject[subjectID]
+	if citations == nil {
+		citations = []interfaces.CitationReference{}
+	}
+
+	return citations, nil
+}
+
+// linkSubjects creates the link edges from a citation to its subjects.
+// When an edge cannot be created, the edges already created for the citation are removed.
+func (s *CitationService) linkSubjects(ctx context.Context, citationID string, subjects []interfaces.CitationSubject) error {
+	for _, subject := range subjects {
+		link := &interfaces.CitationLink{
+			From: citationID,
+			To:   subject.ID,
+			Fact: subject.Fact,
+		}
+		if err := s.linkRepo.Create(ctx, link); err != nil {
+			_ = s.linkRepo.DeleteByCitation(ctx, citationID)
+			return fmt.Errorf("failed to link citation to %s: %w", subject.ID, err)
+		}
+	}
+	return nil
+}
+
+// fillSubjects sets the subjects of the citations from their link edges
+func (s *CitationService) fillSubjects(ctx context.Context, citations []interfaces.Citation) error {
+	if len(citations) == 0 {
+		return nil
+	}
+
+	citationIDs := make([]string, 0, len(citations))
+	for _, citation := range citations {
+		citationIDs = append(citationIDs, citation.ID)
+	}
+
+	links, err := s.linkRepo.FindByCitations(ctx, citationIDs)
+	if err != nil {
+		return err
+	}
+
+	byCitation := make(map[string][]interfaces.CitationSubject)
+	for _, link := range links {
+		byCitation[link.From] = append(byCitation[link.From], interfaces.CitationSubject{
+			ID:   link.To,
+			Fact: link.Fact,
+		})
+	}
+
+	for i := range citations {
+		citations[i].Subjects = byCitation[citations[i].ID]
+	}
+
+	return nil
+}
+
+// validateCitation validates the quality of a citation and that its source exists
+func (s *CitationService) validateCitation(ctx context.Context, citation *interfaces.Citation) error {
+	if v1sourceservice.SourceKey(citation.SourceID) == "" {
+		return fmt.Errorf("%w: sourceId is required", ErrInvalidCitation)
+	}
+	if citation.Quality < interfaces.CitationQualityUnreliable || citation.Quality > interfaces.CitationQualityPrimary {
+		return fmt.Errorf("%w: quality must be between %d and %d", ErrInvalidCitation, interfaces.CitationQualityUnreliable, interfaces.CitationQualityPrimary)
+	}
+
+	if _, err := s.sourceRepo.GetByID(ctx, v1sourceservice.SourceKey(citation.SourceID)); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return fmt.Errorf("%w: source %s does not exist", ErrInvalidCitation, citation.SourceID)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// validateSubjects validates that every subject is an existing person, relationship or event, listed once per fact
+func (s *CitationService) validateSubjects(ctx context.Context, subjects []interfaces.CitationSubject) ([]interfaces.CitationSubject, error) {
+	result := make([]interfaces.CitationSubject, 0, len(subjects))
+	seen := make(map[interfaces.CitationSubject]bool)
+	for _, subject := range subjects {
+		subject = interfaces.CitationSubject{
+			ID:   strings.TrimSpace(subject.ID),
+			Fact: strings.TrimSpace(subject.Fact),
+		}
+		if seen[subject] {
+			return nil, fmt.Errorf("%w: %s is listed more than once", ErrInvalidCitation, subject.ID)
+		}
+		seen[subject] = true
+
+		if err := s.subjectExists(ctx, subject.ID); err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				return nil, fmt.Errorf("%w: subject %s does not exist", ErrInvalidCitation, subject.ID)
+			}
+			return nil, err
+		}
+
+		result = append(result, subject)
+	}
+
+	return result, nil
+}
+
+// subjectExists checks that a document ID refers to an existing person, relationship or event
+func (s *CitationService) subjectExists(ctx context.Context, subjectID string) error {
+	collection, key, found := strings.Cut(subjectID, "/")
+	if !found || key == "" {
+		return fmt.Errorf("%w: subject %q must be a document ID such as persons/123", ErrInvalidCitation, subjectID)
+	}
+
+	var err error
+	switch collection {
+	case "persons":
+		_, err = s.personRepo.GetByID(ctx, key)
+	case "relationships":
+		_, err = s.relationshipRepo.GetByID(ctx, key)
+	case "events":
+		_, err = s.eventRepo.GetByID(ctx, key)
+	default:
+		return fmt.Errorf("%w: subject %s must be a person, relationship or event", ErrInvalidCitation, subjectID)
+	}
+
+	return err
+}
+
+// citationKey returns the document key for a citation key or ID
+func citationKey(citationID string) string {
+	return strings.TrimPrefix(citationID, "citations/")
+}
@@ -0,0 +1,365 @@
+package v1eventservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/internal/services/v1personservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1placeservice"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// ErrInvalidEvent is returned when an event or its participants fail validation
+var ErrInvalidEvent = errors.New("invalid event")
+
+// EventService handles business logic for life event operations
+type EventService struct {
+	repo            interfaces.EventRepository
+	participantRepo interfaces.ParticipantRepository
+	personRepo      interfaces.PersonRepository
+	placeService    *v1placeservice.PlaceService
+}
+
+// NewEventService creates a new event service
+func NewEventService(
+	repo interfaces.EventRepository,
+	participantRepo interfaces.ParticipantRepository,
+	personRepo interfaces.PersonRepository,
+	placeService *v1placeservice.PlaceService,
+) *EventService {
+	return &EventService{
+		repo:            repo,
+		participantRepo: participantRepo,
+		personRepo:      personRepo,
+		placeService:    placeService,
+	}
+}
+
+// CreateEvent creates a new event and links its participants
+func (s *EventService) CreateEvent(ctx context.Context, req *interfaces.EventCreateRequest) (*interfaces.Event, error) {
+	if err := validateType(req.Type); err != nil {
+		return nil, err
+	}
+	placeID, err := s.placeService.ValidatePlaceReference(ctx, "placeId", req.PlaceID)
+	if err != nil {
+		return nil, err
+	}
+	participants, err := s.validateParticipants(ctx, req.Participants)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &interfaces.Event{
+		Type:        strings.TrimSpace(req.Type),
+		Date:        req.Date,
+		PlaceID:     placeID,
+		Description: strings.TrimSpace(req.Description),
+	}
+
+	if err := s.repo.Create(ctx, event); err != nil {
+		return nil, fmt.Errorf("failed to create event: %w", err)
+	}
+
+	if err := s.linkParticipants(ctx, event.ID, participants); err != nil {
+		_ = s.repo.Delete(ctx, event.Key)
+		return nil, err
+	}
+	event.Participants = participants
+
+	return event, nil
+}
+
+// GetEvent retrieves an event by ID with its participants
+func (s *EventService) GetEvent(ctx context.Context, id string) (*interfaces.Event, error) {
+	if id == "" {
+		return nil, fmt.Errorf("event ID is required")
+	}
+
+	event, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []interfaces.Event{*event}
+	if err := s.fillParticipants(ctx, events); err != nil {
+		return nil, err
+	}
+
+	return &events[0], nil
+}
+
+// UpdateEvent updates an existing event, replacing its participants when they are given
+func (s *EventService) UpdateEvent(ctx context.Context, id string, req *interfaces.EventUpdateRequest) (*interfaces.Event, error) {
+	if id == "" {
+		return nil, fmt.Errorf("event ID is required")
+	}
+
+	// Get existing event
+	event, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields if provided
+	if req.Type != "" {
+		if err := validateType(req.Type); err != nil {
+			return nil, err
+		}
+		event.Type = strings.TrimSpace(req.Type)
+	}
+	if !req.Date.IsZero() {
+		event.Date = req.Date
+	}
+	if req.PlaceID != "" {
+		if event.PlaceID, err = s.placeService.ValidatePlaceReference(ctx, "placeId", req.PlaceID); err != nil {
+			return nil, err
+		}
+	}
+	if req.Description != "" {
+		event.Description = strings.TrimSpace(req.Description)
+	}
+
+	var participants []interfaces.EventParticipant
+	if req.Participants != nil {
+		if participants, err = s.validateParticipants(ctx, *req.Participants); err != nil {
+			return nil, err
+		}
+	}
+
+	// Update in repository
+	if err := s.repo.Update(ctx, id, event); err != nil {
+		return nil, fmt.Errorf("failed to update event: %w", err)
+	}
+
+	if req.Participants != nil {
+		if err := s.replaceParticipants(ctx, event.ID, participants); err != nil {
+			return nil, err
+		}
+	}
+
+	events := []interfaces.Event{*event}
+	if err := s.fillParticipants(ctx, events); err != nil {
+		return nil, err
+	}
+
+	return &events[0], nil
+}
+
+// DeleteEvent deletes an event and its participant edges
+func (s *EventService) DeleteEvent(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("event ID is required")
+	}
+
+	event, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.participantRepo.DeleteByEvent(ctx, event.ID); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListEvents retrieves all events with their participants
+func (s *EventService) ListEvents(ctx context.Context) ([]interfaces.Event, error) {
+	events, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.fillParticipants(ctx, events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// GetPersonEvents retrieves the events a person takes part in, ordered by date
+func (s *EventService) GetPersonEvents(ctx context.Context, personID string) ([]interfaces.Event, error) {
+	if personID == "" {
+		return nil, fmt.Errorf("person ID is required")
+	}
+
+	person, err := s.personRepo.GetByID(ctx, v1personservice.PersonKey(personID))
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.repo.FindByPerson(ctx, person.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.fillParticipants(ctx, events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// linkParticipants creates the participant edges from persons to an event.
+// When an edge cannot be created, the edges already created for the event are removed.
+func (s *EventService) linkParticipants(ctx context.Context, eventID string, participants []interfaces.EventParticipant) error {
+	for _, participant := range participants {
+		edge := &interfaces.Participant{
+			From: participant.PersonID,
+			To:   eventID,
+			Role: participant.Role,
+		}
+		if err := s.participantRepo.Create(ctx, edge); err != nil {
+			_ = s.participantRepo.DeleteByEvent(ctx, eventID)
+			return fmt.Errorf("failed to link participant %s: %w", participant.PersonID, err)
+		}
+	}
+	return nil
+}
+
+// replaceParticipants replaces the participant edges of an event with edges for the participants.
+// The edges of participants who stay are kept, and new edges are created before the edges of dropped participants
+// are removed, so a failure leaves the previous participants in place.
+func (s *EventService) replaceParticipants(ctx context.Context, eventID string, participants []interfaces.EventParticipant) error {
+	edges, err := s.participantRepo.FindByEvents(ctx, []string{eventID})
+	if err != nil {
+		return err
+	}
+
+	dropped := make(map[interfaces.EventParticipant]interfaces.Participant, len(edges))
+	for _, edge := range edges {
+		dropped[interfaces.EventParticipant{PersonID: edge.From, Role: edge.Role}] = edge
+	}
+
+	var created []string
+	for _, participant := range participants {
+		if _, found := dropped[participant]; found {
+			delete(dropped, participant)
+			continue
+		}
+
+		edge := &interfaces.Participant{
+			From: participant.PersonID,
+			To:   eventID,
+			Role: participant.Role,
+		}
+		if err := s.participantRepo.Create(ctx, edge); err != nil {
+			for _, key := range created {
+				_ = s.participantRepo.Delete(ctx, key)
+			}
+			return fmt.Errorf("failed to link participant %s: %w", participant.PersonID, err)
+		}
+		created = append(created, edge.Key)
+	}
+
+	for _, edge := range dropped {
+		if err := s.participantRepo.Delete(ctx, edge.Key); err != nil {
+			return fmt.Errorf("failed to unlink participant %s: %w", edge.From, err)
+		}
+	}
+
+	return nil
+}
+
+// fillParticipants sets the participants of the events from their participant edges
+func (s *EventService) fillParticipants(ctx context.Context, events []interfaces.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	eventIDs := make([]string, 0, len(events))
+	for _, event := range events {
+		eventIDs = append(eventIDs, event.ID)
+	}
+
+	edges, err := s.participantRepo.FindByEvents(ctx, eventIDs)
+	if err != nil {
+		return err
+	}
+
+	byEvent := make(map[string][]interfaces.EventParticipant)
+	for _, edge := range edges {
+		byEvent[edge.To] = append(byEvent[edge.To], interfaces.EventParticipant{
+			PersonID: edge.From,
+			Role:     edge.Role,
+		})
+	}
+
+	for i := range events {
+		events[i].Participants = byEvent[events[i].ID]
+	}
+
+	return nil
+}
+
+// validateParticipants validates the roles of the participants and that the persons exist.
+// It returns the participants with person document IDs.
+func (s *EventService) validateParticipants(ctx context.Context, participants []interfaces.EventParticipant) ([]interfaces.EventParticipant, error) {
+	validRoles := map[string]bool{
+		interfaces.ParticipantRolePrincipal: true,
+		interfaces.ParticipantRoleWitness:   true,
+		interfaces.ParticipantRoleGodparent: true,
+		interfaces.ParticipantRoleOfficiant: true,
+	}
+
+	result := make([]interfaces.EventParticipant, 0, len(participants))
+	seen := make(map[interfaces.EventParticipant]bool)
+	for _, participant := range participants {
+		if strings.TrimSpace(participant.PersonID) == "" {
+			return nil, fmt.Errorf("%w: participant personId is required", ErrInvalidEvent)
+		}
+
+		participant = interfaces.EventParticipant{
+			PersonID: v1personservice.PersonDocumentID(strings.TrimSpace(participant.PersonID)),
+			Role:     strings.TrimSpace(participant.Role),
+		}
+		if !validRoles[participant.Role] {
+			return nil, fmt.Errorf("%w: invalid participant role: %s. Valid roles are: principal, witness, godparent, officiant", ErrInvalidEvent, participant.Role)
+		}
+		if seen[participant] {
+			return nil, fmt.Errorf("%w: %s is listed more than once as %s", ErrInvalidEvent, participant.PersonID, participant.Role)
+		}
+		seen[participant] = true
+
+		if _, err := s.personRepo.GetByID(ctx, v1personservice.PersonKey(participant.PersonID)); err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				return nil, fmt.Errorf("%w: participant %s does not exist", ErrInvalidEvent, participant.PersonID)
+			}
+			return nil, err
+		}
+
+		result = append(result, participant)
+	}
+
+	return result, nil
+}
+
+// validateType validates an event type
+func validateType(eventType string) error {
+	validTypes := map[string]bool{
+		interfaces.EventTypeBaptism:      true,
+		interfaces.EventTypeConfirmation: true,
+		interfaces.EventTypeMarriage:     true,
+		interfaces.EventTypeDivorce:      true,
+		interfaces.EventTypeEmigration:   true,
+		interfaces.EventTypeCensus:       true,
+		interfaces.EventTypeResidence:    true,
+		interfaces.EventTypeOccupation:   true,
+		interfaces.EventTypeBurial:       true,
+	}
+
+	if strings.TrimSpace(eventType) == "" {
+		return fmt.Errorf("%w: type is required", ErrInvalidEvent)
+	}
+	if !validTypes[strings.TrimSpace(eventType)] {
+		return fmt.Errorf("%w: invalid event type: %s. Valid types are: baptism, confirmation, marriage, divorce, emigration, census, residence, occupation, burial", ErrInvalidEvent, eventType)
+	}
+
+	return nil
+}
@@ -0,0 +1,343 @@
+package v1exportservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rogerwesterbo/familytree/internal/services/v1citationservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1eventservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1mediaservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1personservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1placeservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1relationshipservice"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// ErrInvalidExport is returned when the export options are invalid
+var ErrInvalidExport = errors.New("invalid export")
+
+// descendantNumbers holds the numbers the descendants of the exported person are labelled with, by person ID
+type descendantNumbers struct {
+	system  string
+	numbers map[string][]string
+}
+
+// ExportService writes the tree, or the relatives of a person, in formats other family tree programs can read.
+// Persons the viewer may not see are redacted or left out as they are everywhere else.
+type ExportService struct {
+	personService       *v1personservice.PersonService
+	relationshipService *v1relationshipservice.RelationshipService
+	placeService        *v1placeservice.PlaceService
+	mediaService        *v1mediaservice.MediaService
+	citationService     *v1citationservice.CitationService
+	eventService        *v1eventservice.EventService
+}
+
+// NewExportService creates a new export service
+func NewExportService(
+	personService *v1personservice.PersonService,
+	relationshipService *v1relationshipservice.RelationshipService,
+	placeService *v1placeservice.PlaceService,
+	mediaService *v1mediaservice.MediaService,
+	citationService *v1citationservice.CitationService,
+	eventService *v1eventservice.EventService,
+) *ExportService {
+	return &ExportService{
+		personService:       personService,
+		relationshipService: relationshipService,
+		placeService:        placeService,
+		mediaService:        mediaService,
+		citationService:     citationService,
+		eventService:        eventService,
+	}
+}
+
+// Export writes the persons, relationships and places described by opts to w
+func (s *ExportService) Export(ctx context.Context, w io.Writer, opts interfaces.ExportOptions) error {
+	if err := validateOptions(opts); err != nil {
+		return err
+	}
+
+	persons, numbers, err := s.collect(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	persons, err = s.personService.ApplyPrivacy(ctx, persons, opts.Privacy, opts.HideRestricted)
+	if err != nil {
+		return err
+	}
+
+	relationships, err := s.relationshipService.ListRelationships(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list relationships: %w", err)
+	}
+	relationships = within(relationships, persons)
+
+	// Only GEDCOM X has room for the events of the persons
+	var events []interfaces.Event
+	if opts.Format == interfaces.ExportFormatGedcomX {
+		events, err = s.events(ctx, persons)
+		if err != nil {
+			return err
+		}
+	}
+
+	places, err := s.places(ctx, persons, events)
+	if err != nil {
+		return err
+	}
+
+	switch opts.Format {
+	case interfaces.ExportFormatGedcom:
+		return writeGedcom(w, persons, relationships, places, numbers, opts.Submitter)
+	case interfaces.ExportFormatGedcom7:
+		return writeGedcom7(w, persons, relationships, places, numbers, opts.Submitter)
+	case interfaces.ExportFormatGedzip:
+		return s.writeGedzip(ctx, w, persons, relationships, places, numbers, opts.Submitter)
+	case interfaces.ExportFormatGedcomX:
+		return s.writeGedcomX(ctx, w, persons, relationships, events, places, opts.Submitter)
+	default:
+		return writeJSON(w, persons, relationships, places, numbers)
+	}
+}
+
+// collect returns the persons to export: everyone, or a person followed by their ancestors and descendants
+// with the spouses of the descendants. The descendants are numbered when opts asks for a numbering system.
+func (s *ExportService) collect(ctx context.Context, opts interfaces.ExportOptions) ([]interfaces.Person, descendantNumbers, error) {
+	if opts.PersonID == "" {
+		persons, err := s.personService.ListPersons(ctx)
+		if err != nil {
+			return nil, descendantNumbers{}, fmt.Errorf("failed to list persons: %w", err)
+		}
+		return persons, descendantNumbers{}, nil
+	}
+
+	root, err := s.personService.GetPerson(ctx, strings.TrimPrefix(opts.PersonID, "persons/"))
+	if err != nil {
+		return nil, descendantNumbers{}, err
+	}
+
+	persons := []interfaces.Person{*root}
+	seen := map[string]bool{root.ID: true}
+	add := func(person interfaces.Person) {
+		if !seen[person.ID] {
+			seen[person.ID] = true
+			persons = append(persons, person)
+		}
+	}
+
+	if opts.Scope == interfaces.ExportScopeAncestors || opts.Scope == interfaces.ExportScopeBoth {
+		ancestors, err := s.relationshipService.GetAncestors(ctx, root.ID, opts.Depth, nil)
+		if err != nil {
+			return nil, descendantNumbers{}, err
+		}
+		for _, ancestor := range ancestors {
+			add(ancestor.Person)
+		}
+	}
+
+	numbers := descendantNumbers{system: opts.Numbering}
+	if opts.Scope == interfaces.ExportScopeDescendants || opts.Scope == interfaces.ExportScopeBoth {
+		tree, _, err := s.relationshipService.GetDescendants(ctx, root.ID, opts.Depth, true, nil)
+		if err != nil {
+			return nil, descendantNumbers{}, err
+		}
+		var walk func(node *interfaces.DescendantNode)
+		walk = func(node *interfaces.DescendantNode) {
+			add(node.Person)
+			for _, spouse := range node.Spouses {
+				add(spouse.Person)
+			}
+			for i := range node.Children {
+				walk(&node.Children[i])
+			}
+		}
+		walk(tree)
+
+		if opts.Numbering != "" {
+			numbered, err := v1relationshipservice.NumberDescendants(tree, opts.Numbering)
+			if err != nil {
+				return nil, descendantNumbers{}, err
+			}
+			numbers.numbers = make(map[string][]string, len(numbered))
+			for _, descendant := range numbered {
+				numbers.numbers[descendant.Person.ID] = append(numbers.numbers[descendant.Person.ID], descendant.Number)
+			}
+		}
+	}
+
+	return persons, numbers, nil
+}
+
+// events returns the events the persons who are not redacted take part in
+func (s *ExportService) events(ctx context.Context, persons []interfaces.Person) ([]interfaces.Event, error) {
+	all, err := s.eventService.ListEvents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	visible := make(map[string]bool, len(persons))
+	for _, person := range persons {
+		visible[person.ID] = !person.Redacted
+	}
+
+	var events []interfaces.Event
+	for _, event := range all {
+		if slices.ContainsFunc(event.Participants, func(participant interfaces.EventParticipant) bool {
+			return visible[participant.PersonID]
+		}) {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+// places returns the places the persons were born or died in and the events took place in, with the places
+// containing them, by place ID
+func (s *ExportService) places(ctx context.Context, persons []interfaces.Person, events []interfaces.Event) (map[string]interfaces.Place, error) {
+	all, err := s.placeService.ListPlaces(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list places: %w", err)
+	}
+	byID := make(map[string]interfaces.Place, len(all))
+	for _, place := range all {
+		byID[place.ID] = place
+	}
+
+	var placeIDs []string
+	for _, person := range persons {
+		placeIDs = append(placeIDs, person.BirthPlaceID, person.DeathPlaceID)
+	}
+	for _, event := range events {
+		placeIDs = append(placeIDs, event.PlaceID)
+	}
+
+	places := make(map[string]interfaces.Place)
+	for _, placeID := range placeIDs {
+		for depth := 0; placeID != "" && depth < v1placeservice.MaxHierarchyDepth; depth++ {
+			place, found := byID[placeID]
+			if !found {
+				break
+			}
+			places[placeID] = place
+			placeID = place.ParentID
+		}
+	}
+
+	return places, nil
+}
+
+// within returns the relationships between two of the persons. Relationships of redacted persons only
+// tell who they connect.
+func within(relationships []interfaces.Relationship, persons []interfaces.Person) []interfaces.Relationship {
+	redacted := make(map[string]bool, len(persons))
+	for _, person := range persons {
+		redacted[person.ID] = person.Redacted
+	}
+
+	kept := []interfaces.Relationship{}
+	for _, relationship := range relationships {
+		fromRedacted, fromFound := redacted[relationship.From]
+		toRedacted, toFound := redacted[relationship.To]
+		if !fromFound || !toFound {
+			continue
+		}
+		if fromRedacted || toRedacted {
+			relationship = interfaces.Relationship{
+				Key:          relationship.Key,
+				ID:           relationship.ID,
+				Rev:          relationship.Rev,
+				From:         relationship.From,
+				To:           relationship.To,
+				RelationType: relationship.RelationType,
+			}
+		}
+		kept = append(kept, relationship)
+	}
+
+	return kept
+}
+
+// writeJSON writes the persons, relationships, places and descendant numbers as an indented JSON document
+func writeJSON(w io.Writer, persons []interfaces.Person, relationships []interfaces.Relationship, places map[string]interfaces.Place, numbers descendantNumbers) error {
+	data := interfaces.ExportData{
+		Persons:       persons,
+		Relationships: relationships,
+		Places:        make([]interfaces.Place, 0, len(places)),
+		ExportedAt:    time.Now(),
+	}
+	for _, place := range places {
+		data.Places = append(data.Places, place)
+	}
+	if len(numbers.numbers) > 0 {
+		// Persons left out for privacy leave their numbers out too
+		data.Numbering = numbers.system
+		data.DescendantNumbers = make(map[string][]string)
+		for _, person := range persons {
+			if personNumbers, found := numbers.numbers[person.ID]; found {
+				data.DescendantNumbers[person.ID] = personNumbers
+			}
+		}
+	}
+	slices.SortFunc(data.Places, func(a, b interfaces.Place) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		return fmt.Errorf("failed to write JSON: %w", err)
+	}
+	return nil
+}
+
+// validateOptions validates the format, scope and depth of an export
+func validateOptions(opts interfaces.ExportOptions) error {
+	switch opts.Format {
+	case interfaces.ExportFormatJSON, interfaces.ExportFormatGedcom, interfaces.ExportFormatGedcom7, interfaces.ExportFormatGedzip,
+		interfaces.ExportFormatGedcomX:
+	case "":
+		return fmt.Errorf("%w: format is required", ErrInvalidExport)
+	default:
+		return fmt.Errorf("%w: unsupported format %s. Supported formats are: %s, %s, %s, %s, %s", ErrInvalidExport, opts.Format,
+			interfaces.ExportFormatJSON, interfaces.ExportFormatGedcom, interfaces.ExportFormatGedcom7, interfaces.ExportFormatGedzip,
+			interfaces.ExportFormatGedcomX)
+	}
+
+	if opts.PersonID == "" {
+		if opts.Numbering != "" {
+			return fmt.Errorf("%w: numbering is only available when exporting a person", ErrInvalidExport)
+		}
+		return nil
+	}
+
+	switch opts.Scope {
+	case interfaces.ExportScopeAncestors, interfaces.ExportScopeDescendants, interfaces.ExportScopeBoth:
+	default:
+		return fmt.Errorf("%w: invalid scope %s. Valid scopes are: ancestors, descendants, both", ErrInvalidExport, opts.Scope)
+	}
+	if opts.Depth < 1 || opts.Depth > v1relationshipservice.MaxTraversalDepth {
+		return fmt.Errorf("%w: depth must be between 1 and %d", ErrInvalidExport, v1relationshipservice.MaxTraversalDepth)
+	}
+
+	switch opts.Numbering {
+	case "":
+	case interfaces.NumberingDAboville, interfaces.NumberingHenry:
+		if opts.Scope == interfaces.ExportScopeAncestors {
+			return fmt.Errorf("%w: numbering needs the descendants scope or both", ErrInvalidExport)
+		}
+	default:
+		return fmt.Errorf("%w: invalid numbering system %s. Valid systems are: %s, %s", ErrInvalidExport, opts.Numbering,
+			interfaces.NumberingDAboville, interfaces.NumberingHenry)
+	}
+
+	return nil
+}
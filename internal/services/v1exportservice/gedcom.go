@@ -0,0 +1,466 @@
+package v1exportservice
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rogerwesterbo/familytree/internal/services/v1placeservice"
+	"github.com/rogerwesterbo/familytree/pkg/gedcom"
+	"github.com/rogerwesterbo/familytree/pkg/gedcom7"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// gedcomSource is the system ID written as the source of GEDCOM files
+const gedcomSource = "FAMILYTREE"
+
+// submitterXRef is the cross-reference of the SUBM record of GEDCOM files
+const submitterXRef = "@U1@"
+
+// gedcomNameTypes maps name types to the values of NAME.TYPE
+var gedcomNameTypes = map[string]string{
+	interfaces.NameTypeBirth:      "birth",
+	interfaces.NameTypeMarried:    "married",
+	interfaces.NameTypeAlias:      "aka",
+	interfaces.NameTypeReligious:  "religious",
+	interfaces.NameTypeFarm:       "farm",
+	interfaces.NameTypePatronymic: "patronymic",
+}
+
+// gedcomPedigrees maps the parent qualifiers GEDCOM 5.5.1 has a FAMC.PEDI value for to that value
+var gedcomPedigrees = map[string]string{
+	interfaces.RelationQualifierBiological: "birth",
+	interfaces.RelationQualifierAdoptive:   "adopted",
+	interfaces.RelationQualifierFoster:     "foster",
+}
+
+// gedcom7NameTypes maps the name types GEDCOM 7.0 has a NAME.TYPE value for to that value
+var gedcom7NameTypes = map[string]string{
+	interfaces.NameTypeBirth:   "BIRTH",
+	interfaces.NameTypeMarried: "MARRIED",
+	interfaces.NameTypeAlias:   "AKA",
+}
+
+// gedcom7Pedigrees maps the parent qualifiers GEDCOM 7.0 has a FAMC.PEDI value for to that value
+var gedcom7Pedigrees = map[string]string{
+	interfaces.RelationQualifierBiological: "BIRTH",
+	interfaces.RelationQualifierAdoptive:   "ADOPTED",
+	interfaces.RelationQualifierFoster:     "FOSTER",
+}
+
+// gedcomNumberingTypes maps descendant numbering systems to the REFN.TYPE of the numbers
+var gedcomNumberingTypes = map[string]string{
+	interfaces.NumberingDAboville: "d'Aboville",
+	interfaces.NumberingHenry:     "Henry",
+}
+
+// gedcomParentRelations maps parent qualifiers to the values of the _FREL and _MREL extensions, written for
+// children whose parents are linked to them in ways FAMC.PEDI cannot express
+var gedcomParentRelations = map[string]string{
+	interfaces.RelationQualifierBiological: "Natural",
+	interfaces.RelationQualifierAdoptive:   "Adopted",
+	interfaces.RelationQualifierFoster:     "Foster",
+	interfaces.RelationQualifierStep:       "Step",
+	interfaces.RelationQualifierGuardian:   "Guardian",
+}
+
+// family is a FAM record: two partners, or a single parent, and their children
+type family struct {
+	record  *gedcom.Record
+	husband string
+	wife    string
+	// spouses are the spouse relationships between the partners
+	spouses  []interfaces.Relationship
+	children []familyChild
+}
+
+// familyChild is a child of a family with the qualifiers of the parent edges from the husband and the wife
+type familyChild struct {
+	id               string
+	husbandQualifier string
+	wifeQualifier    string
+}
+
+// gedcomExporter builds the INDI and FAM records of a GEDCOM file from persons and the spouse and parent
+// relationships between them
+type gedcomExporter struct {
+	// version7 is set when writing GEDCOM 7.0, which has other enumeration values than GEDCOM 5.5.1
+	version7 bool
+	persons  map[string]interfaces.Person
+	// personIDs holds the IDs of the persons in the order they are written
+	personIDs []string
+	places    map[string]interfaces.Place
+	numbers   descendantNumbers
+	// individuals holds the INDI record of every person by person ID
+	individuals map[string]*gedcom.Record
+	families    []*family
+	// partners holds the families by the sorted IDs of their partners
+	partners map[[2]string]*family
+	// extensions holds the URIs of the extension tags written, by tag
+	extensions map[string]string
+}
+
+// writeGedcom writes the persons and the relationships between them as a GEDCOM 5.5.1 file submitted by submitter
+func writeGedcom(w io.Writer, persons []interfaces.Person, relationships []interfaces.Relationship, places map[string]interfaces.Place, numbers descendantNumbers, submitter string) error {
+	ex := newGedcomExporter(persons, places, numbers, false)
+	records := ex.records(relationships, submitter)
+	return gedcom.Write(w, gedcom.NewHeader(gedcomSource, submitterXRef, time.Now()), records)
+}
+
+// newGedcomExporter creates an exporter for the persons, writing GEDCOM 7.0 when version7 is set
+func newGedcomExporter(persons []interfaces.Person, places map[string]interfaces.Place, numbers descendantNumbers, version7 bool) *gedcomExporter {
+	ex := &gedcomExporter{
+		version7:    version7,
+		persons:     make(map[string]interfaces.Person, len(persons)),
+		places:      places,
+		numbers:     numbers,
+		individuals: make(map[string]*gedcom.Record, len(persons)),
+		partners:    make(map[[2]string]*family),
+		extensions:  make(map[string]string),
+	}
+	for i, person := range persons {
+		ex.persons[person.ID] = person
+		ex.personIDs = append(ex.personIDs, person.ID)
+		ex.individuals[person.ID] = ex.individual(person, fmt.Sprintf("@I%d@", i+1))
+	}
+	return ex
+}
+
+// records returns the INDI records of the persons, the FAM records joining them and the SUBM record of submitter
+func (ex *gedcomExporter) records(relationships []interfaces.Relationship, submitter string) []*gedcom.Record {
+	records := make([]*gedcom.Record, 0, len(ex.personIDs))
+	for _, personID := range ex.personIDs {
+		records = append(records, ex.individuals[personID])
+	}
+
+	// Spouse relationships make the families; parents who are not spouses get a family of their own
+	parents := make(map[string][]interfaces.Relationship)
+	for _, relationship := range relationships {
+		switch relationship.RelationType {
+		case interfaces.RelationTypeSpouse:
+			fam := ex.family(relationship.From, relationship.To)
+			fam.spouses = append(fam.spouses, relationship)
+		case interfaces.RelationTypeParent, interfaces.RelationTypeChild:
+			// A parent recorded both ways is one parent of the child
+			edge := relationship.AsParentEdge()
+			if !slices.ContainsFunc(parents[edge.To], func(other interfaces.Relationship) bool {
+				return other.From == edge.From
+			}) {
+				parents[edge.To] = append(parents[edge.To], edge)
+			}
+		}
+	}
+	for _, personID := range ex.personIDs {
+		for _, pair := range ex.pairParents(parents[personID]) {
+			ex.addChild(personID, pair)
+		}
+	}
+
+	for i, fam := range ex.families {
+		fam.record.XRef = fmt.Sprintf("@F%d@", i+1)
+		ex.writeFamily(fam)
+		records = append(records, fam.record)
+	}
+
+	if submitter == "" {
+		submitter = "Unknown"
+	}
+	subm := &gedcom.Record{XRef: submitterXRef, Tag: "SUBM"}
+	subm.Add("NAME", submitter)
+	return append(records, subm)
+}
+
+// individual builds the INDI record of a person, without the families they belong to
+func (ex *gedcomExporter) individual(person interfaces.Person, xref string) *gedcom.Record {
+	indi := &gedcom.Record{XRef: xref, Tag: "INDI"}
+
+	for _, name := range names(person) {
+		sub := indi.Add("NAME", strings.TrimSpace(fmt.Sprintf("%s /%s/", name.GivenName, name.Surname)))
+		if name.GivenName != "" {
+			sub.Add("GIVN", name.GivenName)
+		}
+		if name.Surname != "" {
+			sub.Add("SURN", name.Surname)
+		}
+		if nameType, found := gedcomNameTypes[name.Type]; found {
+			ex.enumeration(sub, "TYPE", nameType, gedcom7NameTypes[name.Type])
+		}
+	}
+
+	switch strings.ToLower(person.Gender) {
+	case "":
+	case "male":
+		indi.Add("SEX", "M")
+	case "female":
+		indi.Add("SEX", "F")
+	default:
+		indi.Add("SEX", "U")
+	}
+
+	ex.event(indi, "BIRT", person.BirthDate, person.BirthPlaceID)
+	ex.event(indi, "DEAT", person.DeathDate, person.DeathPlaceID)
+
+	// Descendant numbers are user reference numbers, typed with the numbering system
+	for _, number := range ex.numbers.numbers[person.ID] {
+		indi.Add("REFN", number).Add("TYPE", gedcomNumberingTypes[ex.numbers.system])
+	}
+
+	return indi
+}
+
+// names returns the names of a person with the preferred name first
+func names(person interfaces.Person) []interfaces.PersonName {
+	if len(person.Names) == 0 {
+		return []interfaces.PersonName{{GivenName: person.FirstName, Surname: person.LastName}}
+	}
+
+	sorted := slices.Clone(person.Names)
+	slices.SortStableFunc(sorted, func(a, b interfaces.PersonName) int {
+		switch {
+		case a.Preferred == b.Preferred:
+			return 0
+		case a.Preferred:
+			return -1
+		default:
+			return 1
+		}
+	})
+	return sorted
+}
+
+// event adds an event with a date and place to a record when either is known
+func (ex *gedcomExporter) event(record *gedcom.Record, tag string, date interfaces.GenealogicalDate, placeID string) {
+	place := placeName(ex.places, placeID)
+	if date.IsZero() && place == "" {
+		return
+	}
+
+	sub := record.Add(tag, "")
+	if !date.IsZero() {
+		sub.Add("DATE", gedcom.FormatDate(date))
+	}
+	if place != "" {
+		sub.Add("PLAC", place)
+	}
+}
+
+// placeName returns the name of a place with the places containing it, as in "Haugen, Aker, Akershus, Norway"
+func placeName(places map[string]interfaces.Place, placeID string) string {
+	var jurisdictions []string
+	for placeID != "" && len(jurisdictions) < v1placeservice.MaxHierarchyDepth {
+		place, found := places[placeID]
+		if !found {
+			break
+		}
+		jurisdictions = append(jurisdictions, place.Name)
+		placeID = place.ParentID
+	}
+	return strings.Join(jurisdictions, ", ")
+}
+
+// family returns the family of two partners, or of a single parent when partnerB is empty, creating it when needed.
+// Men are written as the husband and women as the wife; other partners fill the places in the given order.
+func (ex *gedcomExporter) family(partnerA, partnerB string) *family {
+	key := [2]string{partnerA, partnerB}
+	if partnerB != "" && partnerB < partnerA {
+		key = [2]string{partnerB, partnerA}
+	}
+	if fam, found := ex.partners[key]; found {
+		return fam
+	}
+
+	husband, wife := partnerA, partnerB
+	if ex.gender(partnerA) == "female" || ex.gender(partnerB) == "male" {
+		husband, wife = partnerB, partnerA
+	}
+
+	fam := &family{
+		record:  &gedcom.Record{Tag: "FAM"},
+		husband: husband,
+		wife:    wife,
+	}
+	ex.partners[key] = fam
+	ex.families = append(ex.families, fam)
+	return fam
+}
+
+// gender returns the lowercase gender of a person
+func (ex *gedcomExporter) gender(personID string) string {
+	return strings.ToLower(ex.persons[personID].Gender)
+}
+
+// pairParents pairs the parent edges to a child into the families they come from: parents who are spouses first,
+// then the remaining parents two by two. An odd parent out makes a single parent family.
+func (ex *gedcomExporter) pairParents(edges []interfaces.Relationship) [][2]*interfaces.Relationship {
+	var pairs [][2]*interfaces.Relationship
+	used := make([]bool, len(edges))
+
+	for i := range edges {
+		for j := i + 1; j < len(edges) && !used[i]; j++ {
+			if !used[j] && ex.areSpouses(edges[i].From, edges[j].From) {
+				pairs = append(pairs, [2]*interfaces.Relationship{&edges[i], &edges[j]})
+				used[i], used[j] = true, true
+			}
+		}
+	}
+
+	var single *interfaces.Relationship
+	for i := range edges {
+		switch {
+		case used[i]:
+		case single == nil:
+			single = &edges[i]
+		default:
+			pairs = append(pairs, [2]*interfaces.Relationship{single, &edges[i]})
+			single = nil
+		}
+	}
+	if single != nil {
+		pairs = append(pairs, [2]*interfaces.Relationship{single, nil})
+	}
+
+	return pairs
+}
+
+// areSpouses reports whether there is a spouse relationship between two persons
+func (ex *gedcomExporter) areSpouses(personA, personB string) bool {
+	if personB < personA {
+		personA, personB = personB, personA
+	}
+	fam, found := ex.partners[[2]string{personA, personB}]
+	return found && len(fam.spouses) > 0
+}
+
+// addChild adds a child to the family of a pair of parent edges
+func (ex *gedcomExporter) addChild(childID string, pair [2]*interfaces.Relationship) {
+	partnerB := ""
+	if pair[1] != nil {
+		partnerB = pair[1].From
+	}
+	fam := ex.family(pair[0].From, partnerB)
+
+	child := familyChild{id: childID}
+	for _, edge := range pair {
+		if edge == nil {
+			continue
+		}
+		qualifier := edge.Qualifier
+		if qualifier == "" {
+			qualifier = interfaces.RelationQualifierBiological
+		}
+		if edge.From == fam.husband {
+			child.husbandQualifier = qualifier
+		} else {
+			child.wifeQualifier = qualifier
+		}
+	}
+	fam.children = append(fam.children, child)
+}
+
+// writeFamily fills in the FAM record of a family and links its members to it
+func (ex *gedcomExporter) writeFamily(fam *family) {
+	if fam.husband != "" {
+		fam.record.Add("HUSB", ex.individuals[fam.husband].XRef)
+		ex.individuals[fam.husband].Add("FAMS", fam.record.XRef)
+	}
+	if fam.wife != "" {
+		fam.record.Add("WIFE", ex.individuals[fam.wife].XRef)
+		ex.individuals[fam.wife].Add("FAMS", fam.record.XRef)
+	}
+
+	slices.SortStableFunc(fam.children, func(a, b familyChild) int {
+		return ex.persons[a.id].BirthDate.Compare(ex.persons[b.id].BirthDate)
+	})
+	for _, child := range fam.children {
+		chil := fam.record.Add("CHIL", ex.individuals[child.id].XRef)
+		famc := ex.individuals[child.id].Add("FAMC", fam.record.XRef)
+
+		qualifier, same := child.qualifier()
+		_, found := gedcomPedigrees[qualifier]
+		switch {
+		case same && ex.version7:
+			// GEDCOM 7.0 writes step and guardian parents as OTHER pedigrees
+			if qualifier != interfaces.RelationQualifierBiological {
+				ex.enumeration(famc, "PEDI", gedcomParentRelations[qualifier], gedcom7Pedigrees[qualifier])
+			}
+		case !same || !found:
+			// Step and guardian parents, or parents linked in different ways, are written as the extensions
+			// most programs use for them
+			if child.husbandQualifier != "" {
+				ex.extension(chil, gedcom7.TagFatherRelationship, gedcomParentRelations[child.husbandQualifier])
+			}
+			if child.wifeQualifier != "" {
+				ex.extension(chil, gedcom7.TagMotherRelationship, gedcomParentRelations[child.wifeQualifier])
+			}
+		case qualifier != interfaces.RelationQualifierBiological:
+			famc.Add("PEDI", gedcomPedigrees[qualifier])
+		}
+	}
+
+	for _, spouse := range fam.spouses {
+		switch spouse.Qualifier {
+		case interfaces.RelationQualifierCohabitation:
+		case interfaces.RelationQualifierEngaged:
+			familyEvent(fam.record, "ENGA", spouse.StartDate)
+		case "":
+			if !spouse.StartDate.IsZero() {
+				familyEvent(fam.record, "MARR", spouse.StartDate)
+			}
+		default:
+			marriage := familyEvent(fam.record, "MARR", spouse.StartDate)
+			if spouse.Qualifier == interfaces.RelationQualifierCivilUnion {
+				marriage.Add("TYPE", "Civil union")
+			}
+			if spouse.Qualifier == interfaces.RelationQualifierDivorced {
+				familyEvent(fam.record, "DIV", spouse.EndDate)
+			}
+		}
+		if spouse.Notes != "" {
+			fam.record.Add("NOTE", spouse.Notes)
+		}
+	}
+}
+
+// familyEvent adds a family event with a date to a FAM record. An event without a known date is written as
+// "Y", saying it took place.
+func familyEvent(record *gedcom.Record, tag string, date interfaces.GenealogicalDate) *gedcom.Record {
+	if date.IsZero() {
+		return record.Add(tag, "Y")
+	}
+	event := record.Add(tag, "")
+	event.Add("DATE", gedcom.FormatDate(date))
+	return event
+}
+
+// qualifier returns the qualifier of the parent edges to a child in the family, and whether the child is linked
+// to all of their parents in the family in the same way
+func (c familyChild) qualifier() (string, bool) {
+	if c.husbandQualifier != "" && c.wifeQualifier != "" && c.husbandQualifier != c.wifeQualifier {
+		return "", false
+	}
+	if c.husbandQualifier != "" {
+		return c.husbandQualifier, true
+	}
+	return c.wifeQualifier, true
+}
+
+// enumeration adds a line with a value of an enumeration: value in GEDCOM 5.5.1, and value7 in GEDCOM 7.0,
+// where values outside the enumeration are written as OTHER with value as the phrase
+func (ex *gedcomExporter) enumeration(record *gedcom.Record, tag, value, value7 string) {
+	switch {
+	case !ex.version7:
+		record.Add(tag, value)
+	case value7 != "":
+		record.Add(tag, value7)
+	default:
+		record.Add(tag, "OTHER").Add("PHRASE", value)
+	}
+}
+
+// extension adds a line with an extension tag, noting the tag so GEDCOM 7.0 files can declare it
+func (ex *gedcomExporter) extension(record *gedcom.Record, tag, value string) {
+	record.Add(tag, value)
+	ex.extensions[tag] = gedcom7.ExtensionURIs[tag]
+}
@@ -0,0 +1,77 @@
+package v1exportservice
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/rogerwesterbo/familytree/pkg/gedcom"
+	"github.com/rogerwesterbo/familytree/pkg/gedcom7"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// writeGedcom7 writes the persons and the relationships between them as a GEDCOM 7.0 file submitted by submitter
+func writeGedcom7(w io.Writer, persons []interfaces.Person, relationships []interfaces.Relationship, places map[string]interfaces.Place, numbers descendantNumbers, submitter string) error {
+	ex := newGedcomExporter(persons, places, numbers, true)
+	records := ex.records(relationships, submitter)
+	return gedcom7.Write(w, ex.header7(), records)
+}
+
+// writeGedzip writes a GEDZIP archive with a GEDCOM 7.0 file of the persons and the relationships between them,
+// and the media files attached to the persons who are not redacted
+func (s *ExportService) writeGedzip(ctx context.Context, w io.Writer, persons []interfaces.Person, relationships []interfaces.Relationship, places map[string]interfaces.Place, numbers descendantNumbers, submitter string) error {
+	media, err := s.mediaService.ListMedia(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list media: %w", err)
+	}
+
+	ex := newGedcomExporter(persons, places, numbers, true)
+	records := ex.records(relationships, submitter)
+
+	var files []gedcom7.ArchiveFile
+	for _, item := range media {
+		var individuals []*gedcom.Record
+		for _, subjectID := range item.Subjects {
+			if person, found := ex.persons[subjectID]; found && !person.Redacted {
+				individuals = append(individuals, ex.individuals[subjectID])
+			}
+		}
+		if len(individuals) == 0 {
+			continue
+		}
+
+		// The media key keeps files with the same name apart
+		filePath := "media/" + item.Key
+		if item.FileName != "" {
+			filePath += "-" + item.FileName
+		}
+
+		obje := &gedcom.Record{XRef: fmt.Sprintf("@O%d@", len(files)+1), Tag: "OBJE"}
+		file := obje.Add("FILE", gedcom7.FileReference(filePath))
+		file.Add("FORM", item.MimeType)
+		if item.Caption != "" {
+			file.Add("TITL", item.Caption)
+		}
+		for _, indi := range individuals {
+			indi.Add("OBJE", obje.XRef)
+		}
+		records = append(records, obje)
+
+		mediaID := item.ID
+		files = append(files, gedcom7.ArchiveFile{
+			Path: filePath,
+			Open: func() (io.ReadCloser, error) {
+				_, content, err := s.mediaService.OpenMediaContent(ctx, mediaID)
+				return content, err
+			},
+		})
+	}
+
+	return gedcom7.WriteArchive(w, ex.header7(), records, files)
+}
+
+// header7 creates the header of a GEDCOM 7.0 file, declaring the extension tags written to the records
+func (ex *gedcomExporter) header7() *gedcom.Record {
+	return gedcom7.NewHeader(gedcomSource, submitterXRef, ex.extensions, time.Now())
+}
@@ -0,0 +1,425 @@
+package v1exportservice
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rogerwesterbo/familytree/pkg/gedcom"
+	"github.com/rogerwesterbo/familytree/pkg/gedcomx"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// gedcomxNameTypes maps name types to GEDCOM X name types
+var gedcomxNameTypes = map[string]string{
+	interfaces.NameTypeBirth:      gedcomx.NameTypeBirth,
+	interfaces.NameTypeMarried:    gedcomx.NameTypeMarried,
+	interfaces.NameTypeAlias:      gedcomx.NameTypeAlsoKnown,
+	interfaces.NameTypeReligious:  gedcomx.NameTypeReligious,
+	interfaces.NameTypeFarm:       gedcomx.NameTypeFarm,
+	interfaces.NameTypePatronymic: gedcomx.NameTypePatronymic,
+}
+
+// gedcomxEventFacts maps the types of the events of persons to GEDCOM X fact types. Marriages and
+// divorces are written on the couple relationships instead.
+var gedcomxEventFacts = map[string]string{
+	interfaces.EventTypeBaptism:      gedcomx.FactBaptism,
+	interfaces.EventTypeConfirmation: gedcomx.FactConfirmation,
+	interfaces.EventTypeEmigration:   gedcomx.FactEmigration,
+	interfaces.EventTypeCensus:       gedcomx.FactCensus,
+	interfaces.EventTypeResidence:    gedcomx.FactResidence,
+	interfaces.EventTypeOccupation:   gedcomx.FactOccupation,
+	interfaces.EventTypeBurial:       gedcomx.FactBurial,
+}
+
+// gedcomxSpouseFacts maps spouse qualifiers to the couple fact starting the partnership
+var gedcomxSpouseFacts = map[string]string{
+	"":                                       gedcomx.FactMarriage,
+	interfaces.RelationQualifierMarried:      gedcomx.FactMarriage,
+	interfaces.RelationQualifierDivorced:     gedcomx.FactMarriage,
+	interfaces.RelationQualifierCivilUnion:   gedcomx.FactCivilUnion,
+	interfaces.RelationQualifierCohabitation: gedcomx.FactDomesticPartnership,
+	interfaces.RelationQualifierEngaged:      gedcomx.FactEngagement,
+}
+
+// gedcomxParentFacts maps parent qualifiers to parent-child fact types
+var gedcomxParentFacts = map[string]string{
+	interfaces.RelationQualifierBiological: gedcomx.FactBiologicalParent,
+	interfaces.RelationQualifierAdoptive:   gedcomx.FactAdoptiveParent,
+	interfaces.RelationQualifierStep:       gedcomx.FactStepParent,
+	interfaces.RelationQualifierFoster:     gedcomx.FactFosterParent,
+	interfaces.RelationQualifierGuardian:   gedcomx.FactGuardianParent,
+}
+
+// gedcomxExporter builds a GEDCOM X document of persons, the events they take part in and the relationships
+// between them, with the sources cited for them and the places they refer to
+type gedcomxExporter struct {
+	doc     *gedcomx.Document
+	persons map[string]interfaces.Person
+	places  map[string]interfaces.Place
+	// citations holds the citations of the persons, events and relationships by subject ID
+	citations map[string][]interfaces.CitationReference
+	// described holds the IDs of the source descriptions written
+	described map[string]bool
+	// repositories holds the IDs of the agents written for the repositories of sources, by name
+	repositories map[string]string
+}
+
+// writeGedcomX writes the persons, the events they take part in and the relationships between them as a
+// GEDCOM X JSON document submitted by submitter
+func (s *ExportService) writeGedcomX(ctx context.Context, w io.Writer, persons []interfaces.Person, relationships []interfaces.Relationship, events []interfaces.Event, places map[string]interfaces.Place, submitter string) error {
+	ex := &gedcomxExporter{
+		doc:          &gedcomx.Document{},
+		persons:      make(map[string]interfaces.Person, len(persons)),
+		places:       places,
+		described:    make(map[string]bool),
+		repositories: make(map[string]string),
+	}
+
+	// Citations are only written for what the viewer may see in full
+	var subjectIDs []string
+	for _, person := range persons {
+		ex.persons[person.ID] = person
+		if !person.Redacted {
+			subjectIDs = append(subjectIDs, person.ID)
+		}
+	}
+	for _, event := range events {
+		subjectIDs = append(subjectIDs, event.ID)
+	}
+	for _, relationship := range relationships {
+		if ex.visible(relationship.From) && ex.visible(relationship.To) {
+			subjectIDs = append(subjectIDs, relationship.ID)
+		}
+	}
+	citations, err := s.citationService.FindCitations(ctx, subjectIDs)
+	if err != nil {
+		return fmt.Errorf("failed to find citations: %w", err)
+	}
+	ex.citations = citations
+
+	if submitter == "" {
+		submitter = "Unknown"
+	}
+	ex.doc.Agents = append(ex.doc.Agents, gedcomx.Agent{ID: "A1", Names: []gedcomx.TextValue{{Value: submitter}}})
+	ex.doc.Attribution = &gedcomx.Attribution{
+		Contributor: &gedcomx.ResourceReference{Resource: "#A1"},
+		Modified:    time.Now().UnixMilli(),
+	}
+
+	for _, person := range persons {
+		ex.doc.Persons = append(ex.doc.Persons, ex.person(person, events))
+	}
+	// Child relationships are turned around, so a parent recorded both ways is one parent-child relationship
+	parentChild := make(map[[2]string]bool)
+	for _, relationship := range relationships {
+		relationship = relationship.AsParentEdge()
+		if relationship.RelationType == interfaces.RelationTypeParent {
+			pair := [2]string{relationship.From, relationship.To}
+			if parentChild[pair] {
+				continue
+			}
+			parentChild[pair] = true
+		}
+		if converted, ok := ex.relationship(relationship, events); ok {
+			ex.doc.Relationships = append(ex.doc.Relationships, converted)
+		}
+	}
+	ex.writePlaces()
+
+	return gedcomx.Write(w, ex.doc)
+}
+
+// person converts a person with the facts of the events they are a principal in
+func (ex *gedcomxExporter) person(person interfaces.Person, events []interfaces.Event) gedcomx.Person {
+	converted := gedcomx.Person{ID: "I" + person.Key, Private: person.Redacted}
+
+	for _, name := range names(person) {
+		form := gedcomx.NameForm{FullText: strings.TrimSpace(name.GivenName + " " + name.Surname)}
+		if name.GivenName != "" {
+			form.Parts = append(form.Parts, gedcomx.NamePart{Type: gedcomx.NamePartGiven, Value: name.GivenName})
+		}
+		if name.Surname != "" {
+			form.Parts = append(form.Parts, gedcomx.NamePart{Type: gedcomx.NamePartSurname, Value: name.Surname})
+		}
+		converted.Names = append(converted.Names, gedcomx.Name{
+			Type:      gedcomxNameTypes[name.Type],
+			Preferred: name.Preferred,
+			NameForms: []gedcomx.NameForm{form},
+		})
+	}
+
+	switch strings.ToLower(person.Gender) {
+	case "":
+	case "male":
+		converted.Gender = &gedcomx.Gender{Type: gedcomx.GenderMale}
+	case "female":
+		converted.Gender = &gedcomx.Gender{Type: gedcomx.GenderFemale}
+	default:
+		converted.Gender = &gedcomx.Gender{Type: gedcomx.GenderUnknown}
+	}
+
+	if person.Redacted {
+		return converted
+	}
+
+	for _, fact := range []struct {
+		factType string
+		name     string
+		date     interfaces.GenealogicalDate
+		placeID  string
+	}{
+		{gedcomx.FactBirth, "birth", person.BirthDate, person.BirthPlaceID},
+		{gedcomx.FactDeath, "death", person.DeathDate, person.DeathPlaceID},
+	} {
+		sources := ex.sources(person.ID, fact.name)
+		if fact.date.IsZero() && fact.placeID == "" && len(sources) == 0 {
+			continue
+		}
+		converted.Facts = append(converted.Facts, gedcomx.Fact{
+			Type:    fact.factType,
+			Date:    factDate(fact.date),
+			Place:   ex.placeReference(fact.placeID),
+			Sources: sources,
+		})
+	}
+
+	for _, event := range events {
+		factType, found := gedcomxEventFacts[event.Type]
+		if !found || !isPrincipal(event, person.ID) {
+			continue
+		}
+		converted.Facts = append(converted.Facts, gedcomx.Fact{
+			Type:    factType,
+			Date:    factDate(event.Date),
+			Place:   ex.placeReference(event.PlaceID),
+			Value:   event.Description,
+			Sources: ex.sources(event.ID, ""),
+		})
+	}
+
+	// Citations of the names, the gender or the person as a whole are given for the person
+	for _, reference := range ex.citations[person.ID] {
+		if reference.Fact != "birth" && reference.Fact != "death" {
+			converted.Sources = append(converted.Sources, ex.sourceReference(reference)...)
+		}
+	}
+
+	return converted
+}
+
+// relationship converts a spouse relationship to a couple relationship or a parent relationship to a
+// parent-child relationship. Relationships of redacted persons only tell who they connect.
+func (ex *gedcomxExporter) relationship(relationship interfaces.Relationship, events []interfaces.Event) (gedcomx.Relationship, bool) {
+	from, fromFound := ex.persons[relationship.From]
+	to, toFound := ex.persons[relationship.To]
+	if !fromFound || !toFound {
+		return gedcomx.Relationship{}, false
+	}
+
+	converted := gedcomx.Relationship{
+		ID:      "R" + relationship.Key,
+		Person1: gedcomx.ResourceReference{Resource: "#I" + from.Key},
+		Person2: gedcomx.ResourceReference{Resource: "#I" + to.Key},
+	}
+	switch relationship.RelationType {
+	case interfaces.RelationTypeSpouse:
+		converted.Type = gedcomx.RelationshipCouple
+	case interfaces.RelationTypeParent:
+		converted.Type = gedcomx.RelationshipParentChild
+	default:
+		// Siblings follow from the parents they share
+		return gedcomx.Relationship{}, false
+	}
+	if from.Redacted || to.Redacted {
+		return converted, true
+	}
+
+	if relationship.RelationType == interfaces.RelationTypeParent {
+		converted.Facts = append(converted.Facts, gedcomx.Fact{Type: gedcomxParentFacts[relationship.ParentQualifier()]})
+	} else {
+		converted.Facts = ex.coupleFacts(relationship, events)
+	}
+
+	converted.Sources = ex.sources(relationship.ID, "")
+	if notes := strings.TrimSpace(relationship.Notes); notes != "" {
+		converted.Notes = append(converted.Notes, gedcomx.Note{Text: notes})
+	}
+
+	return converted, true
+}
+
+// coupleFacts returns the facts of a spouse relationship: how the partnership started, with the place and
+// sources of the marriage event of the partners, and the divorce ending it
+func (ex *gedcomxExporter) coupleFacts(relationship interfaces.Relationship, events []interfaces.Event) []gedcomx.Fact {
+	var facts []gedcomx.Fact
+
+	marriage := coupleEvent(events, interfaces.EventTypeMarriage, relationship)
+	start := gedcomx.Fact{Type: gedcomxSpouseFacts[relationship.Qualifier], Date: factDate(relationship.StartDate)}
+	if marriage != nil && start.Type == gedcomx.FactMarriage {
+		if start.Date == nil {
+			start.Date = factDate(marriage.Date)
+		}
+		start.Place = ex.placeReference(marriage.PlaceID)
+		start.Value = marriage.Description
+		start.Sources = ex.sources(marriage.ID, "")
+	}
+	// Partners without a qualifier are only known to be married from a date or a marriage event
+	if start.Type != "" && (relationship.Qualifier != "" || start.Date != nil || marriage != nil) {
+		facts = append(facts, start)
+	}
+
+	divorce := coupleEvent(events, interfaces.EventTypeDivorce, relationship)
+	if relationship.Qualifier == interfaces.RelationQualifierDivorced || divorce != nil {
+		end := gedcomx.Fact{Type: gedcomx.FactDivorce, Date: factDate(relationship.EndDate)}
+		if divorce != nil {
+			if end.Date == nil {
+				end.Date = factDate(divorce.Date)
+			}
+			end.Place = ex.placeReference(divorce.PlaceID)
+			end.Value = divorce.Description
+			end.Sources = ex.sources(divorce.ID, "")
+		}
+		facts = append(facts, end)
+	}
+
+	return facts
+}
+
+// sources returns the references to the citations of a fact of a subject, or of the subject as a whole
+// when fact is empty
+func (ex *gedcomxExporter) sources(subjectID, fact string) []gedcomx.SourceReference {
+	var references []gedcomx.SourceReference
+	for _, reference := range ex.citations[subjectID] {
+		if reference.Fact == fact {
+			references = append(references, ex.sourceReference(reference)...)
+		}
+	}
+	return references
+}
+
+// sourceReference describes a citation as a part of its source, with the source described before it,
+// and returns the reference to the description. Citations of sources that no longer exist are left out.
+func (ex *gedcomxExporter) sourceReference(reference interfaces.CitationReference) []gedcomx.SourceReference {
+	source, citation := reference.Source, reference.Citation
+	if source == nil {
+		return nil
+	}
+
+	sourceID := "S" + source.Key
+	if !ex.described[sourceID] {
+		ex.described[sourceID] = true
+		description := gedcomx.SourceDescription{
+			ID:        sourceID,
+			Titles:    []gedcomx.TextValue{{Value: source.Title}},
+			Citations: []gedcomx.TextValue{{Value: source.Title}},
+			About:     source.URL,
+		}
+		if notes := strings.TrimSpace(source.Notes); notes != "" {
+			description.Notes = append(description.Notes, gedcomx.Note{Text: notes})
+		}
+		if source.Repository != "" {
+			description.Repository = &gedcomx.ResourceReference{Resource: "#" + ex.repository(source.Repository)}
+		}
+		ex.doc.SourceDescriptions = append(ex.doc.SourceDescriptions, description)
+	}
+
+	citationID := "C" + citation.Key
+	if !ex.described[citationID] {
+		ex.described[citationID] = true
+		// The citation names the source first, as in "Ministerialbok for Aker, page 42"
+		text := source.Title
+		if citation.Page != "" {
+			text += ", " + citation.Page
+		}
+		description := gedcomx.SourceDescription{
+			ID:          citationID,
+			Citations:   []gedcomx.TextValue{{Value: text}},
+			About:       citation.URL,
+			ComponentOf: &gedcomx.SourceReference{Description: "#" + sourceID},
+		}
+		if citation.Entry != "" {
+			description.Titles = []gedcomx.TextValue{{Value: citation.Entry}}
+		}
+		if citation.Transcription != "" {
+			description.Descriptions = []gedcomx.TextValue{{Value: citation.Transcription}}
+		}
+		ex.doc.SourceDescriptions = append(ex.doc.SourceDescriptions, description)
+	}
+
+	return []gedcomx.SourceReference{{Description: "#" + citationID}}
+}
+
+// repository returns the ID of the agent of a repository, adding the agent when needed
+func (ex *gedcomxExporter) repository(name string) string {
+	if id, found := ex.repositories[name]; found {
+		return id
+	}
+	id := fmt.Sprintf("A%d", len(ex.doc.Agents)+1)
+	ex.repositories[name] = id
+	ex.doc.Agents = append(ex.doc.Agents, gedcomx.Agent{ID: id, Names: []gedcomx.TextValue{{Value: name}}})
+	return id
+}
+
+// placeReference returns the reference to a place, or nil when there is none
+func (ex *gedcomxExporter) placeReference(placeID string) *gedcomx.PlaceReference {
+	place, found := ex.places[placeID]
+	if !found {
+		return nil
+	}
+	return &gedcomx.PlaceReference{Original: placeName(ex.places, placeID), Description: "#PL" + place.Key}
+}
+
+// writePlaces describes the places, each within the place containing it
+func (ex *gedcomxExporter) writePlaces() {
+	for _, place := range ex.places {
+		description := gedcomx.PlaceDescription{
+			ID:        "PL" + place.Key,
+			Names:     []gedcomx.TextValue{{Value: place.Name}},
+			Latitude:  place.Latitude,
+			Longitude: place.Longitude,
+		}
+		if parent, found := ex.places[place.ParentID]; found {
+			description.Jurisdiction = &gedcomx.ResourceReference{Resource: "#PL" + parent.Key}
+		}
+		ex.doc.Places = append(ex.doc.Places, description)
+	}
+	slices.SortFunc(ex.doc.Places, func(a, b gedcomx.PlaceDescription) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+}
+
+// visible reports whether a person is exported without being redacted
+func (ex *gedcomxExporter) visible(personID string) bool {
+	person, found := ex.persons[personID]
+	return found && !person.Redacted
+}
+
+// factDate returns the date of a fact as written in GEDCOM and in the formal format, or nil when unknown
+func factDate(date interfaces.GenealogicalDate) *gedcomx.Date {
+	if date.IsZero() {
+		return nil
+	}
+	return &gedcomx.Date{Original: gedcom.FormatDate(date), Formal: gedcomx.FormatDate(date)}
+}
+
+// coupleEvent returns the first event of a type both partners of a spouse relationship are principals in
+func coupleEvent(events []interfaces.Event, eventType string, relationship interfaces.Relationship) *interfaces.Event {
+	for i := range events {
+		if events[i].Type == eventType && isPrincipal(events[i], relationship.From) && isPrincipal(events[i], relationship.To) {
+			return &events[i]
+		}
+	}
+	return nil
+}
+
+// isPrincipal reports whether a person is a principal of an event
+func isPrincipal(event interfaces.Event, personID string) bool {
+	return slices.Contains(event.Participants, interfaces.EventParticipant{
+		PersonID: personID,
+		Role:     interfaces.ParticipantRolePrincipal,
+	})
+}
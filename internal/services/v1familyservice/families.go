@@ -0,0 +1,150 @@
+package v1familyservice
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/internal/services/v1personservice"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// familyBuilder collects the members of a family while it is being derived
+type familyBuilder struct {
+	partnerIDs []string
+	union      *interfaces.Relationship
+	childIDs   map[string]bool
+}
+
+// DeriveFamilies groups spouse edges with the children the partners share through parent and child edges.
+// A child with one known parent forms a single-parent family. A child with more than two parents is
+// placed with every married couple among them, or with each parent alone when none of them are married.
+func DeriveFamilies(relationships []interfaces.Relationship, persons map[string]interfaces.Person) []interfaces.Family {
+	families := make(map[string]*familyBuilder)
+	family := func(partnerIDs ...string) *familyBuilder {
+		sort.Strings(partnerIDs)
+		key := strings.Join(partnerIDs, "|")
+		if families[key] == nil {
+			families[key] = &familyBuilder{partnerIDs: partnerIDs, childIDs: make(map[string]bool)}
+		}
+		return families[key]
+	}
+
+	parents := make(map[string]map[string]bool)
+	addParent := func(parentID, childID string) {
+		if parents[childID] == nil {
+			parents[childID] = make(map[string]bool)
+		}
+		parents[childID][parentID] = true
+	}
+
+	unions := make(map[string]bool)
+	for i := range relationships {
+		rel := &relationships[i]
+		switch rel.RelationType {
+		case interfaces.RelationTypeParent:
+			addParent(rel.From, rel.To)
+		case interfaces.RelationTypeChild:
+			addParent(rel.To, rel.From)
+		case interfaces.RelationTypeSpouse:
+			builder := family(rel.From, rel.To)
+			if builder.union == nil {
+				builder.union = rel
+			}
+			unions[strings.Join(builder.partnerIDs, "|")] = true
+		}
+	}
+
+	for childID, parentSet := range parents {
+		parentIDs := make([]string, 0, len(parentSet))
+		for parentID := range parentSet {
+			parentIDs = append(parentIDs, parentID)
+		}
+		sort.Strings(parentIDs)
+
+		if len(parentIDs) <= 2 {
+			family(parentIDs...).childIDs[childID] = true
+			continue
+		}
+
+		placed := false
+		for i := 0; i < len(parentIDs); i++ {
+			for j := i + 1; j < len(parentIDs); j++ {
+				if unions[parentIDs[i]+"|"+parentIDs[j]] {
+					family(parentIDs[i], parentIDs[j]).childIDs[childID] = true
+					placed = true
+				}
+			}
+		}
+		if !placed {
+			for _, parentID := range parentIDs {
+				family(parentID).childIDs[childID] = true
+			}
+		}
+	}
+
+	result := make([]interfaces.Family, 0, len(families))
+	for _, builder := range families {
+		result = append(result, builder.build(persons))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
+
+// build resolves the members of a family to persons
+func (b *familyBuilder) build(persons map[string]interfaces.Person) interfaces.Family {
+	keys := make([]string, 0, len(b.partnerIDs))
+	partners := make([]interfaces.Person, 0, len(b.partnerIDs))
+	for _, partnerID := range b.partnerIDs {
+		keys = append(keys, v1personservice.PersonKey(partnerID))
+		partners = append(partners, lookupPerson(persons, partnerID))
+	}
+
+	children := make([]interfaces.Person, 0, len(b.childIDs))
+	for childID := range b.childIDs {
+		children = append(children, lookupPerson(persons, childID))
+	}
+	sort.SliceStable(children, func(i, j int) bool {
+		if c := children[i].BirthDate.Compare(children[j].BirthDate); c != 0 {
+			return c < 0
+		}
+		return children[i].ID < children[j].ID
+	})
+
+	return interfaces.Family{
+		ID:       strings.Join(keys, "+"),
+		Partners: partners,
+		Union:    b.union,
+		Children: children,
+	}
+}
+
+// lookupPerson finds a person by ID, falling back to a person holding only the ID
+func lookupPerson(persons map[string]interfaces.Person, id string) interfaces.Person {
+	if person, found := persons[id]; found {
+		return person
+	}
+	return interfaces.Person{ID: id, Key: v1personservice.PersonKey(id)}
+}
+
+// hasPartner reports whether a person is a partner in a family
+func hasPartner(family interfaces.Family, personID string) bool {
+	for _, partner := range family.Partners {
+		if partner.ID == personID {
+			return true
+		}
+	}
+	return false
+}
+
+// hasChild reports whether a person is a child in a family
+func hasChild(family interfaces.Family, personID string) bool {
+	for _, child := range family.Children {
+		if child.ID == personID {
+			return true
+		}
+	}
+	return false
+}
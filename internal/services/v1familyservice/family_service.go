@@ -0,0 +1,242 @@
+package v1familyservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/internal/services/v1personservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1relationshipservice"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// ErrInvalidFamily is returned when a family create request fails validation
+var ErrInvalidFamily = errors.New("invalid family")
+
+// FamilyService handles business logic for family units derived from relationship edges
+type FamilyService struct {
+	relationshipRepo    interfaces.RelationshipRepository
+	personRepo          interfaces.PersonRepository
+	relationshipService *v1relationshipservice.RelationshipService
+}
+
+// NewFamilyService creates a new family service
+func NewFamilyService(
+	relationshipRepo interfaces.RelationshipRepository,
+	personRepo interfaces.PersonRepository,
+	relationshipService *v1relationshipservice.RelationshipService,
+) *FamilyService {
+	return &FamilyService{
+		relationshipRepo:    relationshipRepo,
+		personRepo:          personRepo,
+		relationshipService: relationshipService,
+	}
+}
+
+// ListFamilies derives every family in the tree
+func (s *FamilyService) ListFamilies(ctx context.Context) ([]interfaces.Family, error) {
+	relationships, err := s.relationshipRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	persons, err := s.personRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return DeriveFamilies(relationships, personsByID(persons)), nil
+}
+
+// GetPersonFamilies derives the families a person is a partner in and the families they are a child in
+func (s *FamilyService) GetPersonFamilies(ctx context.Context, personID string) (asPartner, asChild []interfaces.Family, err error) {
+	if personID == "" {
+		return nil, nil, fmt.Errorf("person ID is required")
+	}
+
+	person, err := s.personRepo.GetByID(ctx, v1personservice.PersonKey(personID))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	families, err := s.deriveAround(ctx, person.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	asPartner = []interfaces.Family{}
+	asChild = []interfaces.Family{}
+	for _, family := range families {
+		if hasPartner(family, person.ID) {
+			asPartner = append(asPartner, family)
+		}
+		if hasChild(family, person.ID) {
+			asChild = append(asChild, family)
+		}
+	}
+
+	return asPartner, asChild, nil
+}
+
+// CreateFamily creates a family by writing the spouse edge between the partners, when they are married,
+// and a parent edge from every partner to every child. Edges that already exist are kept as they are.
+// When an edge is rejected, the edges written so far are removed again.
+func (s *FamilyService) CreateFamily(ctx context.Context, req *interfaces.FamilyCreateRequest) (*interfaces.Family, error) {
+	if err := s.validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+
+	partnerIDs := documentIDs(req.Partners)
+	childIDs := documentIDs(req.Children)
+
+	for _, id := range append(append([]string{}, partnerIDs...), childIDs...) {
+		if _, err := s.personRepo.GetByID(ctx, v1personservice.PersonKey(id)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Find the edges the partners already have
+	var existing []interfaces.Relationship
+	for _, partnerID := range partnerIDs {
+		relationships, err := s.relationshipRepo.FindByPerson(ctx, partnerID)
+		if err != nil {
+			return nil, err
+		}
+		existing = append(existing, relationships...)
+	}
+
+	var planned []interfaces.RelationshipCreateRequest
+	if req.Married && !v1relationshipservice.HasEdge(existing, partnerIDs[0], partnerIDs[1], interfaces.RelationTypeSpouse) {
+		planned = append(planned, interfaces.RelationshipCreateRequest{
+			From:         partnerIDs[0],
+			To:           partnerIDs[1],
+			RelationType: interfaces.RelationTypeSpouse,
+			StartDate:    req.StartDate,
+			EndDate:      req.EndDate,
+			Notes:        req.Notes,
+		})
+	}
+	for _, partnerID := range partnerIDs {
+		for _, childID := range childIDs {
+			if isParentOf(existing, partnerID, childID) {
+				continue
+			}
+			planned = append(planned, interfaces.RelationshipCreateRequest{
+				From:         partnerID,
+				To:           childID,
+				RelationType: interfaces.RelationTypeParent,
+			})
+		}
+	}
+
+	var created []*interfaces.Relationship
+	for i := range planned {
+		relationship, err := s.relationshipService.CreateRelationship(ctx, &planned[i])
+		if err != nil {
+			s.rollback(ctx, created)
+			return nil, err
+		}
+		created = append(created, relationship)
+	}
+
+	families, err := s.deriveAround(ctx, partnerIDs[0])
+	if err != nil {
+		return nil, err
+	}
+	for i := range families {
+		if len(families[i].Partners) == len(partnerIDs) && hasPartner(families[i], partnerIDs[0]) &&
+			hasPartner(families[i], partnerIDs[len(partnerIDs)-1]) {
+			return &families[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to find created family")
+}
+
+// deriveAround derives the families around a person from the edges of the person, their parents and their children
+func (s *FamilyService) deriveAround(ctx context.Context, personID string) ([]interfaces.Family, error) {
+	relationships, err := s.relationshipRepo.FindFamilyEdges(ctx, personID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{personID}
+	for _, rel := range relationships {
+		ids = append(ids, rel.From, rel.To)
+	}
+	persons, err := s.personRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return DeriveFamilies(relationships, personsByID(persons)), nil
+}
+
+// rollback removes relationships created before a family could not be completed
+func (s *FamilyService) rollback(ctx context.Context, created []*interfaces.Relationship) {
+	for _, relationship := range created {
+		_ = s.relationshipService.DeleteRelationship(ctx, relationship.Key)
+	}
+}
+
+// validateCreateRequest validates a family create request
+func (s *FamilyService) validateCreateRequest(req *interfaces.FamilyCreateRequest) error {
+	if len(req.Partners) < 1 || len(req.Partners) > 2 {
+		return fmt.Errorf("%w: a family must have one or two partners", ErrInvalidFamily)
+	}
+	if req.Married && len(req.Partners) != 2 {
+		return fmt.Errorf("%w: a married family must have two partners", ErrInvalidFamily)
+	}
+	if !req.Married && len(req.Children) == 0 {
+		return fmt.Errorf("%w: a family must have married partners or children", ErrInvalidFamily)
+	}
+	if !req.Married && (!req.StartDate.IsZero() || !req.EndDate.IsZero() || req.Notes != "") {
+		return fmt.Errorf("%w: startDate, endDate and notes describe the marriage and need married to be true", ErrInvalidFamily)
+	}
+
+	seen := make(map[string]bool)
+	for _, id := range append(append([]string{}, req.Partners...), req.Children...) {
+		if strings.TrimSpace(id) == "" {
+			return fmt.Errorf("%w: partner and child IDs must not be empty", ErrInvalidFamily)
+		}
+		documentID := v1personservice.PersonDocumentID(strings.TrimSpace(id))
+		if seen[documentID] {
+			return fmt.Errorf("%w: %s is listed more than once", ErrInvalidFamily, documentID)
+		}
+		seen[documentID] = true
+	}
+
+	return nil
+}
+
+// isParentOf reports whether the relationships already record a person as the parent of a child
+func isParentOf(relationships []interfaces.Relationship, parentID, childID string) bool {
+	for _, rel := range relationships {
+		if rel.RelationType == interfaces.RelationTypeParent && rel.From == parentID && rel.To == childID {
+			return true
+		}
+		if rel.RelationType == interfaces.RelationTypeChild && rel.From == childID && rel.To == parentID {
+			return true
+		}
+	}
+	return false
+}
+
+// personsByID indexes persons by document ID
+func personsByID(persons []interfaces.Person) map[string]interfaces.Person {
+	byID := make(map[string]interfaces.Person, len(persons))
+	for _, person := range persons {
+		byID[person.ID] = person
+	}
+	return byID
+}
+
+// documentIDs trims and normalizes person keys or IDs to document IDs
+func documentIDs(ids []string) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, v1personservice.PersonDocumentID(strings.TrimSpace(id)))
+	}
+	return result
+}
@@ -0,0 +1,137 @@
+package v1familyservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+func TestCreateFamilyInvalid(t *testing.T) {
+	date, err := interfaces.ParseGenealogicalDate("1843")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		req  interfaces.FamilyCreateRequest
+	}{
+		{name: "no partners", req: interfaces.FamilyCreateRequest{Children: []string{"3"}}},
+		{name: "three partners", req: interfaces.FamilyCreateRequest{Partners: []string{"1", "2", "3"}, Children: []string{"4"}}},
+		{name: "one married partner", req: interfaces.FamilyCreateRequest{Partners: []string{"1"}, Married: true}},
+		{name: "one partner and no children", req: interfaces.FamilyCreateRequest{Partners: []string{"1"}}},
+		{name: "unmarried partners and no children", req: interfaces.FamilyCreateRequest{Partners: []string{"1", "2"}}},
+		{name: "marriage date of unmarried partners", req: interfaces.FamilyCreateRequest{Partners: []string{"1", "2"}, Children: []string{"3"}, StartDate: date}},
+		{name: "end date of unmarried partners", req: interfaces.FamilyCreateRequest{Partners: []string{"1", "2"}, Children: []string{"3"}, EndDate: date}},
+		{name: "notes of unmarried partners", req: interfaces.FamilyCreateRequest{Partners: []string{"1", "2"}, Children: []string{"3"}, Notes: "Married in Oslo"}},
+		{name: "empty child ID", req: interfaces.FamilyCreateRequest{Partners: []string{"1"}, Children: []string{" "}}},
+		{name: "partner listed as child", req: interfaces.FamilyCreateRequest{Partners: []string{"1", "2"}, Children: []string{"persons/1"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The request is rejected before the service reaches the repositories it does not have
+			service := NewFamilyService(nil, nil, nil)
+			_, err := service.CreateFamily(context.Background(), &tt.req)
+			if !errors.Is(err, ErrInvalidFamily) {
+				t.Errorf("CreateFamily returned %v, want ErrInvalidFamily", err)
+			}
+		})
+	}
+}
+
+func TestValidateCreateRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  interfaces.FamilyCreateRequest
+	}{
+		{name: "married couple", req: interfaces.FamilyCreateRequest{Partners: []string{"1", "2"}, Married: true, Notes: "Married in Oslo"}},
+		{name: "unmarried parents", req: interfaces.FamilyCreateRequest{Partners: []string{"1", "2"}, Children: []string{"3", "4"}}},
+		{name: "single parent", req: interfaces.FamilyCreateRequest{Partners: []string{"1"}, Children: []string{"3"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewFamilyService(nil, nil, nil)
+			if err := service.validateCreateRequest(&tt.req); err != nil {
+				t.Errorf("validateCreateRequest returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDeriveFamilies(t *testing.T) {
+	relationship := func(from, relationType, to string) interfaces.Relationship {
+		return interfaces.Relationship{From: "persons/" + from, To: "persons/" + to, RelationType: relationType}
+	}
+
+	tests := []struct {
+		name          string
+		relationships []interfaces.Relationship
+		// want maps the ID of every family to the number of its children
+		want map[string]int
+	}{
+		{
+			name:          "married couple without children",
+			relationships: []interfaces.Relationship{relationship("1", "spouse", "2")},
+			want:          map[string]int{"1+2": 0},
+		},
+		{
+			name: "parents through parent and child edges",
+			relationships: []interfaces.Relationship{
+				relationship("1", "spouse", "2"), relationship("1", "parent", "3"), relationship("3", "child", "2"),
+			},
+			want: map[string]int{"1+2": 1},
+		},
+		{
+			name:          "single parent",
+			relationships: []interfaces.Relationship{relationship("1", "parent", "3"), relationship("1", "parent", "4")},
+			want:          map[string]int{"1": 2},
+		},
+		{
+			name: "remarriage",
+			relationships: []interfaces.Relationship{
+				relationship("1", "spouse", "2"), relationship("1", "spouse", "5"),
+				relationship("1", "parent", "3"), relationship("2", "parent", "3"),
+				relationship("1", "parent", "6"), relationship("5", "parent", "6"),
+			},
+			want: map[string]int{"1+2": 1, "1+5": 1},
+		},
+		{
+			name: "child with a married couple among three parents",
+			relationships: []interfaces.Relationship{
+				relationship("1", "spouse", "2"),
+				relationship("1", "parent", "3"), relationship("2", "parent", "3"), relationship("4", "parent", "3"),
+			},
+			want: map[string]int{"1+2": 1},
+		},
+		{
+			name: "child with three unmarried parents",
+			relationships: []interfaces.Relationship{
+				relationship("1", "parent", "3"), relationship("2", "parent", "3"), relationship("4", "parent", "3"),
+			},
+			want: map[string]int{"1": 1, "2": 1, "4": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			families := DeriveFamilies(tt.relationships, nil)
+			if len(families) != len(tt.want) {
+				t.Fatalf("got %d families, want %d", len(families), len(tt.want))
+			}
+			for _, family := range families {
+				children, ok := tt.want[family.ID]
+				if !ok {
+					t.Errorf("unexpected family %s", family.ID)
+					continue
+				}
+				if len(family.Children) != children {
+					t.Errorf("family %s has %d children, want %d", family.ID, len(family.Children), children)
+				}
+			}
+		})
+	}
+}
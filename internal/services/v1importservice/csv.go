@@ -0,0 +1,437 @@
+package v1importservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/internal/services/v1placeservice"
+	"github.com/rogerwesterbo/familytree/pkg/gedcom"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// Sheets of a CSV import
+const (
+	csvSheetPersons       = "persons"
+	csvSheetRelationships = "relationships"
+)
+
+// csvPersonFields are the fields the columns of the persons sheet can be mapped to. The id is the local
+// identifier the relationships sheet refers to rows by, and notes become a note on the person.
+var csvPersonFields = []string{
+	"id", "firstName", "lastName", "gender", "birthDate", "birthPlace", "deathDate", "deathPlace",
+	"email", "phone", "privacy", "notes",
+}
+
+// csvRelationshipFields are the columns of the relationships sheet. From and to hold the ids of person rows.
+var csvRelationshipFields = []string{"from", "to", "relationType", "qualifier", "startDate", "endDate", "notes"}
+
+// csvGenders maps the ways spreadsheets tend to write genders to genders
+var csvGenders = map[string]string{
+	"m":      "male",
+	"mann":   "male",
+	"f":      "female",
+	"k":      "female",
+	"kvinne": "female",
+}
+
+// csvDayMonthYear matches dates written day first, as in 12.05.1843
+var csvDayMonthYear = regexp.MustCompile(`^(\d{1,2})\.(\d{1,2})\.(\d{4})$`)
+
+// csvRow is a row of a sheet with its values by field
+type csvRow struct {
+	// number is the row number as a spreadsheet shows it, with the header as row 1
+	number int
+	values map[string]string
+}
+
+// csvSheet is a sheet read with the columns mapped to fields
+type csvSheet struct {
+	rows []csvRow
+	// fields holds the fields a column was mapped to
+	fields map[string]bool
+	// unmapped holds the headers of the columns that were not mapped to a field
+	unmapped []string
+}
+
+// csvImporter creates the persons of a spreadsheet and the relationships between them, or only validates
+// them in a dry run
+type csvImporter struct {
+	*ImportService
+	opts   interfaces.CSVImportOptions
+	author interfaces.NoteAuthor
+	report *interfaces.ImportReport
+	places *v1placeservice.PlaceResolver
+	// persons holds the IDs of the persons created by their local identifier, and failed the identifiers of
+	// the rows that were left out
+	persons map[string]string
+	failed  map[string]bool
+}
+
+// ImportCSV imports a spreadsheet of persons, saved as CSV, with an optional spreadsheet of the relationships
+// between them. Rows that fail validation are left out and reported with their row number; in a dry run
+// every row is validated and nothing is written.
+func (s *ImportService) ImportCSV(ctx context.Context, persons, relationships io.Reader, opts interfaces.CSVImportOptions, author interfaces.NoteAuthor) (*interfaces.ImportReport, error) {
+	personSheet, err := readCSVSheet(persons, csvSheetPersons, opts.Mapping, csvPersonFields)
+	if err != nil {
+		return nil, err
+	}
+	var relationshipSheet *csvSheet
+	if relationships != nil {
+		relationshipSheet, err = readCSVSheet(relationships, csvSheetRelationships, nil, csvRelationshipFields)
+		if err != nil {
+			return nil, err
+		}
+		if !personSheet.fields["id"] {
+			return nil, fmt.Errorf("%w: persons: an id column is required for the relationships to refer to", ErrInvalidImport)
+		}
+	}
+
+	im := &csvImporter{
+		ImportService: s,
+		opts:          opts,
+		author:        author,
+		report:        newReport(interfaces.ImportFormatCSV),
+		places:        v1placeservice.NewPlaceResolver(s.placeService, ""),
+		persons:       make(map[string]string),
+		failed:        make(map[string]bool),
+	}
+	im.report.DryRun = opts.DryRun
+	if opts.DryRun {
+		im.places = v1placeservice.NewReadOnlyPlaceResolver(s.placeService, "")
+	}
+	for _, header := range personSheet.unmapped {
+		im.report.Warnings = append(im.report.Warnings, fmt.Sprintf("persons: column %q is not mapped to a field and was left out", header))
+	}
+
+	for _, row := range personSheet.rows {
+		im.importPerson(ctx, row)
+	}
+	if relationshipSheet != nil {
+		for _, header := range relationshipSheet.unmapped {
+			im.report.Warnings = append(im.report.Warnings, fmt.Sprintf("relationships: column %q is not known and was left out", header))
+		}
+		for _, row := range relationshipSheet.rows {
+			im.importRelationship(ctx, row)
+		}
+		if opts.DryRun {
+			im.report.Warnings = append(im.report.Warnings, "relationships: a dry run does not check the relationships against each other, "+
+				"so rows that contradict each other, such as two persons who are each other's parent, are only found by the import")
+		}
+	}
+
+	im.report.Created.Places = im.places.Created()
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("import interrupted: %w", err)
+	}
+
+	return im.report, nil
+}
+
+// importPerson validates a row of the persons sheet and creates the person unless it is a dry run
+func (im *csvImporter) importPerson(ctx context.Context, row csvRow) {
+	localID := row.values["id"]
+	if localID != "" {
+		if _, found := im.persons[localID]; found || im.failed[localID] {
+			im.rowError(csvSheetPersons, row, fmt.Errorf("id %q is used by an earlier row", localID))
+			return
+		}
+	}
+	fail := func(err error) {
+		if localID != "" {
+			im.failed[localID] = true
+		}
+		im.rowError(csvSheetPersons, row, err)
+	}
+
+	req := interfaces.PersonCreateRequest{
+		FirstName: row.values["firstName"],
+		LastName:  row.values["lastName"],
+		Gender:    csvGender(row.values["gender"]),
+		Email:     row.values["email"],
+		Phone:     row.values["phone"],
+		Privacy:   row.values["privacy"],
+	}
+	var err error
+	if req.BirthDate, err = csvDate("birthDate", row.values["birthDate"]); err != nil {
+		fail(err)
+		return
+	}
+	if req.DeathDate, err = csvDate("deathDate", row.values["deathDate"]); err != nil {
+		fail(err)
+		return
+	}
+	if err := im.personService.ValidateCreateRequest(&req); err != nil {
+		fail(err)
+		return
+	}
+
+	// Places are only resolved for valid rows, so rows left out do not leave places behind.
+	// A dry run only looks them up.
+	if req.BirthPlaceID, err = im.place(ctx, "birthPlace", row.values["birthPlace"]); err != nil {
+		fail(err)
+		return
+	}
+	if req.DeathPlaceID, err = im.place(ctx, "deathPlace", row.values["deathPlace"]); err != nil {
+		fail(err)
+		return
+	}
+
+	notes := row.values["notes"]
+	if im.opts.DryRun {
+		// Relationships are validated against placeholders for the persons that would be created
+		if localID != "" {
+			im.persons[localID] = "persons/row-" + strconv.Itoa(row.number)
+		}
+		im.report.Created.Persons++
+		if notes != "" {
+			im.report.Created.Notes++
+		}
+		return
+	}
+
+	person, err := im.personService.CreatePerson(ctx, &req)
+	if err != nil {
+		fail(err)
+		return
+	}
+	if localID != "" {
+		im.persons[localID] = person.ID
+	}
+	im.report.Created.Persons++
+
+	if notes != "" {
+		if _, err := im.noteService.CreateNote(ctx, &interfaces.NoteCreateRequest{SubjectID: person.ID, Body: notes}, im.author); err != nil {
+			im.report.Warnings = append(im.report.Warnings, fmt.Sprintf("persons row %d: note not imported: %v", row.number, err))
+			return
+		}
+		im.report.Created.Notes++
+	}
+}
+
+// importRelationship validates a row of the relationships sheet and creates the relationship unless it is
+// a dry run
+func (im *csvImporter) importRelationship(ctx context.Context, row csvRow) {
+	from, err := im.person("from", row.values["from"])
+	if err != nil {
+		im.rowError(csvSheetRelationships, row, err)
+		return
+	}
+	to, err := im.person("to", row.values["to"])
+	if err != nil {
+		im.rowError(csvSheetRelationships, row, err)
+		return
+	}
+
+	req := interfaces.RelationshipCreateRequest{
+		From:         from,
+		To:           to,
+		RelationType: strings.ToLower(row.values["relationType"]),
+		Qualifier:    strings.ToLower(row.values["qualifier"]),
+		Notes:        row.values["notes"],
+	}
+	if req.StartDate, err = csvDate("startDate", row.values["startDate"]); err != nil {
+		im.rowError(csvSheetRelationships, row, err)
+		return
+	}
+	if req.EndDate, err = csvDate("endDate", row.values["endDate"]); err != nil {
+		im.rowError(csvSheetRelationships, row, err)
+		return
+	}
+	if err := im.relationshipService.ValidateCreateRequest(&req); err != nil {
+		im.rowError(csvSheetRelationships, row, err)
+		return
+	}
+
+	if !im.opts.DryRun {
+		if _, err := im.relationshipService.CreateRelationship(ctx, &req); err != nil {
+			im.rowError(csvSheetRelationships, row, err)
+			return
+		}
+	}
+	im.report.Created.Relationships++
+}
+
+// person returns the ID of the person of the row with a local identifier, for the from or to column
+func (im *csvImporter) person(field, localID string) (string, error) {
+	if localID == "" {
+		return "", nil
+	}
+	if id, found := im.persons[localID]; found {
+		return id, nil
+	}
+	if im.failed[localID] {
+		return "", fmt.Errorf("%s: the person row with id %q was left out", field, localID)
+	}
+	return "", fmt.Errorf("%s: no person row has id %q", field, localID)
+}
+
+// place returns the ID of the place with a full name, such as "Haugen, Aker, Akershus, Norway", creating
+// it when needed unless it is a dry run
+func (im *csvImporter) place(ctx context.Context, field, name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+	id, err := im.places.Resolve(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", field, err)
+	}
+	return id, nil
+}
+
+// rowError reports a row that was left out
+func (im *csvImporter) rowError(sheet string, row csvRow, err error) {
+	im.report.RowErrors = append(im.report.RowErrors, interfaces.ImportRowError{
+		Sheet: sheet,
+		Row:   row.number,
+		Error: err.Error(),
+	})
+}
+
+// readCSVSheet reads the sheet called name, saved as CSV, separated by commas, semicolons or tabs, with a header row.
+// The columns are mapped to fields by mapping, or by their header when it names a field.
+func readCSVSheet(r io.Reader, name string, mapping map[string]string, fields []string) (*csvSheet, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = csvDelimiter(data)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: %s: the sheet is empty", ErrInvalidImport, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidImport, name, err)
+	}
+
+	columns, sheet, err := mapColumns(header, mapping, fields)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidImport, name, err)
+	}
+
+	for number := 2; ; number++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidImport, name, err)
+		}
+
+		row := csvRow{number: number, values: make(map[string]string)}
+		for i, value := range record {
+			if value = strings.TrimSpace(value); i < len(columns) && columns[i] != "" && value != "" {
+				row.values[columns[i]] = value
+			}
+		}
+		// Blank rows between the data are common in spreadsheets
+		if len(row.values) > 0 {
+			sheet.rows = append(sheet.rows, row)
+		}
+	}
+
+	return sheet, nil
+}
+
+// mapColumns returns the field of every column of a header, or "" for columns that are not mapped, with the
+// sheet the rows are read into
+func mapColumns(header []string, mapping map[string]string, fields []string) ([]string, *csvSheet, error) {
+	sheet := &csvSheet{fields: make(map[string]bool)}
+	headers := make(map[string]bool, len(header))
+	for _, column := range header {
+		headers[strings.TrimSpace(column)] = true
+	}
+	for column, field := range mapping {
+		if field != "" && !slices.Contains(fields, field) {
+			return nil, nil, fmt.Errorf("column %q is mapped to unknown field %q. Fields are: %s", column, field, strings.Join(fields, ", "))
+		}
+		if !headers[column] {
+			return nil, nil, fmt.Errorf("mapped column %q is not in the header", column)
+		}
+	}
+
+	columns := make([]string, len(header))
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		field, found := mapping[column]
+		if found && field == "" {
+			// Columns mapped to no field are left out on purpose
+			continue
+		}
+		if !found {
+			index := slices.IndexFunc(fields, func(field string) bool { return strings.EqualFold(field, column) })
+			if index == -1 {
+				if column != "" {
+					sheet.unmapped = append(sheet.unmapped, column)
+				}
+				continue
+			}
+			field = fields[index]
+		}
+		if sheet.fields[field] {
+			return nil, nil, fmt.Errorf("more than one column is mapped to %s", field)
+		}
+		sheet.fields[field] = true
+		columns[i] = field
+	}
+
+	return columns, sheet, nil
+}
+
+// csvDelimiter returns the delimiter the header row of a sheet is separated by: semicolons, as spreadsheets
+// save CSV in locales with decimal commas, tabs, or commas
+func csvDelimiter(data []byte) rune {
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	delimiter, most := ',', bytes.Count(header, []byte(","))
+	for _, candidate := range []rune{';', '\t'} {
+		if count := bytes.Count(header, []byte(string(candidate))); count > most {
+			delimiter, most = candidate, count
+		}
+	}
+	return delimiter
+}
+
+// csvDate reads a date as genealogical dates are written, as in GEDCOM or day first as in 12.05.1843
+func csvDate(field, value string) (interfaces.GenealogicalDate, error) {
+	if value == "" {
+		return interfaces.GenealogicalDate{}, nil
+	}
+	if match := csvDayMonthYear.FindStringSubmatch(value); match != nil {
+		day, _ := strconv.Atoi(match[1])
+		month, _ := strconv.Atoi(match[2])
+		value = fmt.Sprintf("%s-%02d-%02d", match[3], month, day)
+	}
+
+	if date, err := interfaces.ParseGenealogicalDate(value); err == nil {
+		return date, nil
+	}
+	date, err := gedcom.ParseDate(value)
+	if err != nil {
+		return interfaces.GenealogicalDate{}, fmt.Errorf("%s: %v", field, err)
+	}
+	if date.Phrase != "" {
+		return interfaces.GenealogicalDate{}, fmt.Errorf("%s: %q is not a date", field, value)
+	}
+	return date.Value, nil
+}
+
+// csvGender returns the gender written in a cell, as male or female when written as one
+func csvGender(value string) string {
+	value = strings.ToLower(value)
+	if gender, found := csvGenders[value]; found {
+		return gender
+	}
+	return value
+}
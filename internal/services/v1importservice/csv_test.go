@@ -0,0 +1,338 @@
+package v1importservice
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rogerwesterbo/familytree/internal/services/v1personservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1placeservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1relationshipservice"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+func TestMapColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   []string
+		mapping  map[string]string
+		want     []string
+		unmapped []string
+		wantErr  string
+	}{
+		{
+			name:   "headers naming fields",
+			header: []string{"FirstName", " lastname ", "BIRTHDATE"},
+			want:   []string{"firstName", "lastName", "birthDate"},
+		},
+		{
+			name:     "mapped and unmapped columns",
+			header:   []string{"Nr", "Fornavn", "Etternavn", "Yrke", ""},
+			mapping:  map[string]string{"Nr": "id", "Fornavn": "firstName", "Etternavn": "lastName"},
+			want:     []string{"id", "firstName", "lastName", "", ""},
+			unmapped: []string{"Yrke"},
+		},
+		{
+			name:    "column left out on purpose",
+			header:  []string{"firstName", "lastName", "email"},
+			mapping: map[string]string{"email": ""},
+			want:    []string{"firstName", "lastName", ""},
+		},
+		{
+			name:    "unknown field",
+			header:  []string{"Fornavn"},
+			mapping: map[string]string{"Fornavn": "givenName"},
+			wantErr: "unknown field",
+		},
+		{
+			name:    "mapped column not in the header",
+			header:  []string{"firstName"},
+			mapping: map[string]string{"Etternavn": "lastName"},
+			wantErr: "not in the header",
+		},
+		{
+			name:    "two columns mapped to a field",
+			header:  []string{"Fornavn", "firstName"},
+			mapping: map[string]string{"Fornavn": "firstName"},
+			wantErr: "more than one column",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, sheet, err := mapColumns(tt.header, tt.mapping, csvPersonFields)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("mapColumns returned %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("mapColumns returned error: %v", err)
+			}
+			if !slices.Equal(columns, tt.want) {
+				t.Errorf("columns = %q, want %q", columns, tt.want)
+			}
+			if !slices.Equal(sheet.unmapped, tt.unmapped) {
+				t.Errorf("unmapped = %q, want %q", sheet.unmapped, tt.unmapped)
+			}
+		})
+	}
+}
+
+func TestReadCSVSheet(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		rows  []map[string]string
+		// numbers holds the row numbers of the rows
+		numbers []int
+	}{
+		{
+			name:    "commas",
+			input:   "firstName,lastName\nOle,Hansen\n",
+			rows:    []map[string]string{{"firstName": "Ole", "lastName": "Hansen"}},
+			numbers: []int{2},
+		},
+		{
+			name:    "semicolons with a byte order mark and CR LF line endings",
+			input:   "\ufefffirstName;lastName;notes\r\nOle;Hansen;\"1,5 acres\"\r\n",
+			rows:    []map[string]string{{"firstName": "Ole", "lastName": "Hansen", "notes": "1,5 acres"}},
+			numbers: []int{2},
+		},
+		{
+			name:    "tabs",
+			input:   "firstName\tlastName\nOle\tHansen\n",
+			rows:    []map[string]string{{"firstName": "Ole", "lastName": "Hansen"}},
+			numbers: []int{2},
+		},
+		{
+			name:    "blank rows and short rows",
+			input:   "firstName,lastName,email\nOle,Hansen\n,,\nKari, Olsdatter ,\n",
+			rows:    []map[string]string{{"firstName": "Ole", "lastName": "Hansen"}, {"firstName": "Kari", "lastName": "Olsdatter"}},
+			numbers: []int{2, 4},
+		},
+		{
+			name:    "quoted line breaks",
+			input:   "firstName,notes\nOle,\"Two\nlines\"\nKari,\n",
+			rows:    []map[string]string{{"firstName": "Ole", "notes": "Two\nlines"}, {"firstName": "Kari"}},
+			numbers: []int{2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sheet, err := readCSVSheet(strings.NewReader(tt.input), csvSheetPersons, nil, csvPersonFields)
+			if err != nil {
+				t.Fatalf("readCSVSheet returned error: %v", err)
+			}
+			if len(sheet.rows) != len(tt.rows) {
+				t.Fatalf("got %d rows, want %d", len(sheet.rows), len(tt.rows))
+			}
+			for i, row := range sheet.rows {
+				if len(row.values) != len(tt.rows[i]) {
+					t.Errorf("row %d = %q, want %q", i, row.values, tt.rows[i])
+				}
+				for field, want := range tt.rows[i] {
+					if row.values[field] != want {
+						t.Errorf("row %d %s = %q, want %q", i, field, row.values[field], want)
+					}
+				}
+				if row.number != tt.numbers[i] {
+					t.Errorf("row %d is numbered %d, want %d", i, row.number, tt.numbers[i])
+				}
+			}
+		})
+	}
+}
+
+func TestReadCSVSheetInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: ""},
+		{name: "unterminated quote", input: "firstName,lastName\n\"Ole,Hansen\n"},
+		{name: "two columns for a field", input: "firstName,FIRSTNAME\nOle,Ole\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readCSVSheet(strings.NewReader(tt.input), csvSheetPersons, nil, csvPersonFields)
+			if !errors.Is(err, ErrInvalidImport) {
+				t.Errorf("readCSVSheet returned %v, want ErrInvalidImport", err)
+			}
+		})
+	}
+}
+
+func TestCSVDate(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "", want: ""},
+		{input: "1843", want: "1843"},
+		{input: "1843-05-12", want: "1843-05-12"},
+		{input: "12.05.1843", want: "1843-05-12"},
+		{input: "1.5.1843", want: "1843-05-01"},
+		{input: "12 MAY 1843", want: "1843-05-12"},
+		{input: "ABT 1843", want: "ABT 1843"},
+		{input: "abt 1843", want: "ABT 1843"},
+		{input: "(in the spring)", wantErr: true},
+		{input: "not a date", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := csvDate("birthDate", tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("csvDate(%q) = %q, want an error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("csvDate(%q) returned error: %v", tt.input, err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("csvDate(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSVGender(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "M", want: "male"},
+		{input: "Mann", want: "male"},
+		{input: "k", want: "female"},
+		{input: "Female", want: "female"},
+		{input: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := csvGender(tt.input); got != tt.want {
+				t.Errorf("csvGender(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// dryRunPlaceRepository holds the places a dry run looks up and fails the test when a place is created
+type dryRunPlaceRepository struct {
+	interfaces.PlaceRepository
+	t      *testing.T
+	places []interfaces.Place
+}
+
+func (r *dryRunPlaceRepository) FindByName(_ context.Context, name string) ([]interfaces.Place, error) {
+	var places []interfaces.Place
+	for _, place := range r.places {
+		if strings.EqualFold(place.Name, name) {
+			places = append(places, place)
+		}
+	}
+	return places, nil
+}
+
+func (r *dryRunPlaceRepository) GetByID(_ context.Context, id string) (*interfaces.Place, error) {
+	for _, place := range r.places {
+		if place.Key == id {
+			return &place, nil
+		}
+	}
+	return nil, errors.New("place not found")
+}
+
+func (r *dryRunPlaceRepository) FindChildren(_ context.Context, parentID string) ([]interfaces.Place, error) {
+	var places []interfaces.Place
+	for _, place := range r.places {
+		if place.ParentID == parentID {
+			places = append(places, place)
+		}
+	}
+	return places, nil
+}
+
+func (r *dryRunPlaceRepository) Create(context.Context, *interfaces.Place) error {
+	r.t.Fatal("a dry run created a place")
+	return nil
+}
+
+func TestImportCSVDryRun(t *testing.T) {
+	places := &dryRunPlaceRepository{t: t, places: []interfaces.Place{
+		{Key: "1", ID: "places/1", Name: "Norway"},
+		{Key: "2", ID: "places/2", Name: "Aker", ParentID: "places/1"},
+	}}
+	placeService := v1placeservice.NewPlaceService(places)
+	// Nothing is written in a dry run, so the services validating the rows have no repositories to write to
+	service := NewImportService(
+		v1personservice.NewPersonService(nil, nil, placeService, nil, nil, 100),
+		v1relationshipservice.NewRelationshipService(nil, nil),
+		nil, placeService, nil, nil, nil, nil, 0, 0,
+	)
+
+	persons := "Nr;Fornavn;Etternavn;Kjønn;Født;Fødested;Merknad;Yrke\n" +
+		"1;Ole;Hansen;M;12.05.1820;Haugen, Aker, Norway;Farmer;Bonde\n" +
+		"2;Kari;Olsdatter;k;ABT 1822;Aker, Norway;;\n" +
+		"3;Per;;m;;;;\n" +
+		"4;Liv;Olsen;f;1848;Bergen, Norway;;\n" +
+		"1;Anne;Olsen;f;;;;\n"
+	relationships := "from,to,relationType,qualifier,startDate\n" +
+		"1,2,spouse,married,1843\n" +
+		"1,4,parent,,\n" +
+		"1,3,parent,,\n" +
+		"4,4,sibling,,\n"
+	opts := interfaces.CSVImportOptions{
+		Mapping: map[string]string{
+			"Nr": "id", "Fornavn": "firstName", "Etternavn": "lastName", "Kjønn": "gender", "Født": "birthDate",
+			"Fødested": "birthPlace", "Merknad": "notes",
+		},
+		DryRun: true,
+	}
+
+	report, err := service.ImportCSV(context.Background(), strings.NewReader(persons), strings.NewReader(relationships), opts, interfaces.NoteAuthor{})
+	if err != nil {
+		t.Fatalf("ImportCSV returned error: %v", err)
+	}
+
+	if !report.DryRun {
+		t.Error("report is not marked as a dry run")
+	}
+	want := interfaces.ImportCounts{Persons: 3, Relationships: 2, Notes: 1, Places: 2}
+	if report.Created != want {
+		t.Errorf("Created = %+v, want %+v", report.Created, want)
+	}
+
+	wantErrors := []struct {
+		sheet string
+		row   int
+	}{
+		{sheet: csvSheetPersons, row: 4},
+		{sheet: csvSheetPersons, row: 6},
+		{sheet: csvSheetRelationships, row: 4},
+		{sheet: csvSheetRelationships, row: 5},
+	}
+	if len(report.RowErrors) != len(wantErrors) {
+		t.Fatalf("got row errors %+v, want %d", report.RowErrors, len(wantErrors))
+	}
+	for i, want := range wantErrors {
+		if got := report.RowErrors[i]; got.Sheet != want.sheet || got.Row != want.row {
+			t.Errorf("row error %d = %+v, want %s row %d", i, got, want.sheet, want.row)
+		}
+	}
+
+	for _, warning := range []string{`column "Yrke" is not mapped`, "does not check the relationships against each other"} {
+		if !slices.ContainsFunc(report.Warnings, func(w string) bool { return strings.Contains(w, warning) }) {
+			t.Errorf("warnings %q do not contain %q", report.Warnings, warning)
+		}
+	}
+}
@@ -0,0 +1,818 @@
+package v1importservice
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/internal/services/v1personservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1placeservice"
+	"github.com/rogerwesterbo/familytree/pkg/gedcom"
+	"github.com/rogerwesterbo/familytree/pkg/gedcom7"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// gedcomEventTypes maps the individual and family event tags to event types. Births and deaths are
+// kept on the person and marriages and divorces also on the spouse relationship.
+var gedcomEventTypes = map[string]string{
+	"BAPM": interfaces.EventTypeBaptism,
+	"CHR":  interfaces.EventTypeBaptism,
+	"CONF": interfaces.EventTypeConfirmation,
+	"EMIG": interfaces.EventTypeEmigration,
+	"CENS": interfaces.EventTypeCensus,
+	"RESI": interfaces.EventTypeResidence,
+	"OCCU": interfaces.EventTypeOccupation,
+	"BURI": interfaces.EventTypeBurial,
+	"MARR": interfaces.EventTypeMarriage,
+	"DIV":  interfaces.EventTypeDivorce,
+}
+
+// gedcomNameTypes maps the values of NAME.TYPE to name types
+var gedcomNameTypes = map[string]string{
+	"birth":      interfaces.NameTypeBirth,
+	"maiden":     interfaces.NameTypeBirth,
+	"married":    interfaces.NameTypeMarried,
+	"aka":        interfaces.NameTypeAlias,
+	"immigrant":  interfaces.NameTypeAlias,
+	"nickname":   interfaces.NameTypeAlias,
+	"religious":  interfaces.NameTypeReligious,
+	"farm":       interfaces.NameTypeFarm,
+	"patronymic": interfaces.NameTypePatronymic,
+}
+
+// gedcomPedigrees maps the pedigree values of FAMC.PEDI, and the _FREL and _MREL extensions, to parent qualifiers
+var gedcomPedigrees = map[string]string{
+	"birth":          "",
+	"natural":        "",
+	"biological":     "",
+	"adopted":        interfaces.RelationQualifierAdoptive,
+	"foster":         interfaces.RelationQualifierFoster,
+	"step":           interfaces.RelationQualifierStep,
+	"guardian":       interfaces.RelationQualifierGuardian,
+	"legal guardian": interfaces.RelationQualifierGuardian,
+}
+
+// gedcomFacts maps the tags a citation can be given under to the fact it supports
+var gedcomFacts = map[string]string{
+	"NAME": "name",
+	"SEX":  "gender",
+	"BIRT": "birth",
+	"DEAT": "death",
+}
+
+// gedcomSourceTypes maps words in source titles to source types, as GEDCOM sources have no type
+var gedcomSourceTypes = []struct {
+	words      []string
+	sourceType string
+}{
+	{[]string{"census", "folketelling", "folketeljing", "manntall"}, interfaces.SourceTypeCensus},
+	{[]string{"church", "parish", "kirkebok", "ministerialbok", "klokkerbok"}, interfaces.SourceTypeChurchBook},
+	{[]string{"grave", "cemetery", "gravstein", "gravminne", "kirkegård"}, interfaces.SourceTypeGravestone},
+	{[]string{"interview", "intervju"}, interfaces.SourceTypeInterview},
+}
+
+// parentLinks holds the qualifiers of the parent edges from the husband and the wife of a family to a child
+type parentLinks struct {
+	husband string
+	wife    string
+}
+
+// gedcomImporter maps the records of a GEDCOM file onto persons, relationships, events, places, sources,
+// citations and notes
+type gedcomImporter struct {
+	*ImportService
+	doc    *gedcom.Document
+	author interfaces.NoteAuthor
+	report *interfaces.ImportReport
+	places *v1placeservice.PlaceResolver
+	// persons and sources hold the IDs of the created records by their cross-reference
+	persons map[string]string
+	sources map[string]string
+	// pedigrees holds the parent edge qualifiers by child and family cross-reference
+	pedigrees map[[2]string]parentLinks
+	// notesUsed holds the cross-references of the note records something refers to
+	notesUsed map[string]bool
+	// doc7 is the document of a GEDCOM 7.0 file, nil for GEDCOM 5.5.1 files
+	doc7 *gedcom7.Document
+	// archive holds the media files of a GEDZIP archive, nil for plain files
+	archive *gedcom7.Archive
+	// mediaSubjects holds the IDs of the persons referring to every media record by its cross-reference
+	mediaSubjects map[string][]string
+}
+
+// importGedcom imports a GEDCOM 5.5.1 file
+func (s *ImportService) importGedcom(ctx context.Context, r io.Reader, author interfaces.NoteAuthor) (*interfaces.ImportReport, error) {
+	doc, err := gedcom.Parse(r)
+	if err != nil {
+		return nil, invalidGedcom(err)
+	}
+
+	report := newReport(interfaces.ImportFormatGedcom)
+	report.Encoding = doc.Encoding
+	report.Warnings = append(report.Warnings, doc.Warnings...)
+	if doc.Version != "" && !strings.HasPrefix(doc.Version, "5.5") {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("the file is GEDCOM %s and was read as GEDCOM 5.5.1", doc.Version))
+	}
+
+	return s.newGedcomImporter(doc, author, report).run(ctx)
+}
+
+// newGedcomImporter creates an importer for a parsed GEDCOM file reporting to report
+func (s *ImportService) newGedcomImporter(doc *gedcom.Document, author interfaces.NoteAuthor, report *interfaces.ImportReport) *gedcomImporter {
+	placeForm := ""
+	if plac := doc.Header.First("PLAC"); plac != nil {
+		placeForm = plac.Text("FORM")
+	}
+
+	return &gedcomImporter{
+		ImportService: s,
+		doc:           doc,
+		author:        author,
+		report:        report,
+		places:        v1placeservice.NewPlaceResolver(s.placeService, placeForm),
+		persons:       make(map[string]string),
+		sources:       make(map[string]string),
+		pedigrees:     make(map[[2]string]parentLinks),
+		notesUsed:     make(map[string]bool),
+		mediaSubjects: make(map[string][]string),
+	}
+}
+
+// run imports the records of the file
+func (im *gedcomImporter) run(ctx context.Context) (*interfaces.ImportReport, error) {
+	// Sources come first so citations can refer to them, and persons before the families joining them
+	for _, record := range im.doc.RecordsByTag("SOUR") {
+		im.importSource(ctx, record)
+	}
+	var individuals []*gedcom.Record
+	for _, record := range im.doc.RecordsByTag("INDI") {
+		if im.importIndividual(ctx, record) {
+			individuals = append(individuals, record)
+		}
+	}
+	for _, record := range individuals {
+		im.importIndividualDetails(ctx, record)
+	}
+	for _, record := range im.doc.RecordsByTag("FAM") {
+		im.importFamily(ctx, record)
+	}
+
+	for _, record := range im.doc.Records {
+		switch record.Tag {
+		case "INDI", "FAM", "SOUR", "REPO":
+			// Repositories are imported as part of the sources referring to them
+		case "NOTE", "SNOTE":
+			if !im.notesUsed[record.XRef] {
+				im.skip(record.Tag)
+			}
+		case "OBJE":
+			if im.archive == nil {
+				im.skip(record.Tag)
+				continue
+			}
+			im.importMedia(ctx, record)
+		default:
+			im.skip(record.Tag)
+		}
+	}
+
+	im.report.Created.Places = im.places.Created()
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("import interrupted: %w", err)
+	}
+
+	return im.report, nil
+}
+
+// importSource creates a source from a SOUR record
+func (im *gedcomImporter) importSource(ctx context.Context, record *gedcom.Record) {
+	req := interfaces.SourceCreateRequest{
+		Title:  record.Text("TITL"),
+		Author: record.Text("AUTH"),
+	}
+	if req.Title == "" {
+		req.Title = record.Text("ABBR")
+	}
+	if req.Title == "" {
+		req.Title = "Untitled source " + record.XRef
+		im.warnf(record, "source %s has no title", record.XRef)
+	}
+	req.Type = sourceType(req.Title)
+
+	var notes []string
+	for _, sub := range record.Subrecords {
+		switch sub.Tag {
+		case "TITL", "ABBR", "AUTH":
+		case "PUBL":
+			notes = append(notes, "Published: "+strings.TrimSpace(sub.Value))
+		case "TEXT":
+			notes = append(notes, strings.TrimSpace(sub.Value))
+		case "NOTE", "SNOTE":
+			if text := im.noteText(sub); text != "" {
+				notes = append(notes, text)
+			}
+		case "REPO":
+			req.Repository = im.repositoryName(sub)
+		case "WWW", "_URL":
+			req.URL = strings.TrimSpace(sub.Value)
+		default:
+			im.skip("SOUR." + sub.Tag)
+		}
+	}
+	req.Notes = strings.Join(notes, "\n\n")
+
+	source, err := im.sourceService.CreateSource(ctx, &req)
+	if err != nil {
+		im.warnf(record, "source %s not imported: %v", record.XRef, err)
+		return
+	}
+	im.sources[record.XRef] = source.ID
+	im.report.Created.Sources++
+}
+
+// repositoryName returns the name of the repository a SOUR.REPO line refers to or names
+func (im *gedcomImporter) repositoryName(record *gedcom.Record) string {
+	if pointer := record.Pointer(); pointer != "" {
+		if repo := im.doc.Find(pointer); repo != nil {
+			return repo.Text("NAME")
+		}
+		im.warnf(record, "repository %s does not exist", pointer)
+		return ""
+	}
+	return strings.TrimSpace(record.Value)
+}
+
+// importIndividual creates a person from the names, sex, birth, death and contact details of an INDI record
+// and notes how the person is linked to their parents. It reports whether the person was created.
+func (im *gedcomImporter) importIndividual(ctx context.Context, record *gedcom.Record) bool {
+	var req interfaces.PersonCreateRequest
+	for _, sub := range record.Subrecords {
+		switch sub.Tag {
+		case "NAME":
+			names := im.names(sub, len(req.Names) == 0)
+			req.Names = append(req.Names, names...)
+		case "SEX":
+			switch strings.ToUpper(strings.TrimSpace(sub.Value)) {
+			case "M":
+				req.Gender = "male"
+			case "F":
+				req.Gender = "female"
+			}
+		case "BIRT":
+			req.BirthDate = im.date(sub, "birth")
+			req.BirthPlaceID = im.place(ctx, sub)
+		case "DEAT":
+			req.DeathDate = im.date(sub, "death")
+			req.DeathPlaceID = im.place(ctx, sub)
+		case "EMAIL":
+			email := strings.TrimSpace(sub.Value)
+			if v1personservice.IsValidEmail(email) {
+				req.Email = email
+			} else {
+				im.warnf(sub, "%s: invalid email address %q left out", record.XRef, email)
+			}
+		case "PHON":
+			req.Phone = strings.TrimSpace(sub.Value)
+		case "FAMC":
+			im.pedigree(record.XRef, sub)
+		case "ADOP":
+			im.adoption(record.XRef, sub)
+		}
+	}
+
+	if len(req.Names) == 0 {
+		req.Names = []interfaces.PersonName{{GivenName: "Unknown", Type: interfaces.NameTypeBirth}}
+		im.warnf(record, "%s has no name and was imported as Unknown", record.XRef)
+	}
+	if req.DeathDate.DefinitelyBefore(req.BirthDate) {
+		im.warnf(record, "%s dies (%s) before being born (%s), the death date was left out", record.XRef, req.DeathDate, req.BirthDate)
+		req.DeathDate = interfaces.GenealogicalDate{}
+	}
+
+	person, err := im.personService.CreatePerson(ctx, &req)
+	if err != nil {
+		im.warnf(record, "%s not imported: %v", record.XRef, err)
+		return false
+	}
+	im.persons[record.XRef] = person.ID
+	im.report.Created.Persons++
+
+	return true
+}
+
+// importIndividualDetails creates the events, citations and notes of an imported INDI record
+func (im *gedcomImporter) importIndividualDetails(ctx context.Context, record *gedcom.Record) {
+	personID := im.persons[record.XRef]
+
+	for _, sub := range record.Subrecords {
+		path := "INDI." + sub.Tag
+		switch sub.Tag {
+		case "NAME", "SEX", "BIRT", "DEAT":
+			// The citations and notes of the facts kept on the person
+			for _, detail := range sub.Subrecords {
+				switch {
+				case detail.Tag == "SOUR":
+					im.cite(ctx, detail, personID, gedcomFacts[sub.Tag])
+				case detail.Tag == "NOTE" || detail.Tag == "SNOTE":
+					im.note(ctx, detail, personID)
+				case sub.Tag == "NAME":
+					// The parts of a name were read with the name
+				case sub.Tag != "SEX" && (detail.Tag == "DATE" || detail.Tag == "PLAC"):
+				default:
+					im.skip(path + "." + detail.Tag)
+				}
+			}
+		case "EMAIL", "PHON", "ADOP", "FAMS":
+			// Families list their partners and children themselves
+		case "FAMC":
+			for _, detail := range sub.Subrecords {
+				if detail.Tag != "PEDI" {
+					im.skip(path + "." + detail.Tag)
+				}
+			}
+		case "SOUR":
+			im.cite(ctx, sub, personID, "")
+		case "NOTE", "SNOTE":
+			im.note(ctx, sub, personID)
+		case "OBJE":
+			if im.archive == nil || sub.Pointer() == "" {
+				im.skip(path)
+				continue
+			}
+			im.mediaSubjects[sub.Pointer()] = append(im.mediaSubjects[sub.Pointer()], personID)
+		default:
+			if _, found := gedcomEventTypes[sub.Tag]; found && sub.Tag != "MARR" && sub.Tag != "DIV" {
+				im.event(ctx, sub, path, []string{personID})
+				continue
+			}
+			im.skip(path)
+		}
+	}
+}
+
+// importFamily creates the spouse relationship between the partners of a FAM record, the parent relationships
+// to its children and its marriage and divorce events
+func (im *gedcomImporter) importFamily(ctx context.Context, record *gedcom.Record) {
+	husbandID := im.member(record, "HUSB")
+	wifeID := im.member(record, "WIFE")
+	var partnerIDs []string
+	for _, id := range []string{husbandID, wifeID} {
+		if id != "" {
+			partnerIDs = append(partnerIDs, id)
+		}
+	}
+
+	// The notes and citations of the family go on the spouse relationship, or on the only partner
+	subjectID := ""
+	if len(partnerIDs) == 1 {
+		subjectID = partnerIDs[0]
+	}
+	if len(partnerIDs) == 2 {
+		req := interfaces.RelationshipCreateRequest{
+			From:         husbandID,
+			To:           wifeID,
+			RelationType: interfaces.RelationTypeSpouse,
+		}
+		marriage, divorce, engagement := record.First("MARR"), record.First("DIV"), record.First("ENGA")
+		switch {
+		case divorce != nil:
+			req.Qualifier = interfaces.RelationQualifierDivorced
+		case marriage != nil:
+			req.Qualifier = interfaces.RelationQualifierMarried
+		case engagement != nil:
+			req.Qualifier = interfaces.RelationQualifierEngaged
+		}
+		if marriage != nil {
+			req.StartDate = im.date(marriage, "marriage")
+		}
+		if divorce != nil {
+			req.EndDate = im.date(divorce, "divorce")
+		}
+
+		relationship, err := im.relationshipService.CreateRelationship(ctx, &req)
+		if err != nil {
+			im.warnf(record, "%s: spouse relationship not imported: %v", record.XRef, err)
+		} else {
+			subjectID = relationship.ID
+			im.report.Created.Relationships++
+		}
+	}
+
+	for _, sub := range record.Subrecords {
+		path := "FAM." + sub.Tag
+		switch sub.Tag {
+		case "HUSB", "WIFE", "ENGA":
+		case "CHIL":
+			im.children(ctx, record, sub, husbandID, wifeID)
+		case "MARR", "DIV":
+			if len(partnerIDs) == 0 {
+				im.skip(path)
+				continue
+			}
+			im.event(ctx, sub, path, partnerIDs)
+		case "SOUR", "NOTE", "SNOTE":
+			if subjectID == "" {
+				im.warnf(sub, "%s: %s left out, the family has no imported partners", record.XRef, sub.Tag)
+				continue
+			}
+			if sub.Tag == "SOUR" {
+				im.cite(ctx, sub, subjectID, "")
+			} else {
+				im.note(ctx, sub, subjectID)
+			}
+		default:
+			im.skip(path)
+		}
+	}
+}
+
+// member returns the person ID of the partner a FAM.HUSB or FAM.WIFE line refers to, or "" when there is none
+func (im *gedcomImporter) member(family *gedcom.Record, tag string) string {
+	sub := family.First(tag)
+	if sub == nil {
+		return ""
+	}
+	id, found := im.persons[sub.Pointer()]
+	if !found {
+		im.warnf(sub, "%s: %s %s was not imported", family.XRef, strings.ToLower(tag), sub.Value)
+	}
+	return id
+}
+
+// children creates the parent relationships from the partners of a family to a child
+func (im *gedcomImporter) children(ctx context.Context, family, child *gedcom.Record, husbandID, wifeID string) {
+	childID, found := im.persons[child.Pointer()]
+	if !found {
+		im.warnf(child, "%s: child %s was not imported", family.XRef, child.Value)
+		return
+	}
+
+	links := im.pedigrees[[2]string{child.Pointer(), family.XRef}]
+	// Some programs write the pedigree on the child line as _FREL and _MREL instead
+	for _, sub := range child.Subrecords {
+		switch im.extensionTag(sub.Tag) {
+		case gedcom7.TagFatherRelationship:
+			links.husband = im.pedigreeQualifier(sub)
+		case gedcom7.TagMotherRelationship:
+			links.wife = im.pedigreeQualifier(sub)
+		default:
+			im.skip("FAM.CHIL." + sub.Tag)
+		}
+	}
+
+	for _, parent := range []struct{ id, qualifier string }{{husbandID, links.husband}, {wifeID, links.wife}} {
+		if parent.id == "" {
+			continue
+		}
+		req := interfaces.RelationshipCreateRequest{
+			From:         parent.id,
+			To:           childID,
+			RelationType: interfaces.RelationTypeParent,
+			Qualifier:    parent.qualifier,
+		}
+		if _, err := im.relationshipService.CreateRelationship(ctx, &req); err != nil {
+			im.warnf(child, "%s: parent relationship to %s not imported: %v", family.XRef, child.Value, err)
+			continue
+		}
+		im.report.Created.Relationships++
+	}
+}
+
+// pedigree notes how a child is linked to the parents of a family from an INDI.FAMC line
+func (im *gedcomImporter) pedigree(childXRef string, famc *gedcom.Record) {
+	pedi := famc.First("PEDI")
+	if pedi == nil || famc.Pointer() == "" {
+		return
+	}
+	qualifier := im.pedigreeQualifier(pedi)
+	im.pedigrees[[2]string{childXRef, famc.Pointer()}] = parentLinks{husband: qualifier, wife: qualifier}
+}
+
+// adoption notes the adoptive parents of a child from an INDI.ADOP event
+func (im *gedcomImporter) adoption(childXRef string, adop *gedcom.Record) {
+	famc := adop.First("FAMC")
+	if famc == nil || famc.Pointer() == "" {
+		return
+	}
+
+	key := [2]string{childXRef, famc.Pointer()}
+	links := im.pedigrees[key]
+	switch strings.ToUpper(famc.Text("ADOP")) {
+	case "HUSB":
+		links.husband = interfaces.RelationQualifierAdoptive
+	case "WIFE":
+		links.wife = interfaces.RelationQualifierAdoptive
+	default:
+		links.husband = interfaces.RelationQualifierAdoptive
+		links.wife = interfaces.RelationQualifierAdoptive
+	}
+	im.pedigrees[key] = links
+}
+
+// pedigreeQualifier returns the parent qualifier of a pedigree value, warning about values it does not know
+func (im *gedcomImporter) pedigreeQualifier(record *gedcom.Record) string {
+	value := strings.ToLower(strings.TrimSpace(otherPhrase(record)))
+	qualifier, found := gedcomPedigrees[value]
+	if !found {
+		im.warnf(record, "pedigree %q is not known and was imported as biological", record.Value)
+	}
+	return qualifier
+}
+
+// names reads the names of a NAME line: the name itself and its nickname. The first name of a person
+// defaults to the birth name and later ones to aliases.
+func (im *gedcomImporter) names(record *gedcom.Record, first bool) []interfaces.PersonName {
+	given, rest, _ := strings.Cut(record.Value, "/")
+	surname, suffix, _ := strings.Cut(rest, "/")
+	if suffix = strings.TrimSpace(suffix); suffix != "" {
+		im.warnf(record, "name suffix %q of %s left out", suffix, strings.TrimSpace(record.Value))
+	}
+
+	name := interfaces.PersonName{
+		GivenName: strings.TrimSpace(given),
+		Surname:   strings.TrimSpace(surname),
+		Type:      interfaces.NameTypeAlias,
+	}
+	if first {
+		name.Type = interfaces.NameTypeBirth
+	}
+
+	var names []interfaces.PersonName
+	prefix := ""
+	for _, sub := range record.Subrecords {
+		switch sub.Tag {
+		case "GIVN":
+			name.GivenName = strings.TrimSpace(sub.Value)
+		case "SURN":
+			name.Surname = strings.TrimSpace(sub.Value)
+		case "SPFX":
+			prefix = strings.TrimSpace(sub.Value)
+		case "TYPE":
+			if nameType, found := gedcomNameTypes[strings.ToLower(strings.TrimSpace(otherPhrase(sub)))]; found {
+				name.Type = nameType
+			} else {
+				im.warnf(sub, "name type %q is not known and was imported as %s", sub.Value, name.Type)
+			}
+		case "NICK":
+			names = append(names, interfaces.PersonName{GivenName: strings.TrimSpace(sub.Value), Type: interfaces.NameTypeAlias})
+		case "SOUR", "NOTE", "SNOTE":
+			// Imported with the details of the person
+		default:
+			im.skip("INDI.NAME." + sub.Tag)
+		}
+	}
+	if prefix != "" && !strings.HasPrefix(name.Surname, prefix) {
+		name.Surname = strings.TrimSpace(prefix + " " + name.Surname)
+	}
+
+	if name.GivenName == "" && name.Surname == "" {
+		return names
+	}
+	return append([]interfaces.PersonName{name}, names...)
+}
+
+// event creates the event of an individual or family event line with its participants as principals
+func (im *gedcomImporter) event(ctx context.Context, record *gedcom.Record, path string, personIDs []string) {
+	req := interfaces.EventCreateRequest{
+		Type:        gedcomEventTypes[record.Tag],
+		PlaceID:     im.place(ctx, record),
+		Description: strings.TrimSpace(record.Value),
+	}
+	if strings.EqualFold(req.Description, "Y") {
+		// Y only says that the event took place
+		req.Description = ""
+	}
+
+	if date := record.First("DATE"); date != nil {
+		parsed, err := im.parseDate(date)
+		if err != nil {
+			im.warnf(date, "%s: %v, date left out", path, err)
+		}
+		req.Date = parsed.Value
+		if parsed.Phrase != "" {
+			req.Description = joinNonEmpty(", ", req.Description, parsed.Phrase)
+		}
+	}
+	req.Description = joinNonEmpty(", ", req.Description, record.Text("TYPE"))
+
+	for _, personID := range personIDs {
+		req.Participants = append(req.Participants, interfaces.EventParticipant{
+			PersonID: personID,
+			Role:     interfaces.ParticipantRolePrincipal,
+		})
+	}
+
+	event, err := im.eventService.CreateEvent(ctx, &req)
+	if err != nil {
+		im.warnf(record, "%s not imported: %v", path, err)
+		return
+	}
+	im.report.Created.Events++
+
+	for _, sub := range record.Subrecords {
+		switch sub.Tag {
+		case "DATE", "PLAC", "TYPE":
+		case "SOUR":
+			im.cite(ctx, sub, event.ID, "")
+		case "NOTE", "SNOTE":
+			im.note(ctx, sub, event.ID)
+		default:
+			im.skip(path + "." + sub.Tag)
+		}
+	}
+}
+
+// cite creates a citation of a source from a SOUR line, supporting a fact of a person, relationship or event.
+// Sources written out in the line instead of referred to are created first.
+func (im *gedcomImporter) cite(ctx context.Context, record *gedcom.Record, subjectID, fact string) {
+	sourceID := ""
+	if pointer := record.Pointer(); pointer != "" {
+		id, found := im.sources[pointer]
+		if !found {
+			im.warnf(record, "source %s was not imported, citation left out", pointer)
+			return
+		}
+		sourceID = id
+	} else {
+		text := strings.TrimSpace(record.Value)
+		if text == "" {
+			return
+		}
+		title, _, _ := strings.Cut(text, "\n")
+		source, err := im.sourceService.CreateSource(ctx, &interfaces.SourceCreateRequest{
+			Title: title,
+			Type:  sourceType(title),
+			Notes: text,
+		})
+		if err != nil {
+			im.warnf(record, "source %q not imported: %v", title, err)
+			return
+		}
+		sourceID = source.ID
+		im.report.Created.Sources++
+	}
+
+	req := interfaces.CitationCreateRequest{
+		SourceID: sourceID,
+		Page:     record.Text("PAGE"),
+		// Citations the file does not rate are taken as secondary evidence
+		Quality:  interfaces.CitationQualitySecondary,
+		Subjects: []interfaces.CitationSubject{{ID: subjectID, Fact: fact}},
+	}
+	for _, sub := range record.Subrecords {
+		switch sub.Tag {
+		case "PAGE":
+		case "QUAY":
+			quality, err := strconv.Atoi(strings.TrimSpace(sub.Value))
+			if err != nil || quality < interfaces.CitationQualityUnreliable || quality > interfaces.CitationQualityPrimary {
+				im.warnf(sub, "invalid citation quality %q left out", sub.Value)
+				continue
+			}
+			req.Quality = quality
+		case "DATA":
+			req.Transcription = sub.Text("TEXT")
+		case "NOTE", "SNOTE":
+			im.note(ctx, sub, subjectID)
+		default:
+			im.skip("SOUR." + sub.Tag)
+		}
+	}
+
+	if _, err := im.citationService.CreateCitation(ctx, &req); err != nil {
+		im.warnf(record, "citation not imported: %v", err)
+		return
+	}
+	im.report.Created.Citations++
+}
+
+// note creates a note on a person, relationship or event from a NOTE line or the note record it refers to
+func (im *gedcomImporter) note(ctx context.Context, record *gedcom.Record, subjectID string) {
+	text := im.noteText(record)
+	if text == "" {
+		return
+	}
+
+	req := interfaces.NoteCreateRequest{
+		SubjectID: subjectID,
+		Body:      text,
+	}
+	if _, err := im.noteService.CreateNote(ctx, &req, im.author); err != nil {
+		im.warnf(record, "note not imported: %v", err)
+		return
+	}
+	im.report.Created.Notes++
+}
+
+// noteText returns the text of a NOTE line, looking up the note record it refers to
+func (im *gedcomImporter) noteText(record *gedcom.Record) string {
+	pointer := record.Pointer()
+	if pointer == "" {
+		return strings.TrimSpace(record.Value)
+	}
+
+	im.notesUsed[pointer] = true
+	note := im.doc.Find(pointer)
+	if note == nil {
+		im.warnf(record, "note %s does not exist", pointer)
+		return ""
+	}
+	return strings.TrimSpace(note.Value)
+}
+
+// date returns the date of an event line, warning about dates that cannot be read and phrases that are lost
+func (im *gedcomImporter) date(record *gedcom.Record, fact string) interfaces.GenealogicalDate {
+	sub := record.First("DATE")
+	if sub == nil {
+		return interfaces.GenealogicalDate{}
+	}
+
+	date, err := im.parseDate(sub)
+	if err != nil {
+		im.warnf(sub, "%s: %v, date left out", fact, err)
+		return interfaces.GenealogicalDate{}
+	}
+	if date.Phrase != "" {
+		im.warnf(sub, "%s: date phrase %q left out", fact, date.Phrase)
+	}
+	return date.Value
+}
+
+// parseDate reads the value of a DATE line in the date syntax of the GEDCOM version of the file
+func (im *gedcomImporter) parseDate(record *gedcom.Record) (gedcom.Date, error) {
+	if im.doc7 != nil {
+		return gedcom7.ParseDate(record.Value, record.Text("PHRASE"))
+	}
+	return gedcom.ParseDate(record.Value)
+}
+
+// extensionTag returns the tag package gedcom7 documents for an extension tag of a GEDCOM 7.0 file,
+// which may define its own tag for the same extension. Tags of GEDCOM 5.5.1 files are returned as they are.
+func (im *gedcomImporter) extensionTag(tag string) string {
+	if im.doc7 == nil {
+		return tag
+	}
+	return im.doc7.Tag(tag)
+}
+
+// place returns the ID of the place of an event line, creating the place when needed
+func (im *gedcomImporter) place(ctx context.Context, record *gedcom.Record) string {
+	sub := record.First("PLAC")
+	if sub == nil {
+		return ""
+	}
+
+	id, err := im.places.Resolve(ctx, sub.Value)
+	if err != nil {
+		im.warnf(sub, "place %q not imported: %v", sub.Value, err)
+		return ""
+	}
+	for _, detail := range sub.Subrecords {
+		im.skip(record.Tag + ".PLAC." + detail.Tag)
+	}
+	return id
+}
+
+// skip counts a tag that was not imported
+func (im *gedcomImporter) skip(path string) {
+	im.report.SkippedTags[path]++
+}
+
+// warnf adds a warning about a line of the file
+func (im *gedcomImporter) warnf(record *gedcom.Record, format string, args ...any) {
+	im.report.Warnings = append(im.report.Warnings, fmt.Sprintf("line %d: ", record.LineNumber)+fmt.Sprintf(format, args...))
+}
+
+// sourceType guesses the type of a source from its title
+func sourceType(title string) string {
+	title = strings.ToLower(title)
+	for _, candidate := range gedcomSourceTypes {
+		for _, word := range candidate.words {
+			if strings.Contains(title, word) {
+				return candidate.sourceType
+			}
+		}
+	}
+	return interfaces.SourceTypeOther
+}
+
+// otherPhrase returns the value of an enumeration line, or the phrase below it when the value is OTHER,
+// as GEDCOM 7.0 writes values outside its enumerations
+func otherPhrase(record *gedcom.Record) string {
+	if phrase := record.Text("PHRASE"); phrase != "" && strings.EqualFold(strings.TrimSpace(record.Value), "OTHER") {
+		return phrase
+	}
+	return record.Value
+}
+
+// joinNonEmpty joins the non-empty values with a separator
+func joinNonEmpty(separator string, values ...string) string {
+	var kept []string
+	for _, value := range values {
+		if value != "" {
+			kept = append(kept, value)
+		}
+	}
+	return strings.Join(kept, separator)
+}
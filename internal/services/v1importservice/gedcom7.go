@@ -0,0 +1,90 @@
+package v1importservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/rogerwesterbo/familytree/pkg/gedcom"
+	"github.com/rogerwesterbo/familytree/pkg/gedcom7"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// importGedcom7 imports a GEDCOM 7.0 file
+func (s *ImportService) importGedcom7(ctx context.Context, r io.Reader, author interfaces.NoteAuthor) (*interfaces.ImportReport, error) {
+	doc, err := gedcom7.Parse(r)
+	if err != nil {
+		return nil, invalidGedcom(err)
+	}
+
+	im := s.newGedcomImporter(doc.Document, author, newGedcom7Report(interfaces.ImportFormatGedcom7, doc))
+	im.doc7 = doc
+	return im.run(ctx)
+}
+
+// importGedzip imports a GEDZIP archive: a GEDCOM 7.0 file with the media files it refers to
+func (s *ImportService) importGedzip(ctx context.Context, r io.Reader, author interfaces.NoteAuthor) (*interfaces.ImportReport, error) {
+	archive, err := gedcom7.ReadArchive(r, s.maxFileSize)
+	if err != nil {
+		return nil, invalidGedcom(err)
+	}
+
+	im := s.newGedcomImporter(archive.Document.Document, author, newGedcom7Report(interfaces.ImportFormatGedzip, archive.Document))
+	im.doc7 = archive.Document
+	im.archive = archive
+	return im.run(ctx)
+}
+
+// newGedcom7Report creates the report for an import of a GEDCOM 7.0 file
+func newGedcom7Report(format string, doc *gedcom7.Document) *interfaces.ImportReport {
+	report := newReport(format)
+	report.Encoding = doc.Encoding
+	report.Warnings = append(report.Warnings, doc.Warnings...)
+	return report
+}
+
+// invalidGedcom wraps the errors of files that cannot be read as GEDCOM as invalid imports
+func invalidGedcom(err error) error {
+	if errors.Is(err, gedcom.ErrInvalidFile) {
+		return fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	return err
+}
+
+// importMedia creates a media file for every file of an OBJE record found in the archive, linked to the
+// persons referring to the record
+func (im *gedcomImporter) importMedia(ctx context.Context, record *gedcom.Record) {
+	for _, sub := range record.Subrecords {
+		if sub.Tag != "FILE" {
+			im.skip("OBJE." + sub.Tag)
+			continue
+		}
+
+		filePath, err := gedcom7.FilePath(sub.Value)
+		if err != nil {
+			im.warnf(sub, "%s: file %s not imported: %v", record.XRef, sub.Value, err)
+			continue
+		}
+		content, err := im.archive.Open(sub.Value, im.maxMediaSize)
+		if err != nil {
+			im.warnf(sub, "%s: file %s not imported: %v", record.XRef, sub.Value, err)
+			continue
+		}
+
+		req := interfaces.MediaUploadRequest{
+			FileName: path.Base(filePath),
+			MimeType: sub.Text("FORM"),
+			Caption:  sub.Text("TITL"),
+			Subjects: im.mediaSubjects[record.XRef],
+		}
+		_, err = im.mediaService.UploadMedia(ctx, &req, content)
+		_ = content.Close()
+		if err != nil {
+			im.warnf(sub, "%s: file %s not imported: %v", record.XRef, sub.Value, err)
+			continue
+		}
+		im.report.Created.Media++
+	}
+}
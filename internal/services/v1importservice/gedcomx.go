@@ -0,0 +1,605 @@
+package v1importservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/internal/services/v1placeservice"
+	"github.com/rogerwesterbo/familytree/pkg/gedcom"
+	"github.com/rogerwesterbo/familytree/pkg/gedcomx"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// gedcomxGenders maps GEDCOM X gender types to genders
+var gedcomxGenders = map[string]string{
+	gedcomx.GenderMale:   "male",
+	gedcomx.GenderFemale: "female",
+}
+
+// gedcomxNameTypes maps GEDCOM X name types to name types
+var gedcomxNameTypes = map[string]string{
+	gedcomx.NameTypeBirth:      interfaces.NameTypeBirth,
+	gedcomx.NameTypeMarried:    interfaces.NameTypeMarried,
+	gedcomx.NameTypeAlsoKnown:  interfaces.NameTypeAlias,
+	gedcomx.NameTypeNickname:   interfaces.NameTypeAlias,
+	gedcomx.NameTypeReligious:  interfaces.NameTypeReligious,
+	gedcomx.NameTypeFarm:       interfaces.NameTypeFarm,
+	gedcomx.NameTypePatronymic: interfaces.NameTypePatronymic,
+}
+
+// gedcomxEventTypes maps the person and couple fact types to event types. Births and deaths are kept on
+// the person and marriages and divorces also on the spouse relationship.
+var gedcomxEventTypes = map[string]string{
+	gedcomx.FactBaptism:      interfaces.EventTypeBaptism,
+	gedcomx.FactChristening:  interfaces.EventTypeBaptism,
+	gedcomx.FactConfirmation: interfaces.EventTypeConfirmation,
+	gedcomx.FactEmigration:   interfaces.EventTypeEmigration,
+	gedcomx.FactCensus:       interfaces.EventTypeCensus,
+	gedcomx.FactResidence:    interfaces.EventTypeResidence,
+	gedcomx.FactOccupation:   interfaces.EventTypeOccupation,
+	gedcomx.FactBurial:       interfaces.EventTypeBurial,
+	gedcomx.FactMarriage:     interfaces.EventTypeMarriage,
+	gedcomx.FactDivorce:      interfaces.EventTypeDivorce,
+}
+
+// gedcomxSpouseQualifiers maps couple fact types to the spouse qualifier they imply, in order of precedence
+var gedcomxSpouseQualifiers = []struct {
+	factType  string
+	qualifier string
+}{
+	{gedcomx.FactDivorce, interfaces.RelationQualifierDivorced},
+	{gedcomx.FactMarriage, interfaces.RelationQualifierMarried},
+	{gedcomx.FactCivilUnion, interfaces.RelationQualifierCivilUnion},
+	{gedcomx.FactDomesticPartnership, interfaces.RelationQualifierCohabitation},
+	{gedcomx.FactEngagement, interfaces.RelationQualifierEngaged},
+}
+
+// gedcomxParentQualifiers maps parent-child fact types to parent qualifiers. Biological parents are the default
+// and have none.
+var gedcomxParentQualifiers = map[string]string{
+	gedcomx.FactBiologicalParent: "",
+	gedcomx.FactAdoptiveParent:   interfaces.RelationQualifierAdoptive,
+	gedcomx.FactStepParent:       interfaces.RelationQualifierStep,
+	gedcomx.FactFosterParent:     interfaces.RelationQualifierFoster,
+	gedcomx.FactGuardianParent:   interfaces.RelationQualifierGuardian,
+}
+
+// gedcomxFacts maps the person fact types kept on the person to the fact citations of them support
+var gedcomxFacts = map[string]string{
+	gedcomx.FactBirth: "birth",
+	gedcomx.FactDeath: "death",
+}
+
+// gedcomxImporter maps the persons, relationships, source descriptions and places of a GEDCOM X document
+// onto persons, relationships, events, places, sources, citations and notes
+type gedcomxImporter struct {
+	*ImportService
+	doc    *gedcomx.Document
+	author interfaces.NoteAuthor
+	report *interfaces.ImportReport
+	places *v1placeservice.PlaceResolver
+	// persons and sources hold the IDs of the created records by their local ID in the document
+	persons map[string]string
+	sources map[string]string
+	// descriptions holds the source descriptions by their local ID
+	descriptions map[string]gedcomx.SourceDescription
+	// citations holds the subjects citing a source description by its local ID, and cited the IDs in the
+	// order they were first cited
+	citations map[string][]interfaces.CitationSubject
+	cited     []string
+	// placeNames holds the full names of the place descriptions by their local ID, as in "Aker, Akershus, Norway"
+	placeNames map[string]string
+}
+
+// importGedcomX imports a GEDCOM X JSON document
+func (s *ImportService) importGedcomX(ctx context.Context, r io.Reader, author interfaces.NoteAuthor) (*interfaces.ImportReport, error) {
+	doc, err := gedcomx.Read(r)
+	if err != nil {
+		if errors.Is(err, gedcomx.ErrInvalidDocument) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+		}
+		return nil, err
+	}
+
+	im := &gedcomxImporter{
+		ImportService: s,
+		doc:           doc,
+		author:        author,
+		report:        newReport(interfaces.ImportFormatGedcomX),
+		places:        v1placeservice.NewPlaceResolver(s.placeService, ""),
+		persons:       make(map[string]string),
+		sources:       make(map[string]string),
+		descriptions:  make(map[string]gedcomx.SourceDescription),
+		citations:     make(map[string][]interfaces.CitationSubject),
+		placeNames:    make(map[string]string),
+	}
+	im.readPlaces()
+
+	// Sources come first so citations can refer to them, and persons before the relationships joining them
+	for _, description := range doc.SourceDescriptions {
+		im.descriptions[description.ID] = description
+	}
+	for _, description := range doc.SourceDescriptions {
+		if description.ComponentOf == nil {
+			im.importSource(ctx, description)
+		}
+	}
+	var persons []gedcomx.Person
+	for _, person := range doc.Persons {
+		if im.importPerson(ctx, person) {
+			persons = append(persons, person)
+		}
+	}
+	for _, person := range persons {
+		im.importPersonDetails(ctx, person)
+	}
+	for _, relationship := range doc.Relationships {
+		im.importRelationship(ctx, relationship)
+	}
+	im.importCitations(ctx)
+
+	im.report.Created.Places = im.places.Created()
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("import interrupted: %w", err)
+	}
+
+	return im.report, nil
+}
+
+// readPlaces notes the full name of every place description, with the names of the places containing it
+func (im *gedcomxImporter) readPlaces() {
+	byID := make(map[string]gedcomx.PlaceDescription, len(im.doc.Places))
+	for _, place := range im.doc.Places {
+		byID[place.ID] = place
+	}
+
+	for _, place := range im.doc.Places {
+		var jurisdictions []string
+		current, found := place, true
+		for found && len(jurisdictions) < v1placeservice.MaxHierarchyDepth {
+			if name := gedcomx.Text(current.Names); name != "" {
+				jurisdictions = append(jurisdictions, name)
+			}
+			if current.Jurisdiction == nil {
+				break
+			}
+			current, found = byID[referenceID(*current.Jurisdiction)]
+		}
+		im.placeNames[place.ID] = strings.Join(jurisdictions, ", ")
+	}
+}
+
+// importSource creates a source from a source description that is not a part of another source
+func (im *gedcomxImporter) importSource(ctx context.Context, description gedcomx.SourceDescription) {
+	citation := gedcomx.Text(description.Citations)
+	req := interfaces.SourceCreateRequest{
+		Title: gedcomx.Text(description.Titles),
+		URL:   description.About,
+	}
+	if req.Title == "" {
+		req.Title = citation
+	}
+	if req.Title == "" {
+		req.Title = "Untitled source " + description.ID
+		im.warnf("source %s has no title", description.ID)
+	}
+	req.Type = sourceType(req.Title)
+	if description.Repository != nil {
+		req.Repository = im.agentName(*description.Repository)
+	}
+
+	notes := []string{gedcomx.Text(description.Descriptions)}
+	if citation != req.Title {
+		notes = append(notes, citation)
+	}
+	for _, note := range description.Notes {
+		notes = append(notes, strings.TrimSpace(note.Text))
+	}
+	req.Notes = joinNonEmpty("\n\n", notes...)
+
+	source, err := im.sourceService.CreateSource(ctx, &req)
+	if err != nil {
+		im.warnf("source %s not imported: %v", description.ID, err)
+		return
+	}
+	im.sources[description.ID] = source.ID
+	im.report.Created.Sources++
+}
+
+// agentName returns the name of the agent a reference refers to
+func (im *gedcomxImporter) agentName(reference gedcomx.ResourceReference) string {
+	id := referenceID(reference)
+	for _, agent := range im.doc.Agents {
+		if agent.ID == id {
+			return gedcomx.Text(agent.Names)
+		}
+	}
+	return ""
+}
+
+// importPerson creates a person from the names, gender, birth and death of a GEDCOM X person.
+// It reports whether the person was created.
+func (im *gedcomxImporter) importPerson(ctx context.Context, person gedcomx.Person) bool {
+	var req interfaces.PersonCreateRequest
+	for _, name := range person.Names {
+		if personName, ok := im.name(person.ID, name, len(req.Names) == 0); ok {
+			req.Names = append(req.Names, personName)
+		}
+	}
+	if person.Gender != nil {
+		req.Gender = gedcomxGenders[person.Gender.Type]
+	}
+	for _, fact := range person.Facts {
+		switch fact.Type {
+		case gedcomx.FactBirth:
+			req.BirthDate = im.date(person.ID, fact)
+			req.BirthPlaceID = im.place(ctx, person.ID, fact)
+		case gedcomx.FactDeath:
+			req.DeathDate = im.date(person.ID, fact)
+			req.DeathPlaceID = im.place(ctx, person.ID, fact)
+		}
+	}
+
+	if len(req.Names) == 0 {
+		req.Names = []interfaces.PersonName{{GivenName: "Unknown", Type: interfaces.NameTypeBirth}}
+		im.warnf("person %s has no name and was imported as Unknown", person.ID)
+	}
+	if req.DeathDate.DefinitelyBefore(req.BirthDate) {
+		im.warnf("person %s dies (%s) before being born (%s), the death date was left out", person.ID, req.DeathDate, req.BirthDate)
+		req.DeathDate = interfaces.GenealogicalDate{}
+	}
+
+	created, err := im.personService.CreatePerson(ctx, &req)
+	if err != nil {
+		im.warnf("person %s not imported: %v", person.ID, err)
+		return false
+	}
+	im.persons[person.ID] = created.ID
+	im.report.Created.Persons++
+
+	return true
+}
+
+// importPersonDetails creates the events, citations and notes of an imported person
+func (im *gedcomxImporter) importPersonDetails(ctx context.Context, person gedcomx.Person) {
+	personID := im.persons[person.ID]
+
+	for _, fact := range person.Facts {
+		if factName, found := gedcomxFacts[fact.Type]; found {
+			for _, source := range fact.Sources {
+				im.cite(source, personID, factName)
+			}
+			im.notes(ctx, fact.Notes, personID)
+			continue
+		}
+		im.event(ctx, person.ID, fact, []string{personID})
+	}
+
+	for _, source := range person.Sources {
+		im.cite(source, personID, "")
+	}
+	im.notes(ctx, person.Notes, personID)
+}
+
+// importRelationship creates a spouse relationship from a couple relationship or a parent relationship from
+// a parent-child relationship, with their events, citations and notes
+func (im *gedcomxImporter) importRelationship(ctx context.Context, relationship gedcomx.Relationship) {
+	person1, found1 := im.persons[referenceID(relationship.Person1)]
+	person2, found2 := im.persons[referenceID(relationship.Person2)]
+	if !found1 || !found2 {
+		im.warnf("relationship %s left out, it is not between two imported persons", relationship.ID)
+		return
+	}
+
+	// Relationships keep their notes themselves
+	var notes []string
+	for _, note := range relationship.Notes {
+		notes = append(notes, strings.TrimSpace(note.Text))
+	}
+	req := interfaces.RelationshipCreateRequest{From: person1, To: person2, Notes: joinNonEmpty("\n\n", notes...)}
+	switch relationship.Type {
+	case gedcomx.RelationshipCouple:
+		req.RelationType = interfaces.RelationTypeSpouse
+		for _, candidate := range gedcomxSpouseQualifiers {
+			if findFact(relationship.Facts, candidate.factType) != nil {
+				req.Qualifier = candidate.qualifier
+				break
+			}
+		}
+		if fact := findFact(relationship.Facts, gedcomx.FactMarriage); fact != nil {
+			req.StartDate = im.date(relationship.ID, *fact)
+		}
+		if fact := findFact(relationship.Facts, gedcomx.FactDivorce); fact != nil {
+			req.EndDate = im.date(relationship.ID, *fact)
+		}
+	case gedcomx.RelationshipParentChild:
+		req.RelationType = interfaces.RelationTypeParent
+		for _, fact := range relationship.Facts {
+			if qualifier, found := gedcomxParentQualifiers[fact.Type]; found {
+				req.Qualifier = qualifier
+			}
+		}
+	default:
+		im.skip("relationships." + typeName(relationship.Type))
+		return
+	}
+
+	created, err := im.relationshipService.CreateRelationship(ctx, &req)
+	if err != nil {
+		im.warnf("relationship %s not imported: %v", relationship.ID, err)
+		return
+	}
+	im.report.Created.Relationships++
+
+	for _, fact := range relationship.Facts {
+		switch {
+		case fact.Type == gedcomx.FactMarriage || fact.Type == gedcomx.FactDivorce:
+			im.event(ctx, relationship.ID, fact, []string{person1, person2})
+		case isSpouseFact(fact.Type) || isParentFact(fact.Type):
+			// The kind of relationship is kept as the qualifier
+		default:
+			im.skip("relationships.facts." + typeName(fact.Type))
+		}
+	}
+	for _, source := range relationship.Sources {
+		im.cite(source, created.ID, "")
+	}
+}
+
+// event creates the event of a person or couple fact with the persons as principals
+func (im *gedcomxImporter) event(ctx context.Context, ownerID string, fact gedcomx.Fact, personIDs []string) {
+	eventType, found := gedcomxEventTypes[fact.Type]
+	if !found {
+		im.skip("facts." + typeName(fact.Type))
+		return
+	}
+
+	req := interfaces.EventCreateRequest{
+		Type:        eventType,
+		Date:        im.date(ownerID, fact),
+		PlaceID:     im.place(ctx, ownerID, fact),
+		Description: strings.TrimSpace(fact.Value),
+	}
+	for _, personID := range personIDs {
+		req.Participants = append(req.Participants, interfaces.EventParticipant{
+			PersonID: personID,
+			Role:     interfaces.ParticipantRolePrincipal,
+		})
+	}
+
+	event, err := im.eventService.CreateEvent(ctx, &req)
+	if err != nil {
+		im.warnf("%s: %s fact not imported: %v", ownerID, typeName(fact.Type), err)
+		return
+	}
+	im.report.Created.Events++
+
+	for _, source := range fact.Sources {
+		im.cite(source, event.ID, "")
+	}
+	im.notes(ctx, fact.Notes, event.ID)
+}
+
+// cite notes that a source reference supports a fact of a person, relationship or event. The citations
+// are created when all subjects are known, so a description cited several times becomes one citation.
+func (im *gedcomxImporter) cite(reference gedcomx.SourceReference, subjectID, fact string) {
+	id := gedcomx.LocalID(reference.Description)
+	if _, found := im.descriptions[id]; !found {
+		im.warnf("source description %s does not exist, citation left out", reference.Description)
+		return
+	}
+
+	if _, found := im.citations[id]; !found {
+		im.cited = append(im.cited, id)
+	}
+	im.citations[id] = append(im.citations[id], interfaces.CitationSubject{ID: subjectID, Fact: fact})
+}
+
+// importCitations creates a citation for every source description cited. Descriptions of a part of a source,
+// such as a page, cite that part of the source.
+func (im *gedcomxImporter) importCitations(ctx context.Context) {
+	for _, id := range im.cited {
+		description := im.descriptions[id]
+		req := interfaces.CitationCreateRequest{
+			SourceID: im.sources[id],
+			// Citations are taken as secondary evidence, as GEDCOM X does not rate them
+			Quality:  interfaces.CitationQualitySecondary,
+			Subjects: im.citations[id],
+		}
+		if description.ComponentOf != nil {
+			sourceID := gedcomx.LocalID(description.ComponentOf.Description)
+			req.SourceID = im.sources[sourceID]
+			// The citation of a part names the source first, as in "Ministerialbok for Aker, page 42"
+			title := gedcomx.Text(im.descriptions[sourceID].Titles)
+			if citation := gedcomx.Text(description.Citations); citation != title {
+				req.Page = strings.TrimPrefix(citation, title+", ")
+			}
+			req.Entry = gedcomx.Text(description.Titles)
+			req.URL = description.About
+			req.Transcription = gedcomx.Text(description.Descriptions)
+		}
+		if req.SourceID == "" {
+			im.warnf("source of %s was not imported, citation left out", id)
+			continue
+		}
+
+		if _, err := im.citationService.CreateCitation(ctx, &req); err != nil {
+			im.warnf("citation of %s not imported: %v", id, err)
+			continue
+		}
+		im.report.Created.Citations++
+	}
+}
+
+// notes creates notes on a person, relationship or event
+func (im *gedcomxImporter) notes(ctx context.Context, notes []gedcomx.Note, subjectID string) {
+	for _, note := range notes {
+		req := interfaces.NoteCreateRequest{
+			SubjectID: subjectID,
+			Title:     strings.TrimSpace(note.Subject),
+			Body:      strings.TrimSpace(note.Text),
+		}
+		if req.Body == "" {
+			continue
+		}
+		if _, err := im.noteService.CreateNote(ctx, &req, im.author); err != nil {
+			im.warnf("note not imported: %v", err)
+			continue
+		}
+		im.report.Created.Notes++
+	}
+}
+
+// name reads a name of a person from its first name form. The first name of a person defaults to the birth
+// name and later ones to aliases.
+func (im *gedcomxImporter) name(personID string, name gedcomx.Name, first bool) (interfaces.PersonName, bool) {
+	if len(name.NameForms) == 0 {
+		return interfaces.PersonName{}, false
+	}
+	form := name.NameForms[0]
+
+	personName := interfaces.PersonName{
+		Type:      interfaces.NameTypeAlias,
+		Preferred: name.Preferred,
+	}
+	if first {
+		personName.Type = interfaces.NameTypeBirth
+	}
+	if name.Type != "" {
+		if nameType, found := gedcomxNameTypes[name.Type]; found {
+			personName.Type = nameType
+		} else {
+			im.warnf("person %s: name type %s is not known and was imported as %s", personID, typeName(name.Type), personName.Type)
+		}
+	}
+
+	var prefix string
+	for _, part := range form.Parts {
+		switch part.Type {
+		case gedcomx.NamePartGiven:
+			personName.GivenName = joinNonEmpty(" ", personName.GivenName, strings.TrimSpace(part.Value))
+		case gedcomx.NamePartSurname:
+			personName.Surname = joinNonEmpty(" ", personName.Surname, strings.TrimSpace(part.Value))
+		case gedcomx.NamePartPrefix:
+			prefix = strings.TrimSpace(part.Value)
+		default:
+			im.skip("persons.names.parts." + typeName(part.Type))
+		}
+	}
+	if prefix != "" && !strings.HasPrefix(personName.Surname, prefix) {
+		personName.Surname = joinNonEmpty(" ", prefix, personName.Surname)
+	}
+
+	// Names without parts are split as the full text is written, with the surname last
+	if personName.GivenName == "" && personName.Surname == "" {
+		fullText := strings.TrimSpace(form.FullText)
+		if i := strings.LastIndex(fullText, " "); i >= 0 {
+			personName.GivenName, personName.Surname = fullText[:i], fullText[i+1:]
+		} else {
+			personName.GivenName = fullText
+		}
+	}
+
+	return personName, personName.GivenName != "" || personName.Surname != ""
+}
+
+// date returns the date of a fact from its formal date, or from the original when there is none,
+// warning about dates that cannot be read
+func (im *gedcomxImporter) date(ownerID string, fact gedcomx.Fact) interfaces.GenealogicalDate {
+	if fact.Date == nil {
+		return interfaces.GenealogicalDate{}
+	}
+
+	if fact.Date.Formal != "" {
+		date, err := gedcomx.ParseDate(fact.Date.Formal)
+		if err != nil {
+			im.warnf("%s: %s: %v, date left out", ownerID, typeName(fact.Type), err)
+		}
+		return date
+	}
+
+	// Originals are often written as in GEDCOM, such as "12 May 1843"
+	date, err := gedcom.ParseDate(fact.Date.Original)
+	if err != nil {
+		im.warnf("%s: %s: %v, date left out", ownerID, typeName(fact.Type), err)
+		return interfaces.GenealogicalDate{}
+	}
+	if date.Phrase != "" {
+		im.warnf("%s: %s: date phrase %q left out", ownerID, typeName(fact.Type), date.Phrase)
+	}
+	return date.Value
+}
+
+// place returns the ID of the place of a fact, creating the place when needed
+func (im *gedcomxImporter) place(ctx context.Context, ownerID string, fact gedcomx.Fact) string {
+	if fact.Place == nil {
+		return ""
+	}
+
+	name := strings.TrimSpace(fact.Place.Original)
+	if fullName := im.placeNames[gedcomx.LocalID(fact.Place.Description)]; fullName != "" {
+		name = fullName
+	}
+	if name == "" {
+		return ""
+	}
+
+	id, err := im.places.Resolve(ctx, name)
+	if err != nil {
+		im.warnf("%s: place %q not imported: %v", ownerID, name, err)
+		return ""
+	}
+	return id
+}
+
+// skip counts a property that was not imported
+func (im *gedcomxImporter) skip(path string) {
+	im.report.SkippedTags[path]++
+}
+
+// warnf adds a warning about the document
+func (im *gedcomxImporter) warnf(format string, args ...any) {
+	im.report.Warnings = append(im.report.Warnings, fmt.Sprintf(format, args...))
+}
+
+// findFact returns the first fact of a type, or nil when there is none
+func findFact(facts []gedcomx.Fact, factType string) *gedcomx.Fact {
+	for i := range facts {
+		if facts[i].Type == factType {
+			return &facts[i]
+		}
+	}
+	return nil
+}
+
+// isSpouseFact reports whether a couple fact type only tells the kind of partnership
+func isSpouseFact(factType string) bool {
+	for _, candidate := range gedcomxSpouseQualifiers {
+		if candidate.factType == factType {
+			return true
+		}
+	}
+	return false
+}
+
+// isParentFact reports whether a parent-child fact type tells how the parent came to be one
+func isParentFact(factType string) bool {
+	_, found := gedcomxParentQualifiers[factType]
+	return found
+}
+
+// referenceID returns the local ID a resource reference refers to
+func referenceID(reference gedcomx.ResourceReference) string {
+	if reference.ResourceID != "" {
+		return reference.ResourceID
+	}
+	return gedcomx.LocalID(reference.Resource)
+}
+
+// typeName returns the last segment of a type URI, such as Birth for http://gedcomx.org/Birth
+func typeName(uri string) string {
+	return uri[strings.LastIndex(uri, "/")+1:]
+}
@@ -0,0 +1,96 @@
+package v1importservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/rogerwesterbo/familytree/internal/services/v1citationservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1eventservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1mediaservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1noteservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1personservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1placeservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1relationshipservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1sourceservice"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// ErrInvalidImport is returned when a file cannot be imported at all, such as when it is not in the given format
+var ErrInvalidImport = errors.New("invalid import")
+
+// ImportService creates persons, relationships and their sources from files written by other family tree programs.
+// Records are created through the services owning them, so they are validated as if they were entered by hand.
+type ImportService struct {
+	personService       *v1personservice.PersonService
+	relationshipService *v1relationshipservice.RelationshipService
+	eventService        *v1eventservice.EventService
+	placeService        *v1placeservice.PlaceService
+	sourceService       *v1sourceservice.SourceService
+	citationService     *v1citationservice.CitationService
+	noteService         *v1noteservice.NoteService
+	mediaService        *v1mediaservice.MediaService
+	// maxFileSize and maxMediaSize limit the size of the files decompressed from archives
+	maxFileSize  int64
+	maxMediaSize int64
+}
+
+// NewImportService creates a new import service
+func NewImportService(
+	personService *v1personservice.PersonService,
+	relationshipService *v1relationshipservice.RelationshipService,
+	eventService *v1eventservice.EventService,
+	placeService *v1placeservice.PlaceService,
+	sourceService *v1sourceservice.SourceService,
+	citationService *v1citationservice.CitationService,
+	noteService *v1noteservice.NoteService,
+	mediaService *v1mediaservice.MediaService,
+	maxFileSize int64,
+	maxMediaSize int64,
+) *ImportService {
+	return &ImportService{
+		personService:       personService,
+		relationshipService: relationshipService,
+		eventService:        eventService,
+		placeService:        placeService,
+		sourceService:       sourceService,
+		citationService:     citationService,
+		noteService:         noteService,
+		mediaService:        mediaService,
+		maxFileSize:         maxFileSize,
+		maxMediaSize:        maxMediaSize,
+	}
+}
+
+// Import reads a file in a format and creates the records in it, with the notes written by author.
+// Records that fail validation are left out and reported as warnings rather than failing the import.
+func (s *ImportService) Import(ctx context.Context, format string, r io.Reader, author interfaces.NoteAuthor) (*interfaces.ImportReport, error) {
+	switch format {
+	case interfaces.ImportFormatGedcom:
+		return s.importGedcom(ctx, r, author)
+	case interfaces.ImportFormatGedcom7:
+		return s.importGedcom7(ctx, r, author)
+	case interfaces.ImportFormatGedzip:
+		return s.importGedzip(ctx, r, author)
+	case interfaces.ImportFormatGedcomX:
+		return s.importGedcomX(ctx, r, author)
+	case interfaces.ImportFormatCSV:
+		return s.ImportCSV(ctx, r, nil, interfaces.CSVImportOptions{}, author)
+	case "":
+		return nil, fmt.Errorf("%w: format is required", ErrInvalidImport)
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %s. Supported formats are: %s, %s, %s, %s, %s", ErrInvalidImport, format,
+			interfaces.ImportFormatGedcom, interfaces.ImportFormatGedcom7, interfaces.ImportFormatGedzip, interfaces.ImportFormatGedcomX,
+			interfaces.ImportFormatCSV)
+	}
+}
+
+// newReport creates an empty report for an import in a format
+func newReport(format string) *interfaces.ImportReport {
+	return &interfaces.ImportReport{
+		Format:      format,
+		SkippedTags: map[string]int{},
+		Warnings:    []string{},
+	}
+}
@@ -0,0 +1,444 @@
+package v1mediaservice
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"  // register GIF for image dimensions
+	_ "image/jpeg" // register JPEG for image dimensions
+	_ "image/png"  // register PNG for image dimensions
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// ErrInvalidMedia is returned when an uploaded file or its metadata fail validation
+var ErrInvalidMedia = errors.New("invalid media")
+
+// sniffLength is the number of bytes http.DetectContentType looks at
+const sniffLength = 512
+
+// MediaService handles business logic for media operations
+type MediaService struct {
+	repo       interfaces.MediaRepository
+	linkRepo   interfaces.MediaLinkRepository
+	blobStore  interfaces.BlobStore
+	personRepo interfaces.PersonRepository
+	eventRepo  interfaces.EventRepository
+	sourceRepo interfaces.SourceRepository
+}
+
+// NewMediaService creates a new media service
+func NewMediaService(
+	repo interfaces.MediaRepository,
+	linkRepo interfaces.MediaLinkRepository,
+	blobStore interfaces.BlobStore,
+	personRepo interfaces.PersonRepository,
+	eventRepo interfaces.EventRepository,
+	sourceRepo interfaces.SourceRepository,
+) *MediaService {
+	return &MediaService{
+		repo:       repo,
+		linkRepo:   linkRepo,
+		blobStore:  blobStore,
+		personRepo: personRepo,
+		eventRepo:  eventRepo,
+		sourceRepo: sourceRepo,
+	}
+}
+
+// UploadMedia stores the content of an uploaded file and creates its metadata, linked to its subjects.
+// The MIME type is detected from the content, falling back to the type declared by the client.
+func (s *MediaService) UploadMedia(ctx context.Context, req *interfaces.MediaUploadRequest, content io.Reader) (*interfaces.Media, error) {
+	subjects, err := s.validateSubjects(ctx, req.Subjects)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReaderSize(content, sniffLength)
+	head, err := reader.Peek(sniffLength)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if len(head) == 0 {
+		return nil, fmt.Errorf("%w: the file is empty", ErrInvalidMedia)
+	}
+
+	mimeType := detectMimeType(head, req.MimeType)
+	if !isSupportedMimeType(mimeType) {
+		return nil, fmt.Errorf("%w: unsupported file type %s, expected an image, a PDF document or audio", ErrInvalidMedia, mimeType)
+	}
+
+	storageKey, err := newStorageKey()
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	counter := &byteCounter{}
+	if err := s.blobStore.Put(ctx, storageKey, io.TeeReader(reader, io.MultiWriter(hash, counter))); err != nil {
+		return nil, fmt.Errorf("failed to store media content: %w", err)
+	}
+
+	media := &interfaces.Media{
+		FileName:   cleanFileName(req.FileName),
+		MimeType:   mimeType,
+		Size:       counter.n,
+		Checksum:   hex.EncodeToString(hash.Sum(nil)),
+		Caption:    strings.TrimSpace(req.Caption),
+		Date:       req.Date,
+		StorageKey: storageKey,
+	}
+	if strings.HasPrefix(mimeType, "image/") {
+		media.Width, media.Height = s.imageDimensions(ctx, storageKey)
+	}
+
+	// Create in repository
+	if err := s.repo.Create(ctx, media); err != nil {
+		_ = s.blobStore.Delete(ctx, storageKey)
+		return nil, fmt.Errorf("failed to create media: %w", err)
+	}
+
+	if err := s.linkSubjects(ctx, media.ID, subjects); err != nil {
+		_ = s.repo.Delete(ctx, media.Key)
+		_ = s.blobStore.Delete(ctx, storageKey)
+		return nil, err
+	}
+	media.Subjects = subjects
+
+	return media, nil
+}
+
+// GetMedia retrieves the metadata of a media file by ID with its subjects
+func (s *MediaService) GetMedia(ctx context.Context, id string) (*interfaces.Media, error) {
+	if id == "" {
+		return nil, fmt.Errorf("media ID is required")
+	}
+
+	media, err := s.repo.GetByID(ctx, mediaKey(id))
+	if err != nil {
+		return nil, err
+	}
+
+	items := []interfaces.Media{*media}
+	if err := s.fillSubjects(ctx, items); err != nil {
+		return nil, err
+	}
+
+	return &items[0], nil
+}
+
+// OpenMediaContent retrieves the metadata of a media file with its subjects and opens its content.
+// The caller must close the content.
+func (s *MediaService) OpenMediaContent(ctx context.Context, id string) (*interfaces.Media, io.ReadCloser, error) {
+	media, err := s.GetMedia(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.blobStore.Get(ctx, media.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return media, content, nil
+}
+
+// UpdateMedia updates the metadata of a media file, replacing its subjects when they are given
+func (s *MediaService) UpdateMedia(ctx context.Context, id string, req *interfaces.MediaUpdateRequest) (*interfaces.Media, error) {
+	if id == "" {
+		return nil, fmt.Errorf("media ID is required")
+	}
+
+	// Get existing media
+	media, err := s.repo.GetByID(ctx, mediaKey(id))
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields if provided
+	if req.FileName != "" {
+		media.FileName = cleanFileName(req.FileName)
+	}
+	if req.Caption != "" {
+		media.Caption = strings.TrimSpace(req.Caption)
+	}
+	if !req.Date.IsZero() {
+		media.Date = req.Date
+	}
+
+	var subjects []string
+	if req.Subjects != nil {
+		if subjects, err = s.validateSubjects(ctx, *req.Subjects); err != nil {
+			return nil, err
+		}
+	}
+
+	// Update in repository
+	if err := s.repo.Update(ctx, media.Key, media); err != nil {
+		return nil, fmt.Errorf("failed to update media: %w", err)
+	}
+
+	if req.Subjects != nil {
+		if err := s.linkRepo.DeleteByMedia(ctx, media.ID); err != nil {
+			return nil, err
+		}
+		if err := s.linkSubjects(ctx, media.ID, subjects); err != nil {
+			return nil, err
+		}
+	}
+
+	items := []interfaces.Media{*media}
+	if err := s.fillSubjects(ctx, items); err != nil {
+		return nil, err
+	}
+
+	return &items[0], nil
+}
+
+// DeleteMedia deletes a media file, its links and its content
+func (s *MediaService) DeleteMedia(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("media ID is required")
+	}
+
+	media, err := s.repo.GetByID(ctx, mediaKey(id))
+	if err != nil {
+		return err
+	}
+
+	if err := s.linkRepo.DeleteByMedia(ctx, media.ID); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, media.Key); err != nil {
+		return err
+	}
+
+	if err := s.blobStore.Delete(ctx, media.StorageKey); err != nil {
+		return fmt.Errorf("failed to delete media content: %w", err)
+	}
+
+	return nil
+}
+
+// ListMedia retrieves the metadata of all media files with their subjects
+func (s *MediaService) ListMedia(ctx context.Context) ([]interfaces.Media, error) {
+	media, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.fillSubjects(ctx, media); err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// GetSubjectMedia retrieves the media attached to a person, event or source
+func (s *MediaService) GetSubjectMedia(ctx context.Context, subjectID string) ([]interfaces.Media, error) {
+	if err := s.subjectExists(ctx, subjectID); err != nil {
+		return nil, err
+	}
+
+	media, err := s.repo.FindBySubject(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.fillSubjects(ctx, media); err != nil {
+		return nil, err
+	}
+
+	if media == nil {
+		media = []interfaces.Media{}
+	}
+
+	return media, nil
+}
+
+// imageDimensions reads the width and height of a stored image, or zeros when the format is not recognised
+func (s *MediaService) imageDimensions(ctx context.Context, storageKey string) (int, int) {
+	content, err := s.blobStore.Get(ctx, storageKey)
+	if err != nil {
+		return 0, 0
+	}
+	defer func() {
+		_ = content.Close()
+	}()
+
+	config, _, err := image.DecodeConfig(content)
+	if err != nil {
+		return 0, 0
+	}
+
+	return config.Width, config.Height
+}
+
+// linkSubjects creates the link edges from a media file to its subjects.
+// When an edge cannot be created, the edges already created for the media file are removed.
+func (s *MediaService) linkSubjects(ctx context.Context, mediaID string, subjects []string) error {
+	for _, subject := range subjects {
+		link := &interfaces.MediaLink{
+			From: mediaID,
+			To:   subject,
+		}
+		if err := s.linkRepo.Create(ctx, link); err != nil {
+			_ = s.linkRepo.DeleteByMedia(ctx, mediaID)
+			return fmt.Errorf("failed to link media to %s: %w", subject, err)
+		}
+	}
+	return nil
+}
+
+// fillSubjects sets the subjects of the media from their link edges
+func (s *MediaService) fillSubjects(ctx context.Context, media []interfaces.Media) error {
+	if len(media) == 0 {
+		return nil
+	}
+
+	mediaIDs := make([]string, 0, len(media))
+	for _, item := range media {
+		mediaIDs = append(mediaIDs, item.ID)
+	}
+
+	links, err := s.linkRepo.FindByMedia(ctx, mediaIDs)
+	if err != nil {
+		return err
+	}
+
+	byMedia := make(map[string][]string)
+	for _, link := range links {
+		byMedia[link.From] = append(byMedia[link.From], link.To)
+	}
+
+	for i := range media {
+		media[i].Subjects = byMedia[media[i].ID]
+	}
+
+	return nil
+}
+
+// validateSubjects validates that every subject is an existing person, event or source, listed once
+func (s *MediaService) validateSubjects(ctx context.Context, subjects []string) ([]string, error) {
+	result := make([]string, 0, len(subjects))
+	seen := make(map[string]bool)
+	for _, subject := range subjects {
+		subject = strings.TrimSpace(subject)
+		if seen[subject] {
+			return nil, fmt.Errorf("%w: %s is listed more than once", ErrInvalidMedia, subject)
+		}
+		seen[subject] = true
+
+		if err := s.subjectExists(ctx, subject); err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				return nil, fmt.Errorf("%w: subject %s does not exist", ErrInvalidMedia, subject)
+			}
+			return nil, err
+		}
+
+		result = append(result, subject)
+	}
+
+	return result, nil
+}
+
+// subjectExists checks that a document ID refers to an existing person, event or source
+func (s *MediaService) subjectExists(ctx context.Context, subjectID string) error {
+	collection, key, found := strings.Cut(subjectID, "/")
+	if !found || key == "" {
+		return fmt.Errorf("%w: subject %q must be a document ID such as persons/123", ErrInvalidMedia, subjectID)
+	}
+
+	var err error
+	switch collection {
+	case "persons":
+		_, err = s.personRepo.GetByID(ctx, key)
+	case "events":
+		_, err = s.eventRepo.GetByID(ctx, key)
+	case "sources":
+		_, err = s.sourceRepo.GetByID(ctx, key)
+	default:
+		return fmt.Errorf("%w: subject %s must be a person, event or source", ErrInvalidMedia, subjectID)
+	}
+
+	return err
+}
+
+// detectMimeType detects the MIME type of a file from its first bytes. When the content is not recognised,
+// or is a container that may hold audio, such as Ogg or MP4, the type declared by the client is used instead.
+func detectMimeType(head []byte, declared string) string {
+	detected, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if isSupportedMimeType(detected) {
+		return detected
+	}
+
+	switch detected {
+	case "application/octet-stream", "application/ogg", "video/mp4", "video/webm":
+		if declared, _, err := mime.ParseMediaType(declared); err == nil && isSupportedMimeType(declared) {
+			return declared
+		}
+	}
+
+	return detected
+}
+
+// ConfirmMimeType sniffs the first bytes of content and reports whether they confirm that it is of mimeType and
+// a photo, a scanned document or an audio recording. Types that were taken from the client when the content was
+// not recognised are not confirmed. The returned reader still yields all of content.
+func ConfirmMimeType(content io.Reader, mimeType string) (io.Reader, bool) {
+	reader := bufio.NewReaderSize(content, sniffLength)
+	head, _ := reader.Peek(sniffLength)
+	detected, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	return reader, detected == mimeType && isSupportedMimeType(detected)
+}
+
+// isSupportedMimeType reports whether a MIME type is a photo, a scanned document or an audio recording
+func isSupportedMimeType(mimeType string) bool {
+	return strings.HasPrefix(mimeType, "image/") ||
+		strings.HasPrefix(mimeType, "audio/") ||
+		mimeType == "application/pdf"
+}
+
+// cleanFileName strips any directories from a client-supplied file name
+func cleanFileName(fileName string) string {
+	fileName = filepath.Base(strings.ReplaceAll(strings.TrimSpace(fileName), `\`, "/"))
+	if fileName == "." || fileName == "/" {
+		return ""
+	}
+	return fileName
+}
+
+// newStorageKey returns a random key for storing the content of a media file
+func newStorageKey() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate media storage key: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// mediaKey returns the document key for a media key or ID
+func mediaKey(mediaID string) string {
+	return strings.TrimPrefix(mediaID, "media/")
+}
+
+// byteCounter counts the bytes written to it
+type byteCounter struct {
+	n int64
+}
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,289 @@
+package v1noteservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// ErrInvalidNote is returned when a note or its subject fails validation
+var ErrInvalidNote = errors.New("invalid note")
+
+// taskStatuses are the statuses a research task can have
+var taskStatuses = []string{
+	interfaces.TaskStatusOpen,
+	interfaces.TaskStatusInProgress,
+	interfaces.TaskStatusDone,
+	interfaces.TaskStatusCancelled,
+}
+
+// OpenTaskStatuses are the statuses of tasks that still need work
+var OpenTaskStatuses = []string{interfaces.TaskStatusOpen, interfaces.TaskStatusInProgress}
+
+// NoteService handles business logic for note operations
+type NoteService struct {
+	repo             interfaces.NoteRepository
+	personRepo       interfaces.PersonRepository
+	relationshipRepo interfaces.RelationshipRepository
+	eventRepo        interfaces.EventRepository
+	participantRepo  interfaces.ParticipantRepository
+}
+
+// NewNoteService creates a new note service
+func NewNoteService(
+	repo interfaces.NoteRepository,
+	personRepo interfaces.PersonRepository,
+	relationshipRepo interfaces.RelationshipRepository,
+	eventRepo interfaces.EventRepository,
+	participantRepo interfaces.ParticipantRepository,
+) *NoteService {
+	return &NoteService{
+		repo:             repo,
+		personRepo:       personRepo,
+		relationshipRepo: relationshipRepo,
+		eventRepo:        eventRepo,
+		participantRepo:  participantRepo,
+	}
+}
+
+// CreateNote creates a new note written by author
+func (s *NoteService) CreateNote(ctx context.Context, req *interfaces.NoteCreateRequest, author interfaces.NoteAuthor) (*interfaces.Note, error) {
+	note := &interfaces.Note{
+		SubjectID: strings.TrimSpace(req.SubjectID),
+		Title:     strings.TrimSpace(req.Title),
+		Body:      strings.TrimSpace(req.Body),
+		Author:    author,
+		Status:    strings.TrimSpace(req.Status),
+		Assignee:  strings.TrimSpace(req.Assignee),
+		DueDate:   req.DueDate,
+	}
+
+	// A note given an assignee or a due date is a task
+	if note.Status == "" && (note.Assignee != "" || !note.DueDate.IsZero()) {
+		note.Status = interfaces.TaskStatusOpen
+	}
+
+	if err := s.validateNote(ctx, note); err != nil {
+		return nil, err
+	}
+
+	// Create in repository
+	if err := s.repo.Create(ctx, note); err != nil {
+		return nil, fmt.Errorf("failed to create note: %w", err)
+	}
+
+	return note, nil
+}
+
+// GetNote retrieves a note by ID
+func (s *NoteService) GetNote(ctx context.Context, id string) (*interfaces.Note, error) {
+	if id == "" {
+		return nil, fmt.Errorf("note ID is required")
+	}
+
+	note, err := s.repo.GetByID(ctx, noteKey(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return note, nil
+}
+
+// UpdateNote updates an existing note. The author is kept.
+func (s *NoteService) UpdateNote(ctx context.Context, id string, req *interfaces.NoteUpdateRequest) (*interfaces.Note, error) {
+	if id == "" {
+		return nil, fmt.Errorf("note ID is required")
+	}
+
+	// Get existing note
+	note, err := s.repo.GetByID(ctx, noteKey(id))
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields if provided
+	if req.SubjectID != "" {
+		note.SubjectID = strings.TrimSpace(req.SubjectID)
+	}
+	if req.Title != "" {
+		note.Title = strings.TrimSpace(req.Title)
+	}
+	if req.Body != "" {
+		note.Body = strings.TrimSpace(req.Body)
+	}
+	if req.Status != nil {
+		note.Status = strings.TrimSpace(*req.Status)
+	}
+	if req.Assignee != nil {
+		note.Assignee = strings.TrimSpace(*req.Assignee)
+	}
+	if req.DueDate != nil {
+		note.DueDate = *req.DueDate
+	}
+
+	if err := s.validateNote(ctx, note); err != nil {
+		return nil, err
+	}
+
+	// Update in repository
+	if err := s.repo.Update(ctx, note.Key, note); err != nil {
+		return nil, fmt.Errorf("failed to update note: %w", err)
+	}
+
+	return note, nil
+}
+
+// DeleteNote deletes a note
+func (s *NoteService) DeleteNote(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("note ID is required")
+	}
+
+	if err := s.repo.Delete(ctx, noteKey(id)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListNotes retrieves all notes
+func (s *NoteService) ListNotes(ctx context.Context) ([]interfaces.Note, error) {
+	notes, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return notes, nil
+}
+
+// GetSubjectNotes gets the notes attached to a person, relationship or event
+func (s *NoteService) GetSubjectNotes(ctx context.Context, subjectID string) ([]interfaces.Note, error) {
+	if err := s.subjectExists(ctx, subjectID); err != nil {
+		return nil, err
+	}
+
+	notes, err := s.repo.FindBySubject(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return notes, nil
+}
+
+// ListTasks gets the research tasks with one of the statuses, the open ones when none are given,
+// optionally only those assigned to assignee or attached to subjectID
+func (s *NoteService) ListTasks(ctx context.Context, statuses []string, assignee, subjectID string) ([]interfaces.Note, error) {
+	if len(statuses) == 0 {
+		statuses = OpenTaskStatuses
+	}
+	for _, status := range statuses {
+		if !slices.Contains(taskStatuses, status) {
+			return nil, fmt.Errorf("%w: status %s. Valid statuses are: %s", ErrInvalidNote, status, strings.Join(taskStatuses, ", "))
+		}
+	}
+
+	tasks, err := s.repo.FindTasks(ctx, statuses, strings.TrimSpace(assignee), strings.TrimSpace(subjectID))
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// SubjectPersons returns the persons the notes are about, by note subject: the person a note is attached to,
+// the two persons of a relationship or the participants of an event. Subjects that no longer exist have none.
+func (s *NoteService) SubjectPersons(ctx context.Context, notes []interfaces.Note) (map[string][]string, error) {
+	persons := make(map[string][]string)
+	var eventIDs []string
+	for _, note := range notes {
+		if _, done := persons[note.SubjectID]; done {
+			continue
+		}
+
+		collection, key, _ := strings.Cut(note.SubjectID, "/")
+		switch collection {
+		case "persons":
+			persons[note.SubjectID] = []string{note.SubjectID}
+		case "relationships":
+			relationship, err := s.relationshipRepo.GetByID(ctx, key)
+			if err != nil && !strings.Contains(err.Error(), "not found") {
+				return nil, err
+			}
+			persons[note.SubjectID] = nil
+			if relationship != nil {
+				persons[note.SubjectID] = []string{relationship.From, relationship.To}
+			}
+		case "events":
+			persons[note.SubjectID] = nil
+			eventIDs = append(eventIDs, note.SubjectID)
+		}
+	}
+
+	if len(eventIDs) > 0 {
+		participants, err := s.participantRepo.FindByEvents(ctx, eventIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, participant := range participants {
+			persons[participant.To] = append(persons[participant.To], participant.From)
+		}
+	}
+
+	return persons, nil
+}
+
+// validateNote validates the body, subject and task fields of a note
+func (s *NoteService) validateNote(ctx context.Context, note *interfaces.Note) error {
+	if note.Body == "" {
+		return fmt.Errorf("%w: body is required", ErrInvalidNote)
+	}
+	if note.SubjectID == "" {
+		return fmt.Errorf("%w: subjectId is required", ErrInvalidNote)
+	}
+	if note.Status != "" && !slices.Contains(taskStatuses, note.Status) {
+		return fmt.Errorf("%w: status %s. Valid statuses are: %s", ErrInvalidNote, note.Status, strings.Join(taskStatuses, ", "))
+	}
+	if note.Status == "" && (note.Assignee != "" || !note.DueDate.IsZero()) {
+		return fmt.Errorf("%w: only tasks can have an assignee or a due date, give the note a status", ErrInvalidNote)
+	}
+
+	if err := s.subjectExists(ctx, note.SubjectID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return fmt.Errorf("%w: subject %s does not exist", ErrInvalidNote, note.SubjectID)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// subjectExists checks that a document ID refers to an existing person, relationship or event
+func (s *NoteService) subjectExists(ctx context.Context, subjectID string) error {
+	collection, key, found := strings.Cut(subjectID, "/")
+	if !found || key == "" {
+		return fmt.Errorf("%w: subject %q must be a document ID such as persons/123", ErrInvalidNote, subjectID)
+	}
+
+	var err error
+	switch collection {
+	case "persons":
+		_, err = s.personRepo.GetByID(ctx, key)
+	case "relationships":
+		_, err = s.relationshipRepo.GetByID(ctx, key)
+	case "events":
+		_, err = s.eventRepo.GetByID(ctx, key)
+	default:
+		return fmt.Errorf("%w: subject %s must be a person, relationship or event", ErrInvalidNote, subjectID)
+	}
+
+	return err
+}
+
+// noteKey returns the document key for a note key or ID
+func noteKey(noteID string) string {
+	return strings.TrimPrefix(noteID, "notes/")
+}
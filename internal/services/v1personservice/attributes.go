@@ -0,0 +1,137 @@
+package v1personservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/internal/services/v1placeservice"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// ErrInvalidAttribute is returned when a person attribute is not defined or its value does not fit its attribute type
+var ErrInvalidAttribute = errors.New("invalid attribute")
+
+// validateAttributes checks attribute values against their attribute types and returns them in their stored form:
+// strings and enum values trimmed, numbers as float64, dates as genealogical dates and places as document IDs.
+// Null values are kept as nil so updates can remove the attribute.
+func (s *PersonService) validateAttributes(ctx context.Context, values map[string]any) (map[string]any, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	attributeTypes, err := s.attributeService.AttributeTypesByName(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := make(map[string]any, len(values))
+	for name, value := range values {
+		attributeType, found := attributeTypes[name]
+		if !found {
+			return nil, fmt.Errorf("%w: no attribute type named %s is defined", ErrInvalidAttribute, name)
+		}
+		if value == nil {
+			normalized[name] = nil
+			continue
+		}
+
+		if normalized[name], err = s.attributeValue(ctx, attributeType, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return normalized, nil
+}
+
+// attributeValue converts a value sent by a client into the stored form of its attribute type
+func (s *PersonService) attributeValue(ctx context.Context, attributeType interfaces.AttributeType, value any) (any, error) {
+	invalid := func(expected string) error {
+		return fmt.Errorf("%w: %s must be %s", ErrInvalidAttribute, attributeType.Name, expected)
+	}
+
+	switch attributeType.ValueType {
+	case interfaces.AttributeValueTypeNumber:
+		switch number := value.(type) {
+		case float64:
+			return number, nil
+		case string:
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+			if err != nil {
+				return nil, invalid("a number")
+			}
+			return parsed, nil
+		default:
+			return nil, invalid("a number")
+		}
+	case interfaces.AttributeValueTypeDate:
+		var text string
+		switch date := value.(type) {
+		case interfaces.GenealogicalDate:
+			return date, nil
+		case string:
+			text = date
+		case map[string]any:
+			// The object form written by GenealogicalDate.MarshalJSON
+			text, _ = date["value"].(string)
+		}
+		date, err := interfaces.ParseGenealogicalDate(text)
+		if err != nil || date.IsZero() {
+			return nil, invalid("a date such as 1843-05-12, ABT 1843 or BET 1801 AND 1805")
+		}
+		return date, nil
+	}
+
+	text, ok := value.(string)
+	text = strings.TrimSpace(text)
+	if !ok || text == "" {
+		return nil, invalid("a non-empty string")
+	}
+
+	switch attributeType.ValueType {
+	case interfaces.AttributeValueTypeEnum:
+		if !slices.Contains(attributeType.EnumValues, text) {
+			return nil, invalid("one of: " + strings.Join(attributeType.EnumValues, ", "))
+		}
+		return text, nil
+	case interfaces.AttributeValueTypePlace:
+		return s.placeService.ValidatePlaceReference(ctx, "attributes."+attributeType.Name, text)
+	default:
+		return text, nil
+	}
+}
+
+// attributeFilters converts attribute values given as text, such as query parameters, into filters matching stored values
+func (s *PersonService) attributeFilters(ctx context.Context, values map[string]string) ([]interfaces.AttributeFilter, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	attributeTypes, err := s.attributeService.AttributeTypesByName(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := make([]interfaces.AttributeFilter, 0, len(values))
+	for name, text := range values {
+		attributeType, found := attributeTypes[name]
+		if !found {
+			return nil, fmt.Errorf("%w: no attribute type named %s is defined", ErrInvalidAttribute, name)
+		}
+
+		var value any
+		if attributeType.ValueType == interfaces.AttributeValueTypePlace {
+			// Filtering does not require the place to exist, only the ID form of the stored value
+			value = v1placeservice.PlaceDocumentID(strings.TrimSpace(text))
+		} else if value, err = s.attributeValue(ctx, attributeType, text); err != nil {
+			return nil, err
+		}
+
+		filters = append(filters, interfaces.AttributeFilter{Name: name, Value: value})
+	}
+
+	return filters, nil
+}
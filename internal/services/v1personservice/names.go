@@ -0,0 +1,98 @@
+package v1personservice
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// ErrInvalidName is returned when a person's names are incomplete or inconsistent
+var ErrInvalidName = errors.New("invalid name")
+
+var nameTypes = map[string]bool{
+	interfaces.NameTypeBirth:      true,
+	interfaces.NameTypeMarried:    true,
+	interfaces.NameTypePatronymic: true,
+	interfaces.NameTypeFarm:       true,
+	interfaces.NameTypeAlias:      true,
+	interfaces.NameTypeReligious:  true,
+}
+
+// normalizeNames trims and validates a list of names and makes sure exactly one of them is preferred,
+// defaulting to the first
+func normalizeNames(names []interfaces.PersonName) ([]interfaces.PersonName, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%w: at least one name is required", ErrInvalidName)
+	}
+
+	normalized := make([]interfaces.PersonName, 0, len(names))
+	preferred := -1
+	for i, name := range names {
+		name.GivenName = strings.TrimSpace(name.GivenName)
+		name.Surname = strings.TrimSpace(name.Surname)
+		name.Type = strings.TrimSpace(name.Type)
+
+		if name.GivenName == "" && name.Surname == "" {
+			return nil, fmt.Errorf("%w: names[%d] needs a givenName or a surname", ErrInvalidName, i)
+		}
+		if !nameTypes[name.Type] {
+			return nil, fmt.Errorf("%w: names[%d] has unknown type %q", ErrInvalidName, i, name.Type)
+		}
+		if name.ValidTo.DefinitelyBefore(name.ValidFrom) {
+			return nil, fmt.Errorf("%w: names[%d] validTo %s must not be before validFrom %s", ErrInvalidName, i, name.ValidTo, name.ValidFrom)
+		}
+		if name.Preferred {
+			if preferred != -1 {
+				return nil, fmt.Errorf("%w: only one name can be preferred", ErrInvalidName)
+			}
+			preferred = i
+		}
+
+		normalized = append(normalized, name)
+	}
+
+	if preferred == -1 {
+		normalized[0].Preferred = true
+	}
+
+	return normalized, nil
+}
+
+// applyPreferredName copies the preferred name into the first and last name of the person
+func applyPreferredName(person *interfaces.Person) {
+	for _, name := range person.Names {
+		if name.Preferred {
+			person.FirstName = name.GivenName
+			person.LastName = name.Surname
+			return
+		}
+	}
+}
+
+// renamePreferred changes the preferred name of a person, adding it as the birth name
+// when the person has no names yet
+func renamePreferred(person *interfaces.Person, firstName, lastName string) {
+	if firstName != "" {
+		person.FirstName = firstName
+	}
+	if lastName != "" {
+		person.LastName = lastName
+	}
+
+	for i := range person.Names {
+		if person.Names[i].Preferred {
+			person.Names[i].GivenName = person.FirstName
+			person.Names[i].Surname = person.LastName
+			return
+		}
+	}
+
+	person.Names = append(person.Names, interfaces.PersonName{
+		GivenName: person.FirstName,
+		Surname:   person.LastName,
+		Type:      interfaces.NameTypeBirth,
+		Preferred: true,
+	})
+}
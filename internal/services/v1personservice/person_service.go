@@ -3,40 +3,93 @@ package v1personservice
 import (
 	"context"
 	"fmt"
+	"maps"
 	"strings"
 
+	"github.com/rogerwesterbo/familytree/internal/services/v1attributeservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1citationservice"
+	"github.com/rogerwesterbo/familytree/internal/services/v1placeservice"
 	"github.com/rogerwesterbo/familytree/pkg/interfaces"
 )
 
 // PersonService handles business logic for person operations
 type PersonService struct {
-	repo interfaces.PersonRepository
+	repo             interfaces.PersonRepository
+	relationshipRepo interfaces.RelationshipRepository
+	placeService     *v1placeservice.PlaceService
+	citationService  *v1citationservice.CitationService
+	attributeService *v1attributeservice.AttributeService
+	// livingYears is the number of years after their birth a person without a death date is taken as living
+	livingYears int
 }
 
 // NewPersonService creates a new person service
-func NewPersonService(repo interfaces.PersonRepository) *PersonService {
+func NewPersonService(
+	repo interfaces.PersonRepository,
+	relationshipRepo interfaces.RelationshipRepository,
+	placeService *v1placeservice.PlaceService,
+	citationService *v1citationservice.CitationService,
+	attributeService *v1attributeservice.AttributeService,
+	livingYears int,
+) *PersonService {
 	return &PersonService{
-		repo: repo,
+		repo:             repo,
+		relationshipRepo: relationshipRepo,
+		placeService:     placeService,
+		citationService:  citationService,
+		attributeService: attributeService,
+		livingYears:      livingYears,
 	}
 }
 
 // CreatePerson creates a new person with validation
 func (s *PersonService) CreatePerson(ctx context.Context, req *interfaces.PersonCreateRequest) (*interfaces.Person, error) {
 	// Validate required fields
-	if err := s.validateCreateRequest(req); err != nil {
+	if err := s.ValidateCreateRequest(req); err != nil {
 		return nil, err
 	}
 
+	names := req.Names
+	if len(names) == 0 {
+		names = []interfaces.PersonName{{
+			GivenName: req.FirstName,
+			Surname:   req.LastName,
+			Type:      interfaces.NameTypeBirth,
+		}}
+	}
+	names, err := normalizeNames(names)
+	if err != nil {
+		return nil, err
+	}
+
+	birthPlaceID, err := s.placeService.ValidatePlaceReference(ctx, "birthPlaceId", req.BirthPlaceID)
+	if err != nil {
+		return nil, err
+	}
+	deathPlaceID, err := s.placeService.ValidatePlaceReference(ctx, "deathPlaceId", req.DeathPlaceID)
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := s.validateAttributes(ctx, req.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	maps.DeleteFunc(attributes, func(_ string, value any) bool { return value == nil })
+
 	// Create person entity
 	person := &interfaces.Person{
-		FirstName: strings.TrimSpace(req.FirstName),
-		LastName:  strings.TrimSpace(req.LastName),
-		BirthDate: req.BirthDate,
-		DeathDate: req.DeathDate,
-		Gender:    strings.TrimSpace(req.Gender),
-		Email:     strings.TrimSpace(req.Email),
-		Phone:     strings.TrimSpace(req.Phone),
+		Names:        names,
+		BirthDate:    req.BirthDate,
+		BirthPlaceID: birthPlaceID,
+		DeathDate:    req.DeathDate,
+		DeathPlaceID: deathPlaceID,
+		Gender:       strings.TrimSpace(req.Gender),
+		Email:        strings.TrimSpace(req.Email),
+		Phone:        strings.TrimSpace(req.Phone),
+		Attributes:   attributes,
+		Privacy:      strings.TrimSpace(req.Privacy),
 	}
+	applyPreferredName(person)
 
 	// Create in repository
 	if err := s.repo.Create(ctx, person); err != nil {
@@ -73,11 +126,14 @@ func (s *PersonService) UpdatePerson(ctx context.Context, id string, req *interf
 	}
 
 	// Update fields if provided
-	if req.FirstName != "" {
-		person.FirstName = strings.TrimSpace(req.FirstName)
+	if req.Names != nil {
+		if person.Names, err = normalizeNames(*req.Names); err != nil {
+			return nil, err
+		}
+		applyPreferredName(person)
 	}
-	if req.LastName != "" {
-		person.LastName = strings.TrimSpace(req.LastName)
+	if req.FirstName != "" || req.LastName != "" {
+		renamePreferred(person, strings.TrimSpace(req.FirstName), strings.TrimSpace(req.LastName))
 	}
 	if !req.BirthDate.IsZero() {
 		person.BirthDate = req.BirthDate
@@ -85,6 +141,16 @@ func (s *PersonService) UpdatePerson(ctx context.Context, id string, req *interf
 	if !req.DeathDate.IsZero() {
 		person.DeathDate = req.DeathDate
 	}
+	if req.BirthPlaceID != "" {
+		if person.BirthPlaceID, err = s.placeService.ValidatePlaceReference(ctx, "birthPlaceId", req.BirthPlaceID); err != nil {
+			return nil, err
+		}
+	}
+	if req.DeathPlaceID != "" {
+		if person.DeathPlaceID, err = s.placeService.ValidatePlaceReference(ctx, "deathPlaceId", req.DeathPlaceID); err != nil {
+			return nil, err
+		}
+	}
 	if req.Gender != "" {
 		person.Gender = strings.TrimSpace(req.Gender)
 	}
@@ -94,6 +160,29 @@ func (s *PersonService) UpdatePerson(ctx context.Context, id string, req *interf
 	if req.Phone != "" {
 		person.Phone = strings.TrimSpace(req.Phone)
 	}
+	if len(req.Attributes) > 0 {
+		attributes, err := s.validateAttributes(ctx, req.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		if person.Attributes == nil {
+			person.Attributes = make(map[string]any, len(attributes))
+		}
+		// Removed attributes are kept as nil until the repository has removed them from the stored person
+		maps.Copy(person.Attributes, attributes)
+	}
+	if req.Privacy != nil {
+		person.Privacy = strings.TrimSpace(*req.Privacy)
+		if person.Privacy != "" {
+			if err := validatePrivacy(person.Privacy); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := validateLifespan(person.BirthDate, person.DeathDate); err != nil {
+		return nil, err
+	}
 
 	// Update in repository
 	if err := s.repo.Update(ctx, id, person); err != nil {
@@ -126,9 +215,37 @@ func (s *PersonService) ListPersons(ctx context.Context) ([]interfaces.Person, e
 	return persons, nil
 }
 
-// SearchPersonsByName searches persons by name
-func (s *PersonService) SearchPersonsByName(ctx context.Context, firstName, lastName string) ([]interfaces.Person, error) {
-	persons, err := s.repo.FindByName(ctx, strings.TrimSpace(firstName), strings.TrimSpace(lastName))
+// IncludeCitations fills in the citations supporting each of the persons. Redacted persons are left without citations.
+func (s *PersonService) IncludeCitations(ctx context.Context, persons []interfaces.Person) error {
+	personIDs := make([]string, 0, len(persons))
+	for _, person := range persons {
+		if !person.Redacted {
+			personIDs = append(personIDs, person.ID)
+		}
+	}
+
+	citations, err := s.citationService.FindCitations(ctx, personIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range persons {
+		if !persons[i].Redacted {
+			persons[i].Citations = citations[persons[i].ID]
+		}
+	}
+
+	return nil
+}
+
+// SearchPersons searches persons by any of their names and by attribute values given as text
+func (s *PersonService) SearchPersons(ctx context.Context, firstName, lastName string, attributes map[string]string) ([]interfaces.Person, error) {
+	filters, err := s.attributeFilters(ctx, attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	persons, err := s.repo.Search(ctx, strings.TrimSpace(firstName), strings.TrimSpace(lastName), filters)
 	if err != nil {
 		return nil, err
 	}
@@ -136,25 +253,42 @@ func (s *PersonService) SearchPersonsByName(ctx context.Context, firstName, last
 	return persons, nil
 }
 
-// validateCreateRequest validates a person create request
-func (s *PersonService) validateCreateRequest(req *interfaces.PersonCreateRequest) error {
-	if strings.TrimSpace(req.FirstName) == "" {
-		return fmt.Errorf("firstName is required")
-	}
-	if strings.TrimSpace(req.LastName) == "" {
-		return fmt.Errorf("lastName is required")
+// ValidateCreateRequest validates a person create request. Checks that need the stored data, such as of
+// places and attributes, are left to CreatePerson.
+func (s *PersonService) ValidateCreateRequest(req *interfaces.PersonCreateRequest) error {
+	if len(req.Names) == 0 {
+		if strings.TrimSpace(req.FirstName) == "" {
+			return fmt.Errorf("firstName is required")
+		}
+		if strings.TrimSpace(req.LastName) == "" {
+			return fmt.Errorf("lastName is required")
+		}
 	}
 
 	// Validate email format if provided
-	if req.Email != "" && !isValidEmail(req.Email) {
+	if req.Email != "" && !IsValidEmail(req.Email) {
 		return fmt.Errorf("invalid email format")
 	}
 
+	if privacy := strings.TrimSpace(req.Privacy); privacy != "" {
+		if err := validatePrivacy(privacy); err != nil {
+			return err
+		}
+	}
+
+	return validateLifespan(req.BirthDate, req.DeathDate)
+}
+
+// validateLifespan validates that a person does not die before they are born
+func validateLifespan(birthDate, deathDate interfaces.GenealogicalDate) error {
+	if deathDate.DefinitelyBefore(birthDate) {
+		return fmt.Errorf("deathDate %s must not be before birthDate %s", deathDate, birthDate)
+	}
 	return nil
 }
 
-// isValidEmail performs basic email validation
-func isValidEmail(email string) bool {
+// IsValidEmail performs basic email validation
+func IsValidEmail(email string) bool {
 	// Basic validation - contains @ and has characters before and after
 	parts := strings.Split(email, "@")
 	if len(parts) != 2 {
@@ -168,3 +302,16 @@ func isValidEmail(email string) bool {
 	}
 	return true
 }
+
+// PersonDocumentID returns the document ID (persons/<key>) for a person key or ID
+func PersonDocumentID(personID string) string {
+	if strings.Contains(personID, "/") {
+		return personID
+	}
+	return "persons/" + personID
+}
+
+// PersonKey returns the document key for a person key or ID
+func PersonKey(personID string) string {
+	return strings.TrimPrefix(personID, "persons/")
+}
@@ -0,0 +1,203 @@
+package v1personservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// ErrInvalidPrivacy is returned when a privacy level is not one of public, family and private
+var ErrInvalidPrivacy = errors.New("invalid privacy")
+
+// generationYears is the number of years assumed between the births of a parent and a child
+// when the birth year of a person is estimated from their descendants
+const generationYears = 25
+
+// privacyLevels are the privacy levels, from the least to the most restricted
+var privacyLevels = []string{interfaces.PrivacyPublic, interfaces.PrivacyFamily, interfaces.PrivacyPrivate}
+
+// ApplyPrivacy works out which of the persons are probably living and hides or redacts every person whose privacy
+// is more restricted than access. It returns the persons the viewer may see.
+func (s *PersonService) ApplyPrivacy(ctx context.Context, persons []interfaces.Person, access string, hide bool) ([]interfaces.Person, error) {
+	if err := validatePrivacy(access); err != nil {
+		return nil, err
+	}
+	if err := s.MarkLiving(ctx, persons); err != nil {
+		return nil, err
+	}
+
+	visible := make([]interfaces.Person, 0, len(persons))
+	for _, person := range persons {
+		switch {
+		case privacyRank(effectivePrivacy(person)) <= privacyRank(access):
+			visible = append(visible, person)
+		case !hide:
+			visible = append(visible, redact(person))
+		}
+	}
+
+	return visible, nil
+}
+
+// RedactPersons redacts, in place, every one of the persons whose privacy is more restricted than access.
+// It is meant for persons held in larger results, such as the nodes of a descendant tree, that keep their shape.
+func (s *PersonService) RedactPersons(ctx context.Context, access string, persons ...*interfaces.Person) error {
+	values := make([]interfaces.Person, 0, len(persons))
+	for _, person := range persons {
+		values = append(values, *person)
+	}
+
+	visible, err := s.ApplyPrivacy(ctx, values, access, false)
+	if err != nil {
+		return err
+	}
+	for i, person := range persons {
+		*person = visible[i]
+	}
+
+	return nil
+}
+
+// RestrictedPersons returns the IDs of the persons among personIDs whose privacy is more restricted than access,
+// for leaving out what is recorded about them elsewhere, such as their events and notes
+func (s *PersonService) RestrictedPersons(ctx context.Context, access string, personIDs []string) (map[string]bool, error) {
+	restricted := make(map[string]bool)
+	if len(personIDs) == 0 {
+		return restricted, nil
+	}
+
+	persons, err := s.repo.FindByIDs(ctx, personIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find persons: %w", err)
+	}
+
+	persons, err = s.ApplyPrivacy(ctx, persons, access, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, person := range persons {
+		if person.Redacted {
+			restricted[person.ID] = true
+		}
+	}
+
+	return restricted, nil
+}
+
+// MarkLiving fills in whether each of the persons is probably living. A person is probably living when they have
+// no death date and were born within the configured number of years, or, when their birth date is unknown, have a
+// living spouse or descendant close enough in age.
+func (s *PersonService) MarkLiving(ctx context.Context, persons []interfaces.Person) error {
+	year := time.Now().Year()
+
+	// Only persons without any dates need their relatives to tell
+	var undated []string
+	for _, person := range persons {
+		if person.DeathDate.IsZero() && person.BirthDate.IsZero() {
+			undated = append(undated, person.ID)
+		}
+	}
+
+	var relatives map[string][]interfaces.Relative
+	if len(undated) > 0 {
+		var err error
+		relatives, err = s.relationshipRepo.FindSpousesAndDescendants(ctx, undated, max(1, s.livingYears/generationYears))
+		if err != nil {
+			return fmt.Errorf("failed to find relatives: %w", err)
+		}
+	}
+
+	for i := range persons {
+		living := s.probablyLiving(persons[i], relatives[persons[i].ID], year)
+		persons[i].Living = &living
+	}
+
+	return nil
+}
+
+// probablyLiving reports whether a person is probably living in the given year
+func (s *PersonService) probablyLiving(person interfaces.Person, relatives []interfaces.Relative, year int) bool {
+	if !person.DeathDate.IsZero() {
+		return false
+	}
+	if !person.BirthDate.IsZero() {
+		return s.bornWithinLivingYears(latestYear(person.BirthDate), year)
+	}
+
+	// Estimate the birth year from living spouses and descendants
+	for _, relative := range relatives {
+		if !relative.Person.DeathDate.IsZero() || relative.Person.BirthDate.IsZero() {
+			continue
+		}
+		birthYear := latestYear(relative.Person.BirthDate)
+		if s.bornWithinLivingYears(birthYear, year) && s.bornWithinLivingYears(birthYear-relative.Generation*generationYears, year) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bornWithinLivingYears reports whether a person born in birthYear was born within the configured number of years
+func (s *PersonService) bornWithinLivingYears(birthYear, year int) bool {
+	return year-birthYear < s.livingYears
+}
+
+// latestYear returns the latest year a date may refer to, the end year for BET..AND dates
+func latestYear(date interfaces.GenealogicalDate) int {
+	if date.Qualifier == interfaces.DateQualifierBetween {
+		return date.End.Year
+	}
+	return date.Date.Year
+}
+
+// effectivePrivacy returns the privacy of a person: the privacy override when there is one,
+// otherwise family for living persons and public for the others.
+// Persons whose living status is not filled in are taken as living.
+func effectivePrivacy(person interfaces.Person) string {
+	switch {
+	case person.Privacy != "":
+		return person.Privacy
+	case person.Living != nil && !*person.Living:
+		return interfaces.PrivacyPublic
+	default:
+		return interfaces.PrivacyFamily
+	}
+}
+
+// redact returns the person with everything but the ID, gender and living status hidden
+func redact(person interfaces.Person) interfaces.Person {
+	firstName := "Private"
+	if person.Living != nil && *person.Living {
+		firstName = "Living"
+	}
+
+	return interfaces.Person{
+		Key:       person.Key,
+		ID:        person.ID,
+		Rev:       person.Rev,
+		FirstName: firstName,
+		Gender:    person.Gender,
+		Living:    person.Living,
+		Redacted:  true,
+		CreatedAt: person.CreatedAt,
+		UpdatedAt: person.UpdatedAt,
+	}
+}
+
+// privacyRank orders the privacy levels from the least to the most restricted
+func privacyRank(privacy string) int {
+	return slices.Index(privacyLevels, privacy)
+}
+
+// validatePrivacy validates a privacy level
+func validatePrivacy(privacy string) error {
+	if !slices.Contains(privacyLevels, privacy) {
+		return fmt.Errorf("%w: %s. Valid levels are: public, family, private", ErrInvalidPrivacy, privacy)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package v1personservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/rogerwesterbo/familytree/internal/services/v1citationservice"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// testRelationshipRepository holds the relatives found for the persons without dates
+type testRelationshipRepository struct {
+	interfaces.RelationshipRepository
+	relatives map[string][]interfaces.Relative
+}
+
+func (r *testRelationshipRepository) FindSpousesAndDescendants(_ context.Context, personIDs []string, _ int) (map[string][]interfaces.Relative, error) {
+	found := make(map[string][]interfaces.Relative)
+	for _, personID := range personIDs {
+		found[personID] = r.relatives[personID]
+	}
+	return found, nil
+}
+
+// testPersonRepository holds persons by document ID
+type testPersonRepository struct {
+	interfaces.PersonRepository
+	persons []interfaces.Person
+}
+
+func (r *testPersonRepository) FindByIDs(_ context.Context, ids []string) ([]interfaces.Person, error) {
+	var persons []interfaces.Person
+	for _, person := range r.persons {
+		if slices.Contains(ids, person.ID) {
+			persons = append(persons, person)
+		}
+	}
+	return persons, nil
+}
+
+// testCitationRepository holds a citation for every subject
+type testCitationRepository struct {
+	interfaces.CitationRepository
+}
+
+func (r *testCitationRepository) FindBySubjects(_ context.Context, subjectIDs []string) ([]interfaces.CitationReference, error) {
+	var references []interfaces.CitationReference
+	for _, subjectID := range subjectIDs {
+		references = append(references, interfaces.CitationReference{SubjectID: subjectID, Fact: "birth"})
+	}
+	return references, nil
+}
+
+// testPerson creates a person born and died on the given dates, either of which may be empty
+func testPerson(t *testing.T, name, birthDate, deathDate string) interfaces.Person {
+	t.Helper()
+	birth, err := interfaces.ParseGenealogicalDate(birthDate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	death, err := interfaces.ParseGenealogicalDate(deathDate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return interfaces.Person{ID: "persons/" + name, Key: name, FirstName: name, BirthDate: birth, DeathDate: death}
+}
+
+func TestApplyPrivacy(t *testing.T) {
+	recent := fmt.Sprint(time.Now().Year() - 30)
+
+	ancestor := testPerson(t, "ancestor", "1850", "1920")
+	living := testPerson(t, "living", recent, "")
+	undatedParent := testPerson(t, "undatedParent", "", "")
+	undated := testPerson(t, "undated", "", "")
+	private := testPerson(t, "private", "1850", "1920")
+	private.Privacy = interfaces.PrivacyPrivate
+	public := testPerson(t, "public", recent, "")
+	public.Privacy = interfaces.PrivacyPublic
+
+	relationshipRepo := &testRelationshipRepository{relatives: map[string][]interfaces.Relative{
+		undatedParent.ID: {{Person: testPerson(t, "child", recent, ""), Generation: 1}},
+	}}
+	service := NewPersonService(nil, relationshipRepo, nil, nil, nil, 100)
+	persons := []interfaces.Person{ancestor, living, undatedParent, undated, private, public}
+
+	tests := []struct {
+		access string
+		hide   bool
+		// want holds the persons shown in full, the others are redacted or hidden
+		want []string
+	}{
+		{access: interfaces.PrivacyPublic, want: []string{"ancestor", "undated", "public"}},
+		{access: interfaces.PrivacyPublic, hide: true, want: []string{"ancestor", "undated", "public"}},
+		{access: interfaces.PrivacyFamily, want: []string{"ancestor", "living", "undatedParent", "undated", "public"}},
+		{access: interfaces.PrivacyPrivate, want: []string{"ancestor", "living", "undatedParent", "undated", "private", "public"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s hide=%v", tt.access, tt.hide), func(t *testing.T) {
+			visible, err := service.ApplyPrivacy(context.Background(), slices.Clone(persons), tt.access, tt.hide)
+			if err != nil {
+				t.Fatalf("ApplyPrivacy returned error: %v", err)
+			}
+
+			wantCount := len(persons)
+			if tt.hide {
+				wantCount = len(tt.want)
+			}
+			if len(visible) != wantCount {
+				t.Fatalf("got %d persons, want %d", len(visible), wantCount)
+			}
+
+			var shown []string
+			for _, person := range visible {
+				if !person.Redacted {
+					shown = append(shown, person.FirstName)
+					continue
+				}
+				if !person.BirthDate.IsZero() || person.Privacy != "" {
+					t.Errorf("redacted person %s shows %+v", person.ID, person)
+				}
+			}
+			if !slices.Equal(shown, tt.want) {
+				t.Errorf("shown persons = %q, want %q", shown, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyPrivacyInvalidAccess(t *testing.T) {
+	service := NewPersonService(nil, &testRelationshipRepository{}, nil, nil, nil, 100)
+	_, err := service.ApplyPrivacy(context.Background(), nil, "secret", false)
+	if !errors.Is(err, ErrInvalidPrivacy) {
+		t.Errorf("ApplyPrivacy returned %v, want ErrInvalidPrivacy", err)
+	}
+}
+
+func TestRestrictedPersons(t *testing.T) {
+	living := testPerson(t, "living", fmt.Sprint(time.Now().Year()-30), "")
+	ancestor := testPerson(t, "ancestor", "1850", "1920")
+	personRepo := &testPersonRepository{persons: []interfaces.Person{living, ancestor}}
+	service := NewPersonService(personRepo, &testRelationshipRepository{}, nil, nil, nil, 100)
+
+	restricted, err := service.RestrictedPersons(context.Background(), interfaces.PrivacyPublic, []string{living.ID, ancestor.ID, "persons/missing"})
+	if err != nil {
+		t.Fatalf("RestrictedPersons returned error: %v", err)
+	}
+	if want := map[string]bool{living.ID: true}; len(restricted) != len(want) || !restricted[living.ID] {
+		t.Errorf("restricted = %v, want %v", restricted, want)
+	}
+}
+
+func TestIncludeCitationsLeavesOutRedactedPersons(t *testing.T) {
+	citationService := v1citationservice.NewCitationService(&testCitationRepository{}, nil, nil, nil, nil, nil)
+	service := NewPersonService(nil, nil, nil, citationService, nil, 100)
+	persons := []interfaces.Person{
+		{ID: "persons/ancestor"},
+		{ID: "persons/living", Redacted: true},
+	}
+
+	if err := service.IncludeCitations(context.Background(), persons); err != nil {
+		t.Fatalf("IncludeCitations returned error: %v", err)
+	}
+	if len(persons[0].Citations) != 1 {
+		t.Errorf("visible person has %d citations, want 1", len(persons[0].Citations))
+	}
+	if persons[1].Citations != nil {
+		t.Errorf("redacted person has citations %+v", persons[1].Citations)
+	}
+}
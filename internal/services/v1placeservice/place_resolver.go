@@ -0,0 +1,199 @@
+package v1placeservice
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// placeholderPrefix starts the IDs a read-only resolver gives the places it would create
+const placeholderPrefix = "places/new-"
+
+// placeTypesByScope are the place types from the largest to the smallest, assigned to the jurisdictions
+// of a place name from the right when the file does not say what they are
+var placeTypesByScope = []string{
+	interfaces.PlaceTypeCountry,
+	interfaces.PlaceTypeCounty,
+	interfaces.PlaceTypeMunicipality,
+	interfaces.PlaceTypeParish,
+	interfaces.PlaceTypeFarm,
+}
+
+// jurisdictionTypes maps the jurisdiction names used in place forms to place types
+var jurisdictionTypes = map[string]string{
+	"farm":         interfaces.PlaceTypeFarm,
+	"gård":         interfaces.PlaceTypeFarm,
+	"address":      interfaces.PlaceTypeFarm,
+	"parish":       interfaces.PlaceTypeParish,
+	"sogn":         interfaces.PlaceTypeParish,
+	"prestegjeld":  interfaces.PlaceTypeParish,
+	"city":         interfaces.PlaceTypeMunicipality,
+	"town":         interfaces.PlaceTypeMunicipality,
+	"village":      interfaces.PlaceTypeMunicipality,
+	"municipality": interfaces.PlaceTypeMunicipality,
+	"kommune":      interfaces.PlaceTypeMunicipality,
+	"county":       interfaces.PlaceTypeCounty,
+	"state":        interfaces.PlaceTypeCounty,
+	"province":     interfaces.PlaceTypeCounty,
+	"fylke":        interfaces.PlaceTypeCounty,
+	"country":      interfaces.PlaceTypeCountry,
+	"land":         interfaces.PlaceTypeCountry,
+}
+
+// PlaceResolver finds or creates the places named in imports and older records as a hierarchy of jurisdictions,
+// such as "Haugen, Aker, Akershus, Norway", reusing places that already exist under the same parent
+type PlaceResolver struct {
+	service *PlaceService
+	// form holds the place type of each jurisdiction, from the smallest, when the file declares them
+	form []string
+	// ids caches the place ID of every place name resolved so far
+	ids map[string]string
+	// created counts the places created
+	created int
+	// readOnly resolvers only look places up, giving the places they would create placeholder IDs by parent
+	// and name
+	readOnly     bool
+	placeholders map[[2]string]string
+}
+
+// NewPlaceResolver creates a place resolver for a file whose place names follow form, a comma-separated list
+// of jurisdiction names such as "Farm, Parish, County, Country", or the default hierarchy when form is empty
+func NewPlaceResolver(service *PlaceService, form string) *PlaceResolver {
+	resolver := &PlaceResolver{
+		service: service,
+		ids:     make(map[string]string),
+	}
+	if strings.TrimSpace(form) != "" {
+		for jurisdiction := range strings.SplitSeq(form, ",") {
+			resolver.form = append(resolver.form, jurisdictionTypes[strings.ToLower(strings.TrimSpace(jurisdiction))])
+		}
+	}
+	return resolver
+}
+
+// NewReadOnlyPlaceResolver creates a place resolver like NewPlaceResolver that never creates places, for dry runs.
+// Places that do not exist resolve to placeholder IDs, and Created counts the places that would be created.
+func NewReadOnlyPlaceResolver(service *PlaceService, form string) *PlaceResolver {
+	resolver := NewPlaceResolver(service, form)
+	resolver.readOnly = true
+	resolver.placeholders = make(map[[2]string]string)
+	return resolver
+}
+
+// Resolve returns the ID of the place a place name refers to, creating the places of the hierarchy that do not
+// exist yet. An empty name resolves to "".
+func (p *PlaceResolver) Resolve(ctx context.Context, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", nil
+	}
+	if id, found := p.ids[name]; found {
+		return id, nil
+	}
+
+	jurisdictions := strings.Split(name, ",")
+	types := p.types(len(jurisdictions))
+
+	// Walk down from the largest jurisdiction; empty jurisdictions, as in ", Aker, , Norway", are left out
+	parentID := ""
+	for i := len(jurisdictions) - 1; i >= 0; i-- {
+		jurisdiction := strings.TrimSpace(jurisdictions[i])
+		if jurisdiction == "" {
+			continue
+		}
+
+		id, err := p.findOrCreate(ctx, jurisdiction, types[i], parentID)
+		if err != nil {
+			return "", err
+		}
+		parentID = id
+	}
+
+	p.ids[name] = parentID
+	return parentID, nil
+}
+
+// Created returns the number of places the resolver has created, or would create when it is read-only
+func (p *PlaceResolver) Created() int {
+	return p.created
+}
+
+// types returns the place types of the jurisdictions of a place name with n jurisdictions, from the smallest
+func (p *PlaceResolver) types(n int) []string {
+	types := make([]string, n)
+	for i := range types {
+		if i < len(p.form) && p.form[i] != "" {
+			types[i] = p.form[i]
+			continue
+		}
+
+		switch scope := n - 1 - i; {
+		case n == 1:
+			// A single name is most often a town or a municipality
+			types[i] = interfaces.PlaceTypeMunicipality
+		case scope < len(placeTypesByScope):
+			types[i] = placeTypesByScope[scope]
+		default:
+			types[i] = interfaces.PlaceTypeFarm
+		}
+	}
+	return types
+}
+
+// findOrCreate returns the ID of the place with a name directly within a parent, or at the top when parentID is empty,
+// creating it when there is none
+func (p *PlaceResolver) findOrCreate(ctx context.Context, name, placeType, parentID string) (string, error) {
+	if p.readOnly && strings.HasPrefix(parentID, placeholderPrefix) {
+		// Places within a place that does not exist do not exist either
+		return p.placeholder(name, parentID), nil
+	}
+
+	var candidates []interfaces.Place
+	var err error
+	if parentID == "" {
+		candidates, err = p.service.SearchPlacesByName(ctx, name)
+	} else {
+		candidates, err = p.service.GetChildPlaces(ctx, parentID)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	index := slices.IndexFunc(candidates, func(place interfaces.Place) bool {
+		return strings.EqualFold(place.Name, name) && place.ParentID == parentID
+	})
+	if index != -1 {
+		return candidates[index].ID, nil
+	}
+	if p.readOnly {
+		return p.placeholder(name, parentID), nil
+	}
+
+	place, err := p.service.CreatePlace(ctx, &interfaces.PlaceCreateRequest{
+		Name:     name,
+		Type:     placeType,
+		ParentID: parentID,
+	})
+	if err != nil {
+		return "", err
+	}
+	p.created++
+
+	return place.ID, nil
+}
+
+// placeholder returns the placeholder ID of a place a read-only resolver would create with a name within a parent
+func (p *PlaceResolver) placeholder(name, parentID string) string {
+	key := [2]string{parentID, strings.ToLower(name)}
+	if id, found := p.placeholders[key]; found {
+		return id
+	}
+
+	p.created++
+	id := placeholderPrefix + strconv.Itoa(p.created)
+	p.placeholders[key] = id
+	return id
+}
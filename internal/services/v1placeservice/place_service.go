@@ -0,0 +1,314 @@
+package v1placeservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// MaxHierarchyDepth limits how many parent places are followed, guarding against cycles in stored data
+const MaxHierarchyDepth = 20
+
+var (
+	// ErrInvalidPlace is returned when a place fails validation
+	ErrInvalidPlace = errors.New("invalid place")
+
+	// ErrPlaceInUse is returned when deleting a place that other places, persons or events refer to
+	ErrPlaceInUse = errors.New("place is in use")
+)
+
+// PlaceService handles business logic for place operations
+type PlaceService struct {
+	repo interfaces.PlaceRepository
+}
+
+// NewPlaceService creates a new place service
+func NewPlaceService(repo interfaces.PlaceRepository) *PlaceService {
+	return &PlaceService{
+		repo: repo,
+	}
+}
+
+// CreatePlace creates a new place with validation
+func (s *PlaceService) CreatePlace(ctx context.Context, req *interfaces.PlaceCreateRequest) (*interfaces.Place, error) {
+	place := &interfaces.Place{
+		Name:           strings.TrimSpace(req.Name),
+		Type:           strings.TrimSpace(req.Type),
+		AlternateNames: trimNames(req.AlternateNames),
+		Latitude:       req.Latitude,
+		Longitude:      req.Longitude,
+	}
+	if req.ParentID != "" {
+		place.ParentID = PlaceDocumentID(strings.TrimSpace(req.ParentID))
+	}
+
+	if err := s.validatePlace(ctx, place); err != nil {
+		return nil, err
+	}
+
+	// Create in repository
+	if err := s.repo.Create(ctx, place); err != nil {
+		return nil, fmt.Errorf("failed to create place: %w", err)
+	}
+
+	return place, nil
+}
+
+// GetPlace retrieves a place by ID with its hierarchy of parent places
+func (s *PlaceService) GetPlace(ctx context.Context, id string) (*interfaces.Place, []interfaces.Place, error) {
+	if id == "" {
+		return nil, nil, fmt.Errorf("place ID is required")
+	}
+
+	place, err := s.repo.GetByID(ctx, PlaceKey(id))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hierarchy, err := s.hierarchy(ctx, place.ParentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return place, hierarchy, nil
+}
+
+// UpdatePlace updates an existing place
+func (s *PlaceService) UpdatePlace(ctx context.Context, id string, req *interfaces.PlaceUpdateRequest) (*interfaces.Place, error) {
+	if id == "" {
+		return nil, fmt.Errorf("place ID is required")
+	}
+
+	// Get existing place
+	place, err := s.repo.GetByID(ctx, PlaceKey(id))
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields if provided
+	if req.Name != "" {
+		place.Name = strings.TrimSpace(req.Name)
+	}
+	if req.Type != "" {
+		place.Type = strings.TrimSpace(req.Type)
+	}
+	if req.ParentID != "" {
+		place.ParentID = PlaceDocumentID(strings.TrimSpace(req.ParentID))
+	}
+	if req.AlternateNames != nil {
+		place.AlternateNames = trimNames(*req.AlternateNames)
+	}
+	if req.Latitude != nil {
+		place.Latitude = req.Latitude
+	}
+	if req.Longitude != nil {
+		place.Longitude = req.Longitude
+	}
+
+	if err := s.validatePlace(ctx, place); err != nil {
+		return nil, err
+	}
+
+	// Update in repository
+	if err := s.repo.Update(ctx, place.Key, place); err != nil {
+		return nil, fmt.Errorf("failed to update place: %w", err)
+	}
+
+	return place, nil
+}
+
+// DeletePlace deletes a place that nothing refers to
+func (s *PlaceService) DeletePlace(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("place ID is required")
+	}
+
+	place, err := s.repo.GetByID(ctx, PlaceKey(id))
+	if err != nil {
+		return err
+	}
+
+	referenced, err := s.repo.IsReferenced(ctx, place.ID)
+	if err != nil {
+		return err
+	}
+	if referenced {
+		return fmt.Errorf("%w: %s is the parent of another place or is referred to by a person or an event", ErrPlaceInUse, place.ID)
+	}
+
+	if err := s.repo.Delete(ctx, place.Key); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListPlaces retrieves all places
+func (s *PlaceService) ListPlaces(ctx context.Context) ([]interfaces.Place, error) {
+	places, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return places, nil
+}
+
+// SearchPlacesByName searches places by current, alternate and historical names
+func (s *PlaceService) SearchPlacesByName(ctx context.Context, name string) ([]interfaces.Place, error) {
+	places, err := s.repo.FindByName(ctx, strings.TrimSpace(name))
+	if err != nil {
+		return nil, err
+	}
+
+	return places, nil
+}
+
+// GetChildPlaces retrieves the places directly within a place
+func (s *PlaceService) GetChildPlaces(ctx context.Context, parentID string) ([]interfaces.Place, error) {
+	parent, err := s.repo.GetByID(ctx, PlaceKey(parentID))
+	if err != nil {
+		return nil, err
+	}
+
+	places, err := s.repo.FindChildren(ctx, parent.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return places, nil
+}
+
+// ValidatePlaceReference checks that a place ID refers to an existing place and returns its document ID.
+// An empty ID is valid and returned as is.
+func (s *PlaceService) ValidatePlaceReference(ctx context.Context, field, placeID string) (string, error) {
+	placeID = strings.TrimSpace(placeID)
+	if placeID == "" {
+		return "", nil
+	}
+
+	place, err := s.repo.GetByID(ctx, PlaceKey(placeID))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return "", fmt.Errorf("%w: %s %s does not exist", ErrInvalidPlace, field, PlaceDocumentID(placeID))
+		}
+		return "", err
+	}
+
+	return place.ID, nil
+}
+
+// hierarchy returns the chain of places from a parent place up to the top
+func (s *PlaceService) hierarchy(ctx context.Context, parentID string) ([]interfaces.Place, error) {
+	hierarchy := []interfaces.Place{}
+	for parentID != "" && len(hierarchy) < MaxHierarchyDepth {
+		parent, err := s.repo.GetByID(ctx, PlaceKey(parentID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent place %s: %w", parentID, err)
+		}
+		hierarchy = append(hierarchy, *parent)
+		parentID = parent.ParentID
+	}
+	return hierarchy, nil
+}
+
+// validatePlace validates the fields of a place and that its parent exists without making a cycle
+func (s *PlaceService) validatePlace(ctx context.Context, place *interfaces.Place) error {
+	if place.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidPlace)
+	}
+	if err := validateType(place.Type); err != nil {
+		return err
+	}
+	if (place.Latitude == nil) != (place.Longitude == nil) {
+		return fmt.Errorf("%w: latitude and longitude must be given together", ErrInvalidPlace)
+	}
+	if place.Latitude != nil && (*place.Latitude < -90 || *place.Latitude > 90) {
+		return fmt.Errorf("%w: latitude must be between -90 and 90", ErrInvalidPlace)
+	}
+	if place.Longitude != nil && (*place.Longitude < -180 || *place.Longitude > 180) {
+		return fmt.Errorf("%w: longitude must be between -180 and 180", ErrInvalidPlace)
+	}
+	for _, name := range place.AlternateNames {
+		if name.Name == "" {
+			return fmt.Errorf("%w: alternate names must have a name", ErrInvalidPlace)
+		}
+		if name.ValidTo.DefinitelyBefore(name.ValidFrom) {
+			return fmt.Errorf("%w: alternate name %s must not be valid to %s before it is valid from %s", ErrInvalidPlace, name.Name, name.ValidTo, name.ValidFrom)
+		}
+	}
+
+	if place.ParentID == "" {
+		return nil
+	}
+	if place.ParentID == place.ID {
+		return fmt.Errorf("%w: a place cannot be its own parent", ErrInvalidPlace)
+	}
+
+	parentID := place.ParentID
+	for depth := 0; parentID != ""; depth++ {
+		if depth == MaxHierarchyDepth {
+			return fmt.Errorf("%w: the place hierarchy is deeper than %d levels", ErrInvalidPlace, MaxHierarchyDepth)
+		}
+
+		parent, err := s.repo.GetByID(ctx, PlaceKey(parentID))
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				return fmt.Errorf("%w: parent place %s does not exist", ErrInvalidPlace, parentID)
+			}
+			return err
+		}
+		if place.ID != "" && parent.ParentID == place.ID {
+			return fmt.Errorf("%w: %s is within %s and cannot also be its parent", ErrInvalidPlace, place.ParentID, place.ID)
+		}
+		parentID = parent.ParentID
+	}
+
+	return nil
+}
+
+// validateType validates a place type
+func validateType(placeType string) error {
+	validTypes := map[string]bool{
+		interfaces.PlaceTypeFarm:         true,
+		interfaces.PlaceTypeParish:       true,
+		interfaces.PlaceTypeMunicipality: true,
+		interfaces.PlaceTypeCounty:       true,
+		interfaces.PlaceTypeCountry:      true,
+	}
+
+	if placeType == "" {
+		return fmt.Errorf("%w: type is required", ErrInvalidPlace)
+	}
+	if !validTypes[placeType] {
+		return fmt.Errorf("%w: invalid place type: %s. Valid types are: farm, parish, municipality, county, country", ErrInvalidPlace, placeType)
+	}
+
+	return nil
+}
+
+// trimNames trims the names and languages of alternate place names
+func trimNames(names []interfaces.PlaceName) []interfaces.PlaceName {
+	trimmed := make([]interfaces.PlaceName, 0, len(names))
+	for _, name := range names {
+		name.Name = strings.TrimSpace(name.Name)
+		name.Language = strings.TrimSpace(name.Language)
+		trimmed = append(trimmed, name)
+	}
+	return trimmed
+}
+
+// PlaceDocumentID returns the document ID (places/<key>) for a place key or ID
+func PlaceDocumentID(placeID string) string {
+	if strings.Contains(placeID, "/") {
+		return placeID
+	}
+	return "places/" + placeID
+}
+
+// PlaceKey returns the document key for a place key or ID
+func PlaceKey(placeID string) string {
+	return strings.TrimPrefix(placeID, "places/")
+}
@@ -0,0 +1,106 @@
+package v1relationshipservice
+
+import (
+	"slices"
+
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// buildAhnentafel assigns Sosa-Stradonitz numbers to the root and their ancestors, up to the given number of generations.
+// Fathers get 2n and mothers 2n+1; a parent of unknown gender takes whichever slot is free, and biological parents
+// are preferred over adoptive, step, foster and guardian parents.
+// Missing parents of known persons are listed as missing slots, while slots above a missing person are left out.
+func buildAhnentafel(root *interfaces.Person, links []interfaces.AncestorLink, generations int) []interfaces.AhnentafelEntry {
+	ordered := slices.Clone(links)
+	slices.SortStableFunc(ordered, func(a, b interfaces.AncestorLink) int {
+		return biologicalRank(a.Relationship) - biologicalRank(b.Relationship)
+	})
+
+	parents := make(map[string][]interfaces.Person)
+	seen := make(map[[2]string]bool)
+	for _, link := range ordered {
+		pair := [2]string{link.ChildID, link.Person.ID}
+		if seen[pair] {
+			continue
+		}
+		seen[pair] = true
+		parents[link.ChildID] = append(parents[link.ChildID], link.Person)
+	}
+
+	type slot struct {
+		number     int
+		generation int
+		person     *interfaces.Person
+	}
+
+	var entries []interfaces.AhnentafelEntry
+	firstNumber := make(map[string]int)
+	queue := []slot{{number: 1, generation: 1, person: root}}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		entry := interfaces.AhnentafelEntry{
+			Number:     current.number,
+			Generation: current.generation,
+			Person:     current.person,
+			Missing:    current.person == nil,
+		}
+		if current.person != nil {
+			if number, found := firstNumber[current.person.ID]; found {
+				entry.SameAs = number
+			} else {
+				firstNumber[current.person.ID] = current.number
+			}
+		}
+		entries = append(entries, entry)
+
+		if current.person == nil || current.generation >= generations {
+			continue
+		}
+
+		father, mother := splitParents(parents[current.person.ID])
+		queue = append(queue,
+			slot{number: 2 * current.number, generation: current.generation + 1, person: father},
+			slot{number: 2*current.number + 1, generation: current.generation + 1, person: mother},
+		)
+	}
+
+	return entries
+}
+
+// splitParents picks the father and mother among the recorded parents of a person
+func splitParents(parents []interfaces.Person) (father, mother *interfaces.Person) {
+	var unknown []*interfaces.Person
+	for i := range parents {
+		parent := &parents[i]
+		switch {
+		case isMale(parent.Gender) && father == nil:
+			father = parent
+		case isFemale(parent.Gender) && mother == nil:
+			mother = parent
+		case !isMale(parent.Gender) && !isFemale(parent.Gender):
+			unknown = append(unknown, parent)
+		}
+	}
+
+	for _, parent := range unknown {
+		switch {
+		case father == nil:
+			father = parent
+		case mother == nil:
+			mother = parent
+		}
+	}
+
+	return father, mother
+}
+
+// biologicalRank orders biological parent relationships before the others
+func biologicalRank(relationship interfaces.Relationship) int {
+	if relationship.ParentQualifier() == interfaces.RelationQualifierBiological {
+		return 0
+	}
+	return 1
+}
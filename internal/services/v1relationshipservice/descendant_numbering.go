@@ -0,0 +1,60 @@
+package v1relationshipservice
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// NumberDescendants labels the root and every descendant in a tree, walking children in birth order.
+// d'Aboville numbers separate generations with dots (1.2.3). Henry numbers append one symbol per
+// generation: 1-9 for the first nine children, X for the tenth and A, B, C... after that.
+// A descendant reached through two parents gets a number under each of them.
+func NumberDescendants(tree *interfaces.DescendantNode, system string) ([]interfaces.NumberedDescendant, error) {
+	var childNumber func(parent string, index int) string
+	switch system {
+	case interfaces.NumberingDAboville:
+		childNumber = func(parent string, index int) string {
+			return parent + "." + strconv.Itoa(index)
+		}
+	case interfaces.NumberingHenry:
+		childNumber = func(parent string, index int) string {
+			return parent + henrySymbol(index)
+		}
+	default:
+		return nil, fmt.Errorf("invalid numbering system: %s. Valid systems are: %s, %s", system, interfaces.NumberingDAboville, interfaces.NumberingHenry)
+	}
+
+	var numbered []interfaces.NumberedDescendant
+	var walk func(node *interfaces.DescendantNode, number, parentNumber string)
+	walk = func(node *interfaces.DescendantNode, number, parentNumber string) {
+		numbered = append(numbered, interfaces.NumberedDescendant{
+			Number:       number,
+			ParentNumber: parentNumber,
+			Generation:   node.Generation,
+			Person:       node.Person,
+		})
+		for i := range node.Children {
+			walk(&node.Children[i], childNumber(number, i+1), number)
+		}
+	}
+	walk(tree, "1", "")
+
+	return numbered, nil
+}
+
+// henrySymbol returns the Henry symbol for the n-th child, counting from 1
+func henrySymbol(n int) string {
+	switch {
+	case n <= 9:
+		return strconv.Itoa(n)
+	case n == 10:
+		return "X"
+	case n <= 36:
+		return string(rune('A' + n - 11))
+	default:
+		// Beyond the alphabet, fall back to the bracketed form of the modified Henry system
+		return "(" + strconv.Itoa(n) + ")"
+	}
+}
@@ -0,0 +1,100 @@
+package v1relationshipservice
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+func TestHenrySymbol(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{n: 1, want: "1"},
+		{n: 9, want: "9"},
+		{n: 10, want: "X"},
+		{n: 11, want: "A"},
+		{n: 12, want: "B"},
+		{n: 36, want: "Z"},
+		{n: 37, want: "(37)"},
+		{n: 100, want: "(100)"},
+	}
+
+	for _, tt := range tests {
+		if got := henrySymbol(tt.n); got != tt.want {
+			t.Errorf("henrySymbol(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+// testDescendants returns a root with eleven children, the first of whom has two children
+func testDescendants() *interfaces.DescendantNode {
+	root := &interfaces.DescendantNode{Person: interfaces.Person{FirstName: "root"}}
+	for i := range 11 {
+		child := interfaces.DescendantNode{Person: interfaces.Person{FirstName: "child" + henrySymbol(i+1)}, Generation: 1}
+		if i == 0 {
+			child.Children = []interfaces.DescendantNode{
+				{Person: interfaces.Person{FirstName: "grandchild1"}, Generation: 2},
+				{Person: interfaces.Person{FirstName: "grandchild2"}, Generation: 2},
+			}
+		}
+		root.Children = append(root.Children, child)
+	}
+	return root
+}
+
+func TestNumberDescendants(t *testing.T) {
+	tests := []struct {
+		system string
+		want   map[string]string
+	}{
+		{
+			system: interfaces.NumberingDAboville,
+			want: map[string]string{
+				"root": "1", "child1": "1.1", "grandchild1": "1.1.1", "grandchild2": "1.1.2",
+				"child2": "1.2", "childX": "1.10", "childA": "1.11",
+			},
+		},
+		{
+			system: interfaces.NumberingHenry,
+			want: map[string]string{
+				"root": "1", "child1": "11", "grandchild1": "111", "grandchild2": "112",
+				"child2": "12", "childX": "1X", "childA": "1A",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.system, func(t *testing.T) {
+			numbered, err := NumberDescendants(testDescendants(), tt.system)
+			if err != nil {
+				t.Fatalf("NumberDescendants returned error: %v", err)
+			}
+			if len(numbered) != 14 {
+				t.Errorf("got %d numbered descendants, want 14", len(numbered))
+			}
+
+			numbers := make(map[string]interfaces.NumberedDescendant)
+			for _, descendant := range numbered {
+				numbers[descendant.Person.FirstName] = descendant
+			}
+			for name, want := range tt.want {
+				if got := numbers[name].Number; got != want {
+					t.Errorf("%s is numbered %q, want %q", name, got, want)
+				}
+			}
+			if got := numbers["grandchild2"].ParentNumber; got != numbers["child1"].Number {
+				t.Errorf("grandchild2 has parent number %q, want %q", got, numbers["child1"].Number)
+			}
+		})
+	}
+}
+
+func TestNumberDescendantsInvalidSystem(t *testing.T) {
+	_, err := NumberDescendants(testDescendants(), "roman")
+	if err == nil || !strings.Contains(err.Error(), "invalid numbering system") {
+		t.Errorf("NumberDescendants returned %v, want an invalid numbering system error", err)
+	}
+}
@@ -0,0 +1,82 @@
+package v1relationshipservice
+
+import (
+	"sort"
+
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// buildDescendantTree nests the flat result of a descendant traversal under the root person.
+// It returns the tree and the number of distinct descendants in it.
+func buildDescendantTree(root *interfaces.Person, graph *interfaces.DescendantGraph, maxDepth int) (*interfaces.DescendantNode, int) {
+	// Group children by parent, ignoring duplicate parent/child edges
+	children := make(map[string][]interfaces.Person)
+	seenLinks := make(map[[2]string]bool)
+	for _, link := range graph.Links {
+		pair := [2]string{link.ParentID, link.Person.ID}
+		if seenLinks[pair] {
+			continue
+		}
+		seenLinks[pair] = true
+		children[link.ParentID] = append(children[link.ParentID], link.Person)
+	}
+	for parentID := range children {
+		sortByBirth(children[parentID])
+	}
+
+	// Group spouses by person, ignoring duplicate spouse edges
+	spouses := make(map[string][]interfaces.Spouse)
+	seenSpouses := make(map[[2]string]bool)
+	for _, link := range graph.Spouses {
+		pair := [2]string{link.PersonID, link.Spouse.ID}
+		if seenSpouses[pair] {
+			continue
+		}
+		seenSpouses[pair] = true
+		spouses[link.PersonID] = append(spouses[link.PersonID], interfaces.Spouse{
+			Person:       link.Spouse,
+			Relationship: link.Relationship,
+		})
+	}
+
+	descendants := make(map[string]bool)
+	onPath := make(map[string]bool)
+
+	var build func(person interfaces.Person, generation int) interfaces.DescendantNode
+	build = func(person interfaces.Person, generation int) interfaces.DescendantNode {
+		node := interfaces.DescendantNode{
+			Person:     person,
+			Generation: generation,
+			Spouses:    spouses[person.ID],
+		}
+		if generation >= maxDepth {
+			return node
+		}
+
+		onPath[person.ID] = true
+		for _, child := range children[person.ID] {
+			// Guard against cycles in inconsistent data
+			if onPath[child.ID] {
+				continue
+			}
+			descendants[child.ID] = true
+			node.Children = append(node.Children, build(child, generation+1))
+		}
+		onPath[person.ID] = false
+
+		return node
+	}
+
+	tree := build(*root, 0)
+	return &tree, len(descendants)
+}
+
+// sortByBirth orders persons by birth date, then by first name
+func sortByBirth(persons []interfaces.Person) {
+	sort.SliceStable(persons, func(i, j int) bool {
+		if c := persons[i].BirthDate.Compare(persons[j].BirthDate); c != 0 {
+			return c < 0
+		}
+		return persons[i].FirstName < persons[j].FirstName
+	})
+}
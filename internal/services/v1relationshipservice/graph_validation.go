@@ -0,0 +1,110 @@
+package v1relationshipservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/internal/services/v1personservice"
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// ErrConflictingRelationship is returned when a relationship contradicts the existing family graph
+var ErrConflictingRelationship = errors.New("conflicting relationship")
+
+// maxAncestryCheckDepth is the number of generations searched for ancestry cycles. The search follows every
+// path up the tree, so that no cycle is missed, and is kept well below MaxTraversalDepth.
+const maxAncestryCheckDepth = 16
+
+// validateGraph checks a relationship against the existing family graph and rejects relationships to persons
+// that do not exist, ancestry cycles and contradictory edge pairs. The relationship with excludeID, the one
+// being updated, is left out.
+func (s *RelationshipService) validateGraph(ctx context.Context, relationship *interfaces.Relationship, excludeID string) error {
+	from := v1personservice.PersonDocumentID(relationship.From)
+	to := v1personservice.PersonDocumentID(relationship.To)
+
+	for _, personID := range []string{from, to} {
+		if _, err := s.personRepo.GetByID(ctx, v1personservice.PersonKey(personID)); err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				return fmt.Errorf("%w: person %s does not exist", ErrInvalidRelationship, personID)
+			}
+			return fmt.Errorf("failed to check person %s: %w", personID, err)
+		}
+	}
+
+	// Edges between the same two persons must not contradict each other
+	existing, err := s.repo.FindByPerson(ctx, from)
+	if err != nil {
+		return fmt.Errorf("failed to check existing relationships: %w", err)
+	}
+	for _, other := range existing {
+		if other.ID == excludeID || !connects(other, from, to) {
+			continue
+		}
+		if contradicts(relationship.RelationType, other.RelationType) {
+			return fmt.Errorf("%w: %s cannot be %s of %s, they are already connected by %s",
+				ErrConflictingRelationship, from, relationship.RelationType, to, formatPath([]interfaces.Relationship{other}))
+		}
+	}
+
+	switch relationship.RelationType {
+	case interfaces.RelationTypeParent, interfaces.RelationTypeChild:
+		// A parent must not already be a descendant of their child
+		parentID, childID := from, to
+		if relationship.RelationType == interfaces.RelationTypeChild {
+			parentID, childID = to, from
+		}
+
+		path, err := s.repo.FindAncestorPath(ctx, parentID, childID, maxAncestryCheckDepth, excludeID)
+		if err != nil {
+			return fmt.Errorf("failed to check ancestry: %w", err)
+		}
+		if len(path) > 0 {
+			return fmt.Errorf("%w: %s cannot be a parent of %s, %s is already an ancestor of %s through %s",
+				ErrConflictingRelationship, parentID, childID, childID, parentID, formatPath(path))
+		}
+	case interfaces.RelationTypeSibling:
+		// Siblings must not be each other's ancestors
+		for _, pair := range [][2]string{{from, to}, {to, from}} {
+			path, err := s.repo.FindAncestorPath(ctx, pair[0], pair[1], maxAncestryCheckDepth, excludeID)
+			if err != nil {
+				return fmt.Errorf("failed to check ancestry: %w", err)
+			}
+			if len(path) > 0 {
+				return fmt.Errorf("%w: %s and %s cannot be siblings, %s is an ancestor of %s through %s",
+					ErrConflictingRelationship, from, to, pair[1], pair[0], formatPath(path))
+			}
+		}
+	}
+
+	return nil
+}
+
+// connects reports whether a relationship connects the two persons, in either direction
+func connects(relationship interfaces.Relationship, personA, personB string) bool {
+	return (relationship.From == personA && relationship.To == personB) ||
+		(relationship.From == personB && relationship.To == personA)
+}
+
+// contradicts reports whether two relationship types can't both hold between the same two persons.
+// Opposing parent edges are left to the ancestry check, which also reports longer cycles.
+func contradicts(typeA, typeB string) bool {
+	isParent := func(relationType string) bool {
+		return relationType == interfaces.RelationTypeParent || relationType == interfaces.RelationTypeChild
+	}
+	isPeer := func(relationType string) bool {
+		return relationType == interfaces.RelationTypeSpouse || relationType == interfaces.RelationTypeSibling
+	}
+
+	return (isParent(typeA) && isPeer(typeB)) || (isPeer(typeA) && isParent(typeB))
+}
+
+// formatPath renders relationships as a readable chain, e.g. "persons/1 -[parent]-> persons/2"
+func formatPath(path []interfaces.Relationship) string {
+	steps := make([]string, 0, len(path))
+	for _, rel := range path {
+		steps = append(steps, fmt.Sprintf("%s -[%s]-> %s", rel.From, rel.RelationType, rel.To))
+	}
+	return strings.Join(steps, ", ")
+}
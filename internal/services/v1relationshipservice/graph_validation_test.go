@@ -0,0 +1,219 @@
+package v1relationshipservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// testRelationshipRepository holds the edges of a family graph in memory
+type testRelationshipRepository struct {
+	interfaces.RelationshipRepository
+	relationships []interfaces.Relationship
+	// err is returned by every query when set
+	err error
+}
+
+func (r *testRelationshipRepository) FindByPerson(_ context.Context, personID string) ([]interfaces.Relationship, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	var found []interfaces.Relationship
+	for _, rel := range r.relationships {
+		if rel.From == personID || rel.To == personID {
+			found = append(found, rel)
+		}
+	}
+	return found, nil
+}
+
+// FindAncestorPath walks the parent steps breadth first, like the traversal it stands in for
+func (r *testRelationshipRepository) FindAncestorPath(_ context.Context, personID, ancestorID string, maxDepth int, excludeID string) ([]interfaces.Relationship, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	paths := map[string][]interfaces.Relationship{personID: nil}
+	generation := []string{personID}
+	for depth := 0; depth < maxDepth && len(generation) > 0; depth++ {
+		var next []string
+		for _, childID := range generation {
+			for _, rel := range r.relationships {
+				parentID := ""
+				switch {
+				case rel.ID == excludeID:
+				case rel.RelationType == interfaces.RelationTypeParent && rel.To == childID:
+					parentID = rel.From
+				case rel.RelationType == interfaces.RelationTypeChild && rel.From == childID:
+					parentID = rel.To
+				}
+				if _, seen := paths[parentID]; parentID == "" || seen {
+					continue
+				}
+				paths[parentID] = append(append([]interfaces.Relationship(nil), paths[childID]...), rel)
+				if parentID == ancestorID {
+					return paths[parentID], nil
+				}
+				next = append(next, parentID)
+			}
+		}
+		generation = next
+	}
+	return nil, nil
+}
+
+// testPersonRepository knows which persons exist
+type testPersonRepository struct {
+	interfaces.PersonRepository
+	keys map[string]bool
+}
+
+func (r *testPersonRepository) GetByID(_ context.Context, key string) (*interfaces.Person, error) {
+	if !r.keys[key] {
+		return nil, errors.New("entity not found: document not found")
+	}
+	return &interfaces.Person{Key: key, ID: "persons/" + key}, nil
+}
+
+// newTestService creates a relationship service over a graph of the persons 1 to 9 with the relationships
+func newTestService(relationships ...interfaces.Relationship) (*RelationshipService, *testRelationshipRepository) {
+	persons := &testPersonRepository{keys: map[string]bool{}}
+	for i := 1; i <= 9; i++ {
+		persons.keys[fmt.Sprint(i)] = true
+	}
+	for i := range relationships {
+		relationships[i].ID = fmt.Sprintf("relationships/%d", i+1)
+	}
+	repo := &testRelationshipRepository{relationships: relationships}
+	return NewRelationshipService(repo, persons), repo
+}
+
+// edge creates a relationship between two persons of the test graph
+func edge(from, relationType, to string) interfaces.Relationship {
+	return interfaces.Relationship{From: "persons/" + from, To: "persons/" + to, RelationType: relationType}
+}
+
+func TestValidateGraph(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []interfaces.Relationship
+		new      interfaces.Relationship
+		// excludeID is the relationship being updated
+		excludeID string
+		wantErr   error
+	}{
+		{
+			name:     "parent of an unrelated person",
+			existing: []interfaces.Relationship{edge("1", "parent", "2")},
+			new:      edge("3", "parent", "4"),
+		},
+		{
+			name:     "second parent",
+			existing: []interfaces.Relationship{edge("1", "parent", "3"), edge("1", "spouse", "2")},
+			new:      edge("2", "parent", "3"),
+		},
+		{
+			name:     "person who does not exist",
+			existing: nil,
+			new:      edge("1", "parent", "99"),
+			wantErr:  ErrInvalidRelationship,
+		},
+		{
+			name:     "spouse who is already a parent",
+			existing: []interfaces.Relationship{edge("1", "parent", "2")},
+			new:      edge("2", "spouse", "1"),
+			wantErr:  ErrConflictingRelationship,
+		},
+		{
+			name:     "parent who is already a sibling",
+			existing: []interfaces.Relationship{edge("1", "sibling", "2")},
+			new:      edge("1", "parent", "2"),
+			wantErr:  ErrConflictingRelationship,
+		},
+		{
+			name:     "child of their own child",
+			existing: []interfaces.Relationship{edge("1", "parent", "2")},
+			new:      edge("2", "parent", "1"),
+			wantErr:  ErrConflictingRelationship,
+		},
+		{
+			name:     "parent of their grandparent through child edges",
+			existing: []interfaces.Relationship{edge("2", "child", "1"), edge("3", "child", "2")},
+			new:      edge("3", "parent", "1"),
+			wantErr:  ErrConflictingRelationship,
+		},
+		{
+			name:     "child edge closing a cycle",
+			existing: []interfaces.Relationship{edge("1", "parent", "2"), edge("2", "parent", "3")},
+			new:      edge("1", "child", "3"),
+			wantErr:  ErrConflictingRelationship,
+		},
+		{
+			name:     "siblings where one is the other's grandparent",
+			existing: []interfaces.Relationship{edge("1", "parent", "2"), edge("2", "parent", "3")},
+			new:      edge("3", "sibling", "1"),
+			wantErr:  ErrConflictingRelationship,
+		},
+		{
+			name:     "cousins who married",
+			existing: []interfaces.Relationship{edge("1", "parent", "2"), edge("1", "parent", "3"), edge("2", "parent", "4"), edge("3", "parent", "5")},
+			new:      edge("4", "spouse", "5"),
+		},
+		{
+			name:      "update reversing the edge that made the cycle",
+			existing:  []interfaces.Relationship{edge("1", "parent", "2")},
+			new:       interfaces.Relationship{ID: "relationships/1", From: "persons/2", To: "persons/1", RelationType: "parent"},
+			excludeID: "relationships/1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newTestService(tt.existing...)
+			err := service.validateGraph(context.Background(), &tt.new, tt.excludeID)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("validateGraph returned error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateGraph returned %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateRelationshipErrors(t *testing.T) {
+	failure := errors.New("connection refused")
+
+	tests := []struct {
+		name    string
+		req     interfaces.RelationshipCreateRequest
+		repoErr error
+		wantErr error
+	}{
+		{name: "missing from", req: interfaces.RelationshipCreateRequest{To: "persons/2", RelationType: "parent"}, wantErr: ErrInvalidRelationship},
+		{name: "unknown type", req: interfaces.RelationshipCreateRequest{From: "persons/1", To: "persons/2", RelationType: "cousin"}, wantErr: ErrInvalidRelationship},
+		{name: "same person", req: interfaces.RelationshipCreateRequest{From: "persons/1", To: "persons/1", RelationType: "sibling"}, wantErr: ErrInvalidRelationship},
+		{name: "unknown qualifier", req: interfaces.RelationshipCreateRequest{From: "persons/1", To: "persons/2", RelationType: "parent", Qualifier: "distant"}, wantErr: ErrInvalidQualifier},
+		{name: "repository failure", req: interfaces.RelationshipCreateRequest{From: "persons/1", To: "persons/2", RelationType: "parent"}, repoErr: failure, wantErr: failure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo := newTestService()
+			repo.err = tt.repoErr
+			_, err := service.CreateRelationship(context.Background(), &tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateRelationship returned %v, want %v", err, tt.wantErr)
+			}
+			// Only validation errors are the client's fault
+			if tt.repoErr != nil && (errors.Is(err, ErrInvalidRelationship) || errors.Is(err, ErrConflictingRelationship)) {
+				t.Errorf("repository failure %v is reported as a validation error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package v1relationshipservice
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// lineage holds a person and their ancestors keyed by document ID
+type lineage struct {
+	personID    string
+	persons     map[string]interfaces.Person
+	generations map[string]int
+	// paths holds the person IDs walked from the person up to each ancestor
+	paths map[string][]string
+	// parents holds the parents seen for each person on the walked paths
+	parents map[string]map[string]bool
+}
+
+// bloodRelation is the nearest common ancestry of two lineages
+type bloodRelation struct {
+	up              int
+	down            int
+	half            bool
+	commonAncestors []string
+}
+
+// newLineage builds a lineage from a person and the result of an ancestor traversal
+func newLineage(person *interfaces.Person, ancestors []interfaces.Ancestor) *lineage {
+	l := &lineage{
+		personID:    person.ID,
+		persons:     map[string]interfaces.Person{person.ID: *person},
+		generations: map[string]int{person.ID: 0},
+		paths:       map[string][]string{person.ID: {person.ID}},
+		parents:     make(map[string]map[string]bool),
+	}
+
+	for _, ancestor := range ancestors {
+		vertices := []string{person.ID}
+		current := person.ID
+		for _, edge := range ancestor.Path {
+			if edge.From == current {
+				current = edge.To
+			} else {
+				current = edge.From
+			}
+			vertices = append(vertices, current)
+		}
+
+		l.persons[ancestor.Person.ID] = ancestor.Person
+		l.generations[ancestor.Person.ID] = ancestor.Generation
+		l.paths[ancestor.Person.ID] = vertices
+
+		child := vertices[len(vertices)-2]
+		if l.parents[child] == nil {
+			l.parents[child] = make(map[string]bool)
+		}
+		l.parents[child][ancestor.Person.ID] = true
+	}
+
+	return l
+}
+
+// findBloodRelation finds the nearest common ancestors of two lineages.
+// It returns false when the lineages share no ancestor.
+func findBloodRelation(a, b *lineage) (*bloodRelation, bool) {
+	var best *bloodRelation
+	for id, up := range a.generations {
+		down, ok := b.generations[id]
+		if !ok {
+			continue
+		}
+		if best == nil || closer(up, down, best.up, best.down) {
+			best = &bloodRelation{up: up, down: down}
+		}
+	}
+	if best == nil {
+		return nil, false
+	}
+
+	for id, up := range a.generations {
+		if down, ok := b.generations[id]; ok && up == best.up && down == best.down {
+			best.commonAncestors = append(best.commonAncestors, id)
+		}
+	}
+	sort.Strings(best.commonAncestors)
+
+	// Half relations share one parent on the branching generation while both branches have two known parents
+	if best.up > 0 && best.down > 0 {
+		ancestorID := best.commonAncestors[0]
+		branchA := a.paths[ancestorID][best.up-1]
+		branchB := b.paths[ancestorID][best.down-1]
+		parentsA, parentsB := a.parents[branchA], b.parents[branchB]
+
+		shared := 0
+		for id := range parentsA {
+			if parentsB[id] {
+				shared++
+			}
+		}
+		best.half = len(parentsA) >= 2 && len(parentsB) >= 2 && shared == 1
+	}
+
+	return best, true
+}
+
+// closer reports whether a common ancestor at up/down is nearer than one at bestUp/bestDown
+func closer(up, down, bestUp, bestDown int) bool {
+	if up+down != bestUp+bestDown {
+		return up+down < bestUp+bestDown
+	}
+	if abs(up-down) != abs(bestUp-bestDown) {
+		return abs(up-down) < abs(bestUp-bestDown)
+	}
+	return up < bestUp
+}
+
+// kinshipTerm names the relation of a person who is down generations below a common ancestor
+// that is up generations above the reference person, e.g. up=3, down=4 is "second cousin once removed"
+func kinshipTerm(up, down int, half bool, gender string) string {
+	halfPrefix := ""
+	if half {
+		halfPrefix = "half-"
+	}
+
+	switch {
+	case up == 0 && down == 0:
+		return "self"
+	case up == 0:
+		return grandPrefix(down-1) + gendered(gender, "son", "daughter", "child")
+	case down == 0:
+		return grandPrefix(up-1) + gendered(gender, "father", "mother", "parent")
+	case up == 1 && down == 1:
+		return halfPrefix + gendered(gender, "brother", "sister", "sibling")
+	case up == 1:
+		if !isMale(gender) && !isFemale(gender) {
+			return kinshipTerm(up, down, half, "male") + " or " + kinshipTerm(up, down, half, "female")
+		}
+		return halfPrefix + grandPrefix(down-2) + gendered(gender, "nephew", "niece", "")
+	case down == 1:
+		if !isMale(gender) && !isFemale(gender) {
+			return kinshipTerm(up, down, half, "male") + " or " + kinshipTerm(up, down, half, "female")
+		}
+		return halfPrefix + grandPrefix(up-2) + gendered(gender, "uncle", "aunt", "")
+	}
+
+	degree := min(up, down) - 1
+	removed := abs(up - down)
+
+	term := ordinal(degree) + " cousin"
+	if removed > 0 {
+		term += " " + timesRemoved(removed) + " removed"
+	}
+	if half {
+		term = "half " + term
+	}
+
+	return term
+}
+
+// inLawTerm names the relation of B when B is a blood relative of A's spouse.
+// The blood relation is measured from the spouse to B.
+func inLawTerm(relation *bloodRelation, gender string) string {
+	term := kinshipTerm(relation.up, relation.down, relation.half, gender)
+	if relation.up == 0 {
+		// Descendants of a spouse are step-relatives, e.g. stepson or step-granddaughter
+		if relation.down == 1 {
+			return "step" + term
+		}
+		return "step-" + term
+	}
+	return term + "-in-law"
+}
+
+// spouseOfRelativeTerm names the relation of B when B is the spouse of A's blood relative.
+// The blood relation is measured from A to the relative, and the term uses B's gender.
+func spouseOfRelativeTerm(relation *bloodRelation, gender string) string {
+	switch {
+	case relation.down == 0:
+		// Spouses of ancestors are step-relatives, e.g. stepmother
+		if relation.up == 1 {
+			return "step" + kinshipTerm(relation.up, relation.down, false, gender)
+		}
+		return "step-" + kinshipTerm(relation.up, relation.down, false, gender)
+	case relation.up == 0 || (relation.up == 1 && relation.down == 1):
+		// Spouses of descendants and siblings are in-laws, e.g. son-in-law or sister-in-law
+		return kinshipTerm(relation.up, relation.down, false, gender) + "-in-law"
+	default:
+		return kinshipTerm(relation.up, relation.down, relation.half, gender) + " by marriage"
+	}
+}
+
+// grandPrefix returns the prefix for a relation n generations beyond the nearest one,
+// e.g. "" for 0, "grand" for 1 and "great-grand" for 2
+func grandPrefix(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strings.Repeat("great-", n-1) + "grand"
+}
+
+// gendered picks the term matching a gender, falling back to the neutral term
+func gendered(gender, male, female, neutral string) string {
+	switch {
+	case isMale(gender):
+		return male
+	case isFemale(gender):
+		return female
+	default:
+		return neutral
+	}
+}
+
+// isMale reports whether a gender value denotes a male
+func isMale(gender string) bool {
+	g := strings.ToLower(strings.TrimSpace(gender))
+	return g == "male" || g == "m"
+}
+
+// isFemale reports whether a gender value denotes a female
+func isFemale(gender string) bool {
+	g := strings.ToLower(strings.TrimSpace(gender))
+	return g == "female" || g == "f"
+}
+
+// ordinal returns the ordinal word for a cousin degree
+func ordinal(n int) string {
+	words := []string{"", "first", "second", "third", "fourth", "fifth", "sixth", "seventh", "eighth", "ninth", "tenth"}
+	if n < len(words) {
+		return words[n]
+	}
+
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+// timesRemoved returns the word for the number of generations two cousins are removed
+func timesRemoved(n int) string {
+	switch n {
+	case 1:
+		return "once"
+	case 2:
+		return "twice"
+	case 3:
+		return "thrice"
+	default:
+		return fmt.Sprintf("%d times", n)
+	}
+}
+
+// abs returns the absolute value of n
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
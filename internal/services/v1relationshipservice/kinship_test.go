@@ -0,0 +1,189 @@
+package v1relationshipservice
+
+import (
+	"testing"
+
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+func TestKinshipTerm(t *testing.T) {
+	tests := []struct {
+		up     int
+		down   int
+		half   bool
+		gender string
+		want   string
+	}{
+		{up: 0, down: 0, want: "self"},
+		{up: 1, down: 0, gender: "male", want: "father"},
+		{up: 1, down: 0, gender: "F", want: "mother"},
+		{up: 1, down: 0, want: "parent"},
+		{up: 2, down: 0, gender: "female", want: "grandmother"},
+		{up: 4, down: 0, gender: "male", want: "great-great-grandfather"},
+		{up: 0, down: 1, gender: "male", want: "son"},
+		{up: 0, down: 3, gender: "female", want: "great-granddaughter"},
+		{up: 0, down: 2, want: "grandchild"},
+		{up: 1, down: 1, gender: "male", want: "brother"},
+		{up: 1, down: 1, half: true, gender: "female", want: "half-sister"},
+		{up: 1, down: 1, want: "sibling"},
+		{up: 1, down: 2, gender: "male", want: "nephew"},
+		{up: 1, down: 3, gender: "female", want: "grandniece"},
+		{up: 1, down: 2, half: true, gender: "female", want: "half-niece"},
+		{up: 1, down: 2, want: "nephew or niece"},
+		{up: 2, down: 1, gender: "male", want: "uncle"},
+		{up: 3, down: 1, gender: "female", want: "grandaunt"},
+		{up: 2, down: 1, want: "uncle or aunt"},
+		{up: 2, down: 2, want: "first cousin"},
+		{up: 2, down: 2, half: true, want: "half first cousin"},
+		{up: 3, down: 3, want: "second cousin"},
+		{up: 3, down: 4, want: "second cousin once removed"},
+		{up: 5, down: 3, want: "second cousin twice removed"},
+		{up: 2, down: 5, want: "first cousin thrice removed"},
+		{up: 2, down: 6, want: "first cousin 4 times removed"},
+		{up: 12, down: 12, want: "11th cousin"},
+		{up: 13, down: 13, want: "12th cousin"},
+		{up: 22, down: 22, want: "21st cousin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := kinshipTerm(tt.up, tt.down, tt.half, tt.gender); got != tt.want {
+				t.Errorf("kinshipTerm(%d, %d, %v, %q) = %q, want %q", tt.up, tt.down, tt.half, tt.gender, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInLawTerm(t *testing.T) {
+	tests := []struct {
+		relation bloodRelation
+		gender   string
+		want     string
+	}{
+		{relation: bloodRelation{up: 1, down: 0}, gender: "male", want: "father-in-law"},
+		{relation: bloodRelation{up: 1, down: 1}, gender: "female", want: "sister-in-law"},
+		{relation: bloodRelation{up: 0, down: 1}, gender: "male", want: "stepson"},
+		{relation: bloodRelation{up: 0, down: 2}, gender: "female", want: "step-granddaughter"},
+		{relation: bloodRelation{up: 2, down: 2}, want: "first cousin-in-law"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := inLawTerm(&tt.relation, tt.gender); got != tt.want {
+				t.Errorf("inLawTerm(%+v, %q) = %q, want %q", tt.relation, tt.gender, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpouseOfRelativeTerm(t *testing.T) {
+	tests := []struct {
+		relation bloodRelation
+		gender   string
+		want     string
+	}{
+		{relation: bloodRelation{up: 1, down: 0}, gender: "female", want: "stepmother"},
+		{relation: bloodRelation{up: 2, down: 0}, gender: "male", want: "step-grandfather"},
+		{relation: bloodRelation{up: 0, down: 1}, gender: "male", want: "son-in-law"},
+		{relation: bloodRelation{up: 1, down: 1}, gender: "female", want: "sister-in-law"},
+		{relation: bloodRelation{up: 2, down: 1}, gender: "female", want: "aunt by marriage"},
+		{relation: bloodRelation{up: 2, down: 2}, want: "first cousin by marriage"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := spouseOfRelativeTerm(&tt.relation, tt.gender); got != tt.want {
+				t.Errorf("spouseOfRelativeTerm(%+v, %q) = %q, want %q", tt.relation, tt.gender, got, tt.want)
+			}
+		})
+	}
+}
+
+// testLineage builds the lineage of a person from the chains of parents leading up from them
+func testLineage(personID string, chains ...[]string) *lineage {
+	var ancestors []interfaces.Ancestor
+	seen := make(map[string]bool)
+	for _, chain := range chains {
+		var path []interfaces.Relationship
+		child := personID
+		for generation, parentID := range chain {
+			path = append(path, interfaces.Relationship{From: parentID, To: child, RelationType: interfaces.RelationTypeParent})
+			if !seen[parentID] {
+				seen[parentID] = true
+				ancestors = append(ancestors, interfaces.Ancestor{
+					Person:     interfaces.Person{ID: parentID},
+					Generation: generation + 1,
+					Path:       append([]interfaces.Relationship(nil), path...),
+				})
+			}
+			child = parentID
+		}
+	}
+	return newLineage(&interfaces.Person{ID: personID}, ancestors)
+}
+
+func TestFindBloodRelation(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     *lineage
+		b     *lineage
+		want  string
+		found bool
+	}{
+		{
+			name:  "full siblings",
+			a:     testLineage("a", []string{"father"}, []string{"mother"}),
+			b:     testLineage("b", []string{"father"}, []string{"mother"}),
+			want:  "sibling",
+			found: true,
+		},
+		{
+			name:  "half-siblings",
+			a:     testLineage("a", []string{"father"}, []string{"mother"}),
+			b:     testLineage("b", []string{"father"}, []string{"stepmother"}),
+			want:  "half-sibling",
+			found: true,
+		},
+		{
+			name:  "siblings with one known parent",
+			a:     testLineage("a", []string{"father"}),
+			b:     testLineage("b", []string{"father"}),
+			want:  "sibling",
+			found: true,
+		},
+		{
+			name:  "first cousins",
+			a:     testLineage("a", []string{"father", "grandfather"}),
+			b:     testLineage("b", []string{"aunt", "grandfather"}),
+			want:  "first cousin",
+			found: true,
+		},
+		{
+			name:  "grandparent",
+			a:     testLineage("a", []string{"father", "grandfather"}),
+			b:     testLineage("grandfather"),
+			want:  "grandparent",
+			found: true,
+		},
+		{
+			name: "unrelated",
+			a:    testLineage("a", []string{"father"}),
+			b:    testLineage("b", []string{"other"}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			relation, found := findBloodRelation(tt.a, tt.b)
+			if found != tt.found {
+				t.Fatalf("findBloodRelation found %v, want %v", found, tt.found)
+			}
+			if !found {
+				return
+			}
+			if got := kinshipTerm(relation.up, relation.down, relation.half, ""); got != tt.want {
+				t.Errorf("b is %q of a, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package v1relationshipservice
+
+import (
+	"sort"
+
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// collapseUnion is a couple found where two paths to the same ancestor split
+type collapseUnion struct {
+	partners        [2]string
+	childID         string
+	up              int
+	down            int
+	sharedAncestors map[string]bool
+}
+
+// analyzePedigreeCollapse summarizes duplicated ancestors and finds the unions that cause them.
+// Two paths to the same ancestor split at a person whose parents are both descended from that ancestor,
+// so those parents are the union, and the split tells how closely they are related.
+func analyzePedigreeCollapse(duplicated []interfaces.AncestorPaths) ([]interfaces.CollapsedAncestor, []*collapseUnion) {
+	ancestors := make([]interfaces.CollapsedAncestor, 0, len(duplicated))
+	unions := make(map[[3]string]*collapseUnion)
+	var order [][3]string
+
+	for _, ancestor := range duplicated {
+		generations := make(map[int]bool)
+		for _, path := range ancestor.Paths {
+			generations[len(path)-1] = true
+		}
+
+		collapsed := interfaces.CollapsedAncestor{
+			Person:       ancestor.Person,
+			Multiplicity: len(ancestor.Paths),
+			Paths:        ancestor.Paths,
+		}
+		for generation := range generations {
+			collapsed.Generations = append(collapsed.Generations, generation)
+		}
+		sort.Ints(collapsed.Generations)
+		ancestors = append(ancestors, collapsed)
+
+		for i := 0; i < len(ancestor.Paths); i++ {
+			for j := i + 1; j < len(ancestor.Paths); j++ {
+				pathA, pathB := ancestor.Paths[i], ancestor.Paths[j]
+
+				split := 0
+				for split+1 < len(pathA) && split+1 < len(pathB) && pathA[split+1] == pathB[split+1] {
+					split++
+				}
+				if split+1 >= len(pathA) || split+1 >= len(pathB) {
+					continue
+				}
+
+				partnerA, partnerB := pathA[split+1], pathB[split+1]
+				up := len(pathA) - 1 - (split + 1)
+				down := len(pathB) - 1 - (split + 1)
+				if partnerB < partnerA {
+					partnerA, partnerB = partnerB, partnerA
+					up, down = down, up
+				}
+
+				key := [3]string{pathA[split], partnerA, partnerB}
+				union, found := unions[key]
+				if !found {
+					union = &collapseUnion{
+						partners:        [2]string{partnerA, partnerB},
+						childID:         pathA[split],
+						up:              up,
+						down:            down,
+						sharedAncestors: make(map[string]bool),
+					}
+					unions[key] = union
+					order = append(order, key)
+				} else if up+down < union.up+union.down {
+					union.up, union.down = up, down
+				}
+				union.sharedAncestors[ancestor.Person.ID] = true
+			}
+		}
+	}
+
+	result := make([]*collapseUnion, 0, len(order))
+	for _, key := range order {
+		result = append(result, unions[key])
+	}
+
+	return ancestors, result
+}
@@ -0,0 +1,64 @@
+package v1relationshipservice
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// ErrInvalidQualifier is returned when a qualifier does not belong to the vocabulary of its relationship type
+var ErrInvalidQualifier = errors.New("invalid qualifier")
+
+// parentQualifiers are the qualifiers of parent and child relationships
+var parentQualifiers = []string{
+	interfaces.RelationQualifierBiological,
+	interfaces.RelationQualifierAdoptive,
+	interfaces.RelationQualifierStep,
+	interfaces.RelationQualifierFoster,
+	interfaces.RelationQualifierGuardian,
+}
+
+// relationQualifiers holds the qualifier vocabulary of each relationship type. Sibling relationships take no qualifier.
+var relationQualifiers = map[string][]string{
+	interfaces.RelationTypeParent: parentQualifiers,
+	interfaces.RelationTypeChild:  parentQualifiers,
+	interfaces.RelationTypeSpouse: {
+		interfaces.RelationQualifierMarried,
+		interfaces.RelationQualifierCivilUnion,
+		interfaces.RelationQualifierCohabitation,
+		interfaces.RelationQualifierEngaged,
+		interfaces.RelationQualifierDivorced,
+	},
+}
+
+// validateQualifier validates that a qualifier, when given, belongs to the vocabulary of the relationship type
+func validateQualifier(relationType, qualifier string) error {
+	if qualifier == "" {
+		return nil
+	}
+
+	valid := relationQualifiers[relationType]
+	if len(valid) == 0 {
+		return fmt.Errorf("%w: %s relationships take no qualifier", ErrInvalidQualifier, relationType)
+	}
+	if !slices.Contains(valid, qualifier) {
+		return fmt.Errorf("%w: %s. Valid qualifiers for %s relationships are: %s",
+			ErrInvalidQualifier, qualifier, relationType, strings.Join(valid, ", "))
+	}
+
+	return nil
+}
+
+// validateTraversalQualifiers validates the parent qualifiers a traversal is restricted to
+func validateTraversalQualifiers(qualifiers []string) error {
+	for _, qualifier := range qualifiers {
+		if !slices.Contains(parentQualifiers, qualifier) {
+			return fmt.Errorf("%w: %s. Valid qualifiers are: %s",
+				ErrInvalidQualifier, qualifier, strings.Join(parentQualifiers, ", "))
+		}
+	}
+	return nil
+}
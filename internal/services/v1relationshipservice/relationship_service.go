@@ -2,28 +2,53 @@ package v1relationshipservice
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/rogerwesterbo/familytree/internal/services/v1personservice"
 	"github.com/rogerwesterbo/familytree/pkg/interfaces"
 )
 
+const (
+	// DefaultTraversalDepth is the number of generations walked when no depth is given
+	DefaultTraversalDepth = 10
+	// MaxTraversalDepth is the largest number of generations a traversal may walk
+	MaxTraversalDepth = 50
+	// DefaultAhnentafelGenerations is the number of generations numbered when none is given
+	DefaultAhnentafelGenerations = 5
+	// MaxAlternativePaths is the largest number of paths a path search may return
+	MaxAlternativePaths = 10
+	// MaxAncestorPaths is the largest number of paths up to the ancestors the pedigree collapse report follows
+	MaxAncestorPaths = 10000
+)
+
+var (
+	// ErrInvalidRelationship is returned when a relationship fails validation
+	ErrInvalidRelationship = errors.New("invalid relationship")
+	// ErrTooManyAncestorPaths is returned when more paths lead up to the ancestors of a person than a report follows
+	ErrTooManyAncestorPaths = errors.New("too many paths to the ancestors")
+)
+
 // RelationshipService handles business logic for relationship operations
 type RelationshipService struct {
-	repo interfaces.RelationshipRepository
+	repo       interfaces.RelationshipRepository
+	personRepo interfaces.PersonRepository
 }
 
 // NewRelationshipService creates a new relationship service
-func NewRelationshipService(repo interfaces.RelationshipRepository) *RelationshipService {
+func NewRelationshipService(repo interfaces.RelationshipRepository, personRepo interfaces.PersonRepository) *RelationshipService {
 	return &RelationshipService{
-		repo: repo,
+		repo:       repo,
+		personRepo: personRepo,
 	}
 }
 
 // CreateRelationship creates a new relationship with validation
 func (s *RelationshipService) CreateRelationship(ctx context.Context, req *interfaces.RelationshipCreateRequest) (*interfaces.Relationship, error) {
 	// Validate required fields
-	if err := s.validateCreateRequest(req); err != nil {
+	if err := s.ValidateCreateRequest(req); err != nil {
 		return nil, err
 	}
 
@@ -32,11 +57,17 @@ func (s *RelationshipService) CreateRelationship(ctx context.Context, req *inter
 		From:         strings.TrimSpace(req.From),
 		To:           strings.TrimSpace(req.To),
 		RelationType: strings.TrimSpace(req.RelationType),
+		Qualifier:    strings.TrimSpace(req.Qualifier),
 		StartDate:    req.StartDate,
 		EndDate:      req.EndDate,
 		Notes:        strings.TrimSpace(req.Notes),
 	}
 
+	// Reject relationships that contradict the family graph
+	if err := s.validateGraph(ctx, relationship, ""); err != nil {
+		return nil, err
+	}
+
 	// Create in repository
 	if err := s.repo.Create(ctx, relationship); err != nil {
 		return nil, fmt.Errorf("failed to create relationship: %w", err)
@@ -80,6 +111,13 @@ func (s *RelationshipService) UpdateRelationship(ctx context.Context, id string,
 	}
 	if req.RelationType != "" {
 		relationship.RelationType = strings.TrimSpace(req.RelationType)
+		// Drop a qualifier the new type does not take, unless a new one is given
+		if req.Qualifier == "" && validateQualifier(relationship.RelationType, relationship.Qualifier) != nil {
+			relationship.Qualifier = ""
+		}
+	}
+	if req.Qualifier != "" {
+		relationship.Qualifier = strings.TrimSpace(req.Qualifier)
 	}
 	if !req.StartDate.IsZero() {
 		relationship.StartDate = req.StartDate
@@ -91,6 +129,20 @@ func (s *RelationshipService) UpdateRelationship(ctx context.Context, id string,
 		relationship.Notes = strings.TrimSpace(req.Notes)
 	}
 
+	// Validate the updated relationship
+	if err := validateRelationship(relationship.From, relationship.To, relationship.RelationType); err != nil {
+		return nil, err
+	}
+	if err := validateQualifier(relationship.RelationType, relationship.Qualifier); err != nil {
+		return nil, err
+	}
+	if err := validatePeriod(relationship.StartDate, relationship.EndDate); err != nil {
+		return nil, err
+	}
+	if err := s.validateGraph(ctx, relationship, relationship.ID); err != nil {
+		return nil, err
+	}
+
 	// Update in repository
 	if err := s.repo.Update(ctx, id, relationship); err != nil {
 		return nil, fmt.Errorf("failed to update relationship: %w", err)
@@ -150,21 +202,390 @@ func (s *RelationshipService) GetRelationshipsByType(ctx context.Context, relati
 	return relationships, nil
 }
 
-// validateCreateRequest validates a relationship create request
-func (s *RelationshipService) validateCreateRequest(req *interfaces.RelationshipCreateRequest) error {
+// GetAncestors gets all ancestors of a person, up to depth generations.
+// When qualifiers are given, only parents with one of them are followed, e.g. biological ancestors only.
+func (s *RelationshipService) GetAncestors(ctx context.Context, personID string, depth int, qualifiers []string) ([]interfaces.Ancestor, error) {
+	if personID == "" {
+		return nil, fmt.Errorf("person ID is required")
+	}
+	if err := validateDepth(depth); err != nil {
+		return nil, err
+	}
+	if err := validateTraversalQualifiers(qualifiers); err != nil {
+		return nil, err
+	}
+
+	// Make sure the person exists, the traversal alone can't tell a missing person from one without ancestors
+	if _, err := s.personRepo.GetByID(ctx, v1personservice.PersonKey(personID)); err != nil {
+		return nil, err
+	}
+
+	ancestors, err := s.repo.FindAncestors(ctx, v1personservice.PersonDocumentID(personID), depth, qualifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	return ancestors, nil
+}
+
+// GetDescendants gets a nested tree of the descendants of a person, up to depth generations.
+// It returns the tree and the number of distinct descendants in it. When qualifiers are given, only children
+// with a parent relationship of one of them are followed.
+func (s *RelationshipService) GetDescendants(ctx context.Context, personID string, depth int, includeSpouses bool, qualifiers []string) (*interfaces.DescendantNode, int, error) {
+	if personID == "" {
+		return nil, 0, fmt.Errorf("person ID is required")
+	}
+	if err := validateDepth(depth); err != nil {
+		return nil, 0, err
+	}
+	if err := validateTraversalQualifiers(qualifiers); err != nil {
+		return nil, 0, err
+	}
+
+	root, err := s.personRepo.GetByID(ctx, v1personservice.PersonKey(personID))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	graph, err := s.repo.FindDescendants(ctx, v1personservice.PersonDocumentID(personID), depth, includeSpouses, qualifiers)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tree, count := buildDescendantTree(root, graph, depth)
+	return tree, count, nil
+}
+
+// GetDescendantNumbers labels a person and their descendants, up to depth generations,
+// with d'Aboville or Henry numbers
+func (s *RelationshipService) GetDescendantNumbers(ctx context.Context, personID string, depth int, system string, qualifiers []string) ([]interfaces.NumberedDescendant, error) {
+	tree, _, err := s.GetDescendants(ctx, personID, depth, false, qualifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	return NumberDescendants(tree, system)
+}
+
+// GetKinship works out how person B is related to person A, searching up to depth generations.
+// Blood relations are found through the nearest common ancestors; when there are none, the
+// spouses of A and B are searched for relations by marriage.
+func (s *RelationshipService) GetKinship(ctx context.Context, personA, personB string, depth int) (*interfaces.Kinship, error) {
+	if personA == "" || personB == "" {
+		return nil, fmt.Errorf("person ID is required")
+	}
+	if err := validateDepth(depth); err != nil {
+		return nil, err
+	}
+
+	a, err := s.personRepo.GetByID(ctx, v1personservice.PersonKey(personA))
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.personRepo.GetByID(ctx, v1personservice.PersonKey(personB))
+	if err != nil {
+		return nil, err
+	}
+
+	lineageA, err := s.getLineage(ctx, a, depth)
+	if err != nil {
+		return nil, err
+	}
+	lineageB, err := s.getLineage(ctx, b, depth)
+	if err != nil {
+		return nil, err
+	}
+
+	if relation, ok := findBloodRelation(lineageA, lineageB); ok {
+		return &interfaces.Kinship{
+			Related:          true,
+			Relationship:     kinshipTerm(relation.up, relation.down, relation.half, b.Gender),
+			GenerationsFromA: relation.up,
+			GenerationsFromB: relation.down,
+			CommonAncestors:  commonAncestors(lineageA, relation),
+			Half:             relation.half,
+		}, nil
+	}
+
+	relationshipsA, err := s.repo.FindByPerson(ctx, a.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Siblings recorded without parents are only connected by a sibling edge
+	if HasEdge(relationshipsA, a.ID, b.ID, interfaces.RelationTypeSibling) {
+		return &interfaces.Kinship{
+			Related:          true,
+			Relationship:     kinshipTerm(1, 1, false, b.Gender),
+			GenerationsFromA: 1,
+			GenerationsFromB: 1,
+		}, nil
+	}
+
+	if HasEdge(relationshipsA, a.ID, b.ID, interfaces.RelationTypeSpouse) {
+		return &interfaces.Kinship{
+			Related:      true,
+			Relationship: gendered(b.Gender, "husband", "wife", "spouse"),
+			InLaw:        true,
+		}, nil
+	}
+
+	// B is a blood relative of A's spouse
+	for _, spouseID := range spouseIDs(relationshipsA, a.ID) {
+		spouse, err := s.personRepo.GetByID(ctx, v1personservice.PersonKey(spouseID))
+		if err != nil {
+			return nil, err
+		}
+		lineageSpouse, err := s.getLineage(ctx, spouse, depth)
+		if err != nil {
+			return nil, err
+		}
+		if relation, ok := findBloodRelation(lineageSpouse, lineageB); ok {
+			return &interfaces.Kinship{
+				Related:          true,
+				Relationship:     inLawTerm(relation, b.Gender),
+				GenerationsFromA: relation.up,
+				GenerationsFromB: relation.down,
+				CommonAncestors:  commonAncestors(lineageSpouse, relation),
+				Half:             relation.half,
+				InLaw:            true,
+				Via:              spouse,
+			}, nil
+		}
+	}
+
+	// B is the spouse of a blood relative of A
+	relationshipsB, err := s.repo.FindByPerson(ctx, b.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, spouseID := range spouseIDs(relationshipsB, b.ID) {
+		spouse, err := s.personRepo.GetByID(ctx, v1personservice.PersonKey(spouseID))
+		if err != nil {
+			return nil, err
+		}
+		lineageSpouse, err := s.getLineage(ctx, spouse, depth)
+		if err != nil {
+			return nil, err
+		}
+		if relation, ok := findBloodRelation(lineageA, lineageSpouse); ok {
+			return &interfaces.Kinship{
+				Related:          true,
+				Relationship:     spouseOfRelativeTerm(relation, b.Gender),
+				GenerationsFromA: relation.up,
+				GenerationsFromB: relation.down,
+				CommonAncestors:  commonAncestors(lineageA, relation),
+				Half:             relation.half,
+				InLaw:            true,
+				Via:              spouse,
+			}, nil
+		}
+	}
+
+	return &interfaces.Kinship{
+		Related:      false,
+		Relationship: "not related",
+	}, nil
+}
+
+// GetPaths gets up to k shortest chains of relationships connecting two persons
+func (s *RelationshipService) GetPaths(ctx context.Context, personA, personB string, k int) ([]interfaces.RelationshipPath, error) {
+	if personA == "" || personB == "" {
+		return nil, fmt.Errorf("person ID is required")
+	}
+	if v1personservice.PersonDocumentID(personA) == v1personservice.PersonDocumentID(personB) {
+		return nil, fmt.Errorf("from and to must be different persons")
+	}
+	if k < 1 || k > MaxAlternativePaths {
+		return nil, fmt.Errorf("k must be between 1 and %d", MaxAlternativePaths)
+	}
+
+	for _, personID := range []string{personA, personB} {
+		if _, err := s.personRepo.GetByID(ctx, v1personservice.PersonKey(personID)); err != nil {
+			return nil, err
+		}
+	}
+
+	paths, err := s.repo.FindPaths(ctx, v1personservice.PersonDocumentID(personA), v1personservice.PersonDocumentID(personB), k)
+	if err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
+
+// GetAhnentafel numbers the root person and their ancestors with Sosa-Stradonitz numbers, up to the given number of generations.
+// The root person is generation 1. When qualifiers are given, only parents with one of them are numbered.
+func (s *RelationshipService) GetAhnentafel(ctx context.Context, personID string, generations int, qualifiers []string) ([]interfaces.AhnentafelEntry, error) {
+	if personID == "" {
+		return nil, fmt.Errorf("person ID is required")
+	}
+	if generations < 1 || generations > MaxTraversalDepth {
+		return nil, fmt.Errorf("generations must be between 1 and %d", MaxTraversalDepth)
+	}
+	if err := validateTraversalQualifiers(qualifiers); err != nil {
+		return nil, err
+	}
+
+	root, err := s.personRepo.GetByID(ctx, v1personservice.PersonKey(personID))
+	if err != nil {
+		return nil, err
+	}
+
+	var links []interfaces.AncestorLink
+	if generations > 1 {
+		links, err = s.repo.FindAncestorLinks(ctx, root.ID, generations-1, qualifiers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return buildAhnentafel(root, links, generations), nil
+}
+
+// GetPedigreeCollapse reports the ancestors of a person reached through more than one path, up to depth generations,
+// together with the unions of related partners that cause them. When qualifiers are given, only parents with one of them are followed.
+func (s *RelationshipService) GetPedigreeCollapse(ctx context.Context, personID string, depth int, qualifiers []string) ([]interfaces.CollapsedAncestor, []interfaces.CollapseUnion, error) {
+	if personID == "" {
+		return nil, nil, fmt.Errorf("person ID is required")
+	}
+	if err := validateDepth(depth); err != nil {
+		return nil, nil, err
+	}
+	if err := validateTraversalQualifiers(qualifiers); err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := s.personRepo.GetByID(ctx, v1personservice.PersonKey(personID)); err != nil {
+		return nil, nil, err
+	}
+
+	duplicated, complete, err := s.repo.FindDuplicatedAncestors(ctx, v1personservice.PersonDocumentID(personID), depth, MaxAncestorPaths, qualifiers)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !complete {
+		return nil, nil, fmt.Errorf("%w: more than %d paths lead up to the ancestors within %d generations, ask for fewer generations",
+			ErrTooManyAncestorPaths, MaxAncestorPaths, depth)
+	}
+
+	ancestors, found := analyzePedigreeCollapse(duplicated)
+
+	unions := make([]interfaces.CollapseUnion, 0, len(found))
+	for _, union := range found {
+		partners := make([]interfaces.Person, 0, len(union.partners))
+		for _, partnerID := range union.partners {
+			partner, err := s.personRepo.GetByID(ctx, v1personservice.PersonKey(partnerID))
+			if err != nil {
+				return nil, nil, err
+			}
+			partners = append(partners, *partner)
+		}
+
+		sharedAncestors := make([]string, 0, len(union.sharedAncestors))
+		for ancestorID := range union.sharedAncestors {
+			sharedAncestors = append(sharedAncestors, ancestorID)
+		}
+		sort.Strings(sharedAncestors)
+
+		unions = append(unions, interfaces.CollapseUnion{
+			Partners:        partners,
+			ChildID:         union.childID,
+			Relationship:    kinshipTerm(union.up, union.down, false, partners[1].Gender),
+			SharedAncestors: sharedAncestors,
+		})
+	}
+
+	return ancestors, unions, nil
+}
+
+// getLineage gets a person together with their ancestors, up to depth generations
+func (s *RelationshipService) getLineage(ctx context.Context, person *interfaces.Person, depth int) (*lineage, error) {
+	ancestors, err := s.repo.FindAncestors(ctx, person.ID, depth, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return newLineage(person, ancestors), nil
+}
+
+// commonAncestors resolves the common ancestors of a blood relation to persons
+func commonAncestors(l *lineage, relation *bloodRelation) []interfaces.Person {
+	persons := make([]interfaces.Person, 0, len(relation.commonAncestors))
+	for _, id := range relation.commonAncestors {
+		persons = append(persons, l.persons[id])
+	}
+	return persons
+}
+
+// HasEdge reports whether the relationships contain an edge of the given type between two persons
+func HasEdge(relationships []interfaces.Relationship, personA, personB, relationType string) bool {
+	for _, rel := range relationships {
+		if rel.RelationType != relationType {
+			continue
+		}
+		if (rel.From == personA && rel.To == personB) || (rel.From == personB && rel.To == personA) {
+			return true
+		}
+	}
+	return false
+}
+
+// spouseIDs returns the IDs of the spouses of a person from their relationships
+func spouseIDs(relationships []interfaces.Relationship, personID string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, rel := range relationships {
+		if rel.RelationType != interfaces.RelationTypeSpouse {
+			continue
+		}
+		spouseID := rel.To
+		if spouseID == personID {
+			spouseID = rel.From
+		}
+		if !seen[spouseID] {
+			seen[spouseID] = true
+			ids = append(ids, spouseID)
+		}
+	}
+	return ids
+}
+
+// ValidateCreateRequest validates a relationship create request. Checks against the family graph are left to
+// CreateRelationship.
+func (s *RelationshipService) ValidateCreateRequest(req *interfaces.RelationshipCreateRequest) error {
 	if strings.TrimSpace(req.From) == "" {
-		return fmt.Errorf("from is required")
+		return fmt.Errorf("%w: from is required", ErrInvalidRelationship)
 	}
 	if strings.TrimSpace(req.To) == "" {
-		return fmt.Errorf("to is required")
+		return fmt.Errorf("%w: to is required", ErrInvalidRelationship)
 	}
 	if strings.TrimSpace(req.RelationType) == "" {
-		return fmt.Errorf("relationType is required")
+		return fmt.Errorf("%w: relationType is required", ErrInvalidRelationship)
+	}
+	if err := validatePeriod(req.StartDate, req.EndDate); err != nil {
+		return err
+	}
+	if err := validateRelationship(req.From, req.To, req.RelationType); err != nil {
+		return err
 	}
 
+	return validateQualifier(strings.TrimSpace(req.RelationType), strings.TrimSpace(req.Qualifier))
+}
+
+// validatePeriod validates that a relationship does not end before it starts
+func validatePeriod(startDate, endDate interfaces.GenealogicalDate) error {
+	if endDate.DefinitelyBefore(startDate) {
+		return fmt.Errorf("%w: endDate %s must not be before startDate %s", ErrInvalidRelationship, endDate, startDate)
+	}
+	return nil
+}
+
+// validateRelationship validates the persons and type of a relationship
+func validateRelationship(from, to, relationType string) error {
 	// Validate that from and to are different
-	if req.From == req.To {
-		return fmt.Errorf("from and to must be different persons")
+	if from == to {
+		return fmt.Errorf("%w: from and to must be different persons", ErrInvalidRelationship)
 	}
 
 	// Validate relationship type
@@ -175,9 +596,17 @@ func (s *RelationshipService) validateCreateRequest(req *interfaces.Relationship
 		interfaces.RelationTypeSibling: true,
 	}
 
-	if !validTypes[req.RelationType] {
-		return fmt.Errorf("invalid relationship type: %s. Valid types are: parent, child, spouse, sibling", req.RelationType)
+	if !validTypes[relationType] {
+		return fmt.Errorf("%w: unknown relationType %s. Valid types are: parent, child, spouse, sibling", ErrInvalidRelationship, relationType)
 	}
 
 	return nil
 }
+
+// validateDepth validates a traversal depth
+func validateDepth(depth int) error {
+	if depth < 1 || depth > MaxTraversalDepth {
+		return fmt.Errorf("depth must be between 1 and %d", MaxTraversalDepth)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package v1sourceservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+var (
+	// ErrInvalidSource is returned when a source fails validation
+	ErrInvalidSource = errors.New("invalid source")
+
+	// ErrSourceInUse is returned when deleting a source that citations refer to
+	ErrSourceInUse = errors.New("source is in use")
+)
+
+// SourceService handles business logic for source operations
+type SourceService struct {
+	repo interfaces.SourceRepository
+}
+
+// NewSourceService creates a new source service
+func NewSourceService(repo interfaces.SourceRepository) *SourceService {
+	return &SourceService{
+		repo: repo,
+	}
+}
+
+// CreateSource creates a new source with validation
+func (s *SourceService) CreateSource(ctx context.Context, req *interfaces.SourceCreateRequest) (*interfaces.Source, error) {
+	source := &interfaces.Source{
+		Title:      strings.TrimSpace(req.Title),
+		Type:       strings.TrimSpace(req.Type),
+		Author:     strings.TrimSpace(req.Author),
+		Repository: strings.TrimSpace(req.Repository),
+		URL:        strings.TrimSpace(req.URL),
+		Notes:      strings.TrimSpace(req.Notes),
+	}
+
+	if err := validateSource(source); err != nil {
+		return nil, err
+	}
+
+	// Create in repository
+	if err := s.repo.Create(ctx, source); err != nil {
+		return nil, fmt.Errorf("failed to create source: %w", err)
+	}
+
+	return source, nil
+}
+
+// GetSource retrieves a source by ID
+func (s *SourceService) GetSource(ctx context.Context, id string) (*interfaces.Source, error) {
+	if id == "" {
+		return nil, fmt.Errorf("source ID is required")
+	}
+
+	source, err := s.repo.GetByID(ctx, SourceKey(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+// UpdateSource updates an existing source
+func (s *SourceService) UpdateSource(ctx context.Context, id string, req *interfaces.SourceUpdateRequest) (*interfaces.Source, error) {
+	if id == "" {
+		return nil, fmt.Errorf("source ID is required")
+	}
+
+	// Get existing source
+	source, err := s.repo.GetByID(ctx, SourceKey(id))
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields if provided
+	if req.Title != "" {
+		source.Title = strings.TrimSpace(req.Title)
+	}
+	if req.Type != "" {
+		source.Type = strings.TrimSpace(req.Type)
+	}
+	if req.Author != "" {
+		source.Author = strings.TrimSpace(req.Author)
+	}
+	if req.Repository != "" {
+		source.Repository = strings.TrimSpace(req.Repository)
+	}
+	if req.URL != "" {
+		source.URL = strings.TrimSpace(req.URL)
+	}
+	if req.Notes != "" {
+		source.Notes = strings.TrimSpace(req.Notes)
+	}
+
+	if err := validateSource(source); err != nil {
+		return nil, err
+	}
+
+	// Update in repository
+	if err := s.repo.Update(ctx, source.Key, source); err != nil {
+		return nil, fmt.Errorf("failed to update source: %w", err)
+	}
+
+	return source, nil
+}
+
+// DeleteSource deletes a source that no citation refers to
+func (s *SourceService) DeleteSource(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("source ID is required")
+	}
+
+	source, err := s.repo.GetByID(ctx, SourceKey(id))
+	if err != nil {
+		return err
+	}
+
+	cited, err := s.repo.IsCited(ctx, source.ID)
+	if err != nil {
+		return err
+	}
+	if cited {
+		return fmt.Errorf("%w: %s is referred to by citations", ErrSourceInUse, source.ID)
+	}
+
+	if err := s.repo.Delete(ctx, source.Key); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListSources retrieves all sources
+func (s *SourceService) ListSources(ctx context.Context) ([]interfaces.Source, error) {
+	sources, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return sources, nil
+}
+
+// validateSource validates the title and type of a source
+func validateSource(source *interfaces.Source) error {
+	validTypes := map[string]bool{
+		interfaces.SourceTypeChurchBook: true,
+		interfaces.SourceTypeCensus:     true,
+		interfaces.SourceTypeGravestone: true,
+		interfaces.SourceTypeInterview:  true,
+		interfaces.SourceTypeOther:      true,
+	}
+
+	if source.Title == "" {
+		return fmt.Errorf("%w: title is required", ErrInvalidSource)
+	}
+	if !validTypes[source.Type] {
+		return fmt.Errorf("%w: invalid source type: %s. Valid types are: church_book, census, gravestone, interview, other", ErrInvalidSource, source.Type)
+	}
+
+	return nil
+}
+
+// SourceDocumentID returns the document ID (sources/<key>) for a source key or ID
+func SourceDocumentID(sourceID string) string {
+	if strings.Contains(sourceID, "/") {
+		return sourceID
+	}
+	return "sources/" + sourceID
+}
+
+// SourceKey returns the document key for a source key or ID
+func SourceKey(sourceID string) string {
+	return strings.TrimPrefix(sourceID, "sources/")
+}
@@ -32,6 +32,16 @@ func Init() {
 	viper.SetDefault(consts.ARANGODB_USERNAME, "root")
 	viper.SetDefault(consts.ARANGODB_PASSWORD, "")
 
+	// Media settings
+	viper.SetDefault(consts.MEDIA_STORAGE_PATH, "data/media")
+	viper.SetDefault(consts.MEDIA_MAX_UPLOAD_SIZE, 100<<20) // 100 MiB per file
+
+	// Privacy settings
+	viper.SetDefault(consts.LIVING_PERSON_YEARS, 100) // Persons born within this many years are probably living
+
+	// Import settings
+	viper.SetDefault(consts.IMPORT_MAX_UPLOAD_SIZE, 100<<20) // 100 MiB per imported file
+
 	// Authentication settings
 	viper.SetDefault(consts.KEYCLOAK_URL, "http://localhost:14101")
 	viper.SetDefault(consts.KEYCLOAK_REALM, "familytree")
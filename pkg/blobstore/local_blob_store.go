@@ -0,0 +1,123 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// LocalBlobStore implements the BlobStore interface on the local filesystem.
+// Content is kept in files below a root directory, spread over subdirectories named by the first two characters of the key.
+type LocalBlobStore struct {
+	root string
+}
+
+// NewLocalBlobStore creates a blob store below the root directory, creating the directory if necessary
+func NewLocalBlobStore(root string) (*LocalBlobStore, error) {
+	if root == "" {
+		return nil, fmt.Errorf("blob store root directory is required")
+	}
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create blob store directory %s: %w", root, err)
+	}
+
+	return &LocalBlobStore{root: root}, nil
+}
+
+// Put stores the content read from r under key, replacing any content already stored under it.
+// The content is written to a temporary file first so a failed upload never leaves partial content behind.
+func (s *LocalBlobStore) Put(ctx context.Context, key string, r io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create blob file: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(file.Name())
+	}()
+
+	if _, err := io.Copy(file, contextReader{ctx: ctx, r: r}); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write blob %s: %w", key, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write blob %s: %w", key, err)
+	}
+
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("failed to store blob %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// Get opens the content stored under key. The caller must close it.
+func (s *LocalBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path) // #nosec G304 -- the path is built from a validated key below the root directory
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("blob %s not found", key)
+		}
+		return nil, fmt.Errorf("failed to open blob %s: %w", key, err)
+	}
+
+	return file, nil
+}
+
+// Delete removes the content stored under key. Deleting missing content is not an error.
+func (s *LocalBlobStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete blob %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// path returns the file holding the content of a key. Keys are limited to letters, digits,
+// dashes and underscores so they can never point outside the root directory.
+func (s *LocalBlobStore) path(key string) (string, error) {
+	if len(key) < 3 {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	for _, c := range key {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != '-' && c != '_' {
+			return "", fmt.Errorf("invalid blob key %q", key)
+		}
+	}
+
+	return filepath.Join(s.root, key[:2], key), nil
+}
+
+// contextReader stops reading once its context is done, so abandoned uploads are not written to the end
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
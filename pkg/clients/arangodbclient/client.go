@@ -11,6 +11,9 @@ import (
 	"github.com/spf13/viper"
 )
 
+// FamilyGraphName is the name of the graph connecting persons through relationships
+const FamilyGraphName = "familygraph"
+
 // Client wraps the ArangoDB client with additional functionality
 type Client struct {
 	conn    arangodb.Client
@@ -81,6 +84,11 @@ func NewClient(ctx context.Context, optFuncs ...OptionFunc) (*Client, error) {
 		return nil, fmt.Errorf("failed to initialize collections: %w", err)
 	}
 
+	// Initialize graphs
+	if err := c.initializeGraphs(ctx); err != nil {
+		return nil, fmt.Errorf("failed to initialize graphs: %w", err)
+	}
+
 	return c, nil
 }
 
@@ -118,6 +126,59 @@ func (c *Client) initializeCollections(ctx context.Context) error {
 		return fmt.Errorf("failed to create relationships collection: %w", err)
 	}
 
+	// Create places collection (document collection)
+	if err := c.ensureCollection(ctx, "places", false); err != nil {
+		return fmt.Errorf("failed to create places collection: %w", err)
+	}
+
+	// Create events collection (document collection)
+	if err := c.ensureCollection(ctx, "events", false); err != nil {
+		return fmt.Errorf("failed to create events collection: %w", err)
+	}
+
+	// Create participants collection (edge collection from persons to events)
+	if err := c.ensureCollection(ctx, "participants", true); err != nil {
+		return fmt.Errorf("failed to create participants collection: %w", err)
+	}
+
+	// Create sources and citations collections (document collections)
+	if err := c.ensureCollection(ctx, "sources", false); err != nil {
+		return fmt.Errorf("failed to create sources collection: %w", err)
+	}
+	if err := c.ensureCollection(ctx, "citations", false); err != nil {
+		return fmt.Errorf("failed to create citations collection: %w", err)
+	}
+
+	// Create supports collection (edge collection from citations to persons, relationships and events)
+	if err := c.ensureCollection(ctx, "supports", true); err != nil {
+		return fmt.Errorf("failed to create supports collection: %w", err)
+	}
+
+	// Create media collection (document collection for media metadata)
+	if err := c.ensureCollection(ctx, "media", false); err != nil {
+		return fmt.Errorf("failed to create media collection: %w", err)
+	}
+
+	// Create attachments collection (edge collection from media to persons, events and sources)
+	if err := c.ensureCollection(ctx, "attachments", true); err != nil {
+		return fmt.Errorf("failed to create attachments collection: %w", err)
+	}
+
+	// Create attributeTypes collection (document collection for custom person attributes)
+	if err := c.ensureCollection(ctx, "attributeTypes", false); err != nil {
+		return fmt.Errorf("failed to create attributeTypes collection: %w", err)
+	}
+
+	// Create notes collection (document collection for research notes and tasks)
+	if err := c.ensureCollection(ctx, "notes", false); err != nil {
+		return fmt.Errorf("failed to create notes collection: %w", err)
+	}
+
+	// Create migrations collection (document collection recording the data migrations that only run once)
+	if err := c.ensureCollection(ctx, "migrations", false); err != nil {
+		return fmt.Errorf("failed to create migrations collection: %w", err)
+	}
+
 	return nil
 }
 
@@ -146,6 +207,92 @@ func (c *Client) ensureCollection(ctx context.Context, name string, isEdge bool)
 	return nil
 }
 
+// initializeGraphs creates the named graphs if they don't exist and keeps their edge definitions up to date
+func (c *Client) initializeGraphs(ctx context.Context) error {
+	if err := c.ensureGraph(ctx, FamilyGraphName, familyGraphEdgeDefinitions()); err != nil {
+		return fmt.Errorf("failed to create %s graph: %w", FamilyGraphName, err)
+	}
+
+	return nil
+}
+
+// familyGraphEdgeDefinitions returns the edge definitions of the family graph.
+// Only edges between persons belong here, since path searches walk every edge in the graph.
+func familyGraphEdgeDefinitions() []arangodb.EdgeDefinition {
+	return []arangodb.EdgeDefinition{
+		{
+			Collection: "relationships",
+			From:       []string{"persons"},
+			To:         []string{"persons"},
+		},
+	}
+}
+
+// ensureGraph ensures a named graph exists with the given edge definitions, creating or updating it if necessary
+func (c *Client) ensureGraph(ctx context.Context, name string, edgeDefinitions []arangodb.EdgeDefinition) error {
+	exists, err := c.db.GraphExists(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to check graph existence: %w", err)
+	}
+
+	if !exists {
+		_, err = c.db.CreateGraph(ctx, name, &arangodb.GraphDefinition{EdgeDefinitions: edgeDefinitions}, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create graph: %w", err)
+		}
+		return nil
+	}
+
+	graph, err := c.db.Graph(ctx, name, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get graph: %w", err)
+	}
+
+	existing := make(map[string]arangodb.EdgeDefinition)
+	for _, definition := range graph.EdgeDefinitions() {
+		existing[definition.Collection] = definition
+	}
+
+	for _, definition := range edgeDefinitions {
+		current, found := existing[definition.Collection]
+		if !found {
+			_, err = graph.CreateEdgeDefinition(ctx, definition.Collection, definition.From, definition.To, nil)
+			if err != nil {
+				return fmt.Errorf("failed to create edge definition for %s: %w", definition.Collection, err)
+			}
+			continue
+		}
+
+		if !sameCollections(current.From, definition.From) || !sameCollections(current.To, definition.To) {
+			_, err = graph.ReplaceEdgeDefinition(ctx, definition.Collection, definition.From, definition.To, nil)
+			if err != nil {
+				return fmt.Errorf("failed to replace edge definition for %s: %w", definition.Collection, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// sameCollections reports whether two lists hold the same collection names, ignoring order
+func sameCollections(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	names := make(map[string]bool, len(a))
+	for _, name := range a {
+		names[name] = true
+	}
+	for _, name := range b {
+		if !names[name] {
+			return false
+		}
+	}
+
+	return true
+}
+
 // GetDatabase returns the database instance
 func (c *Client) GetDatabase() arangodb.Database {
 	return c.db
@@ -156,6 +303,11 @@ func (c *Client) GetCollection(ctx context.Context, name string) (arangodb.Colle
 	return c.db.GetCollection(ctx, name, nil)
 }
 
+// GetGraph returns the family graph
+func (c *Client) GetGraph(ctx context.Context) (arangodb.Graph, error) {
+	return c.db.Graph(ctx, FamilyGraphName, nil)
+}
+
 // Close closes the client connection
 func (c *Client) Close() error {
 	// The v2 driver doesn't have an explicit close method
@@ -35,4 +35,14 @@ var (
 	ARANGODB_HOST          = "ARANGODB_HOST"
 	ARANGODB_PORT          = "ARANGODB_PORT"
 	ARANGODB_HTTP2_ENABLED = "ARANGODB_HTTP2_ENABLED"
+
+	// Media settings
+	MEDIA_STORAGE_PATH    = "MEDIA_STORAGE_PATH"
+	MEDIA_MAX_UPLOAD_SIZE = "MEDIA_MAX_UPLOAD_SIZE"
+
+	// Privacy settings
+	LIVING_PERSON_YEARS = "LIVING_PERSON_YEARS"
+
+	// Import settings
+	IMPORT_MAX_UPLOAD_SIZE = "IMPORT_MAX_UPLOAD_SIZE"
 )
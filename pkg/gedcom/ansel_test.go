@@ -0,0 +1,32 @@
+package gedcom
+
+import (
+	"testing"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestDecodeANSEL(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		want  string
+	}{
+		{name: "ASCII", input: []byte("Ole Hansen"), want: "Ole Hansen"},
+		{name: "spacing characters", input: []byte("S\xb2ren \xa2rsta \xb5r"), want: "Søren Ørsta ær"},
+		{name: "diacritic before its letter", input: []byte("B\xe8ar"), want: "Bär"},
+		{name: "ring above", input: []byte("\xeaAsnes"), want: "Åsnes"},
+		{name: "two diacritics", input: []byte("\xe8\xe2u"), want: "ǘ"},
+		{name: "diacritic at the end of a line", input: []byte("a\xe8\nb"), want: "ä\nb"},
+		{name: "unknown character", input: []byte("a\x90b"), want: "a�b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := norm.NFC.String(decodeANSEL(tt.input))
+			if got != norm.NFC.String(tt.want) {
+				t.Errorf("decodeANSEL(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package gedcom
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// Calendar escapes
+const (
+	calendarGregorian = "@#DGREGORIAN@"
+	calendarJulian    = "@#DJULIAN@"
+)
+
+// julianDayUnixEpoch is the Julian day number of 1970-01-01
+const julianDayUnixEpoch = 2440588
+
+// months are the GEDCOM month abbreviations
+var months = []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+
+// Date is a GEDCOM date value read as a genealogical date
+type Date struct {
+	Value interfaces.GenealogicalDate
+	// Phrase is the free text of a date phrase, as in "INT 1850 (about the time of the emigration)"
+	// or "(before the war)", which the genealogical date cannot hold
+	Phrase string
+}
+
+// ParseDate reads a GEDCOM date value. Besides the dates ParseGenealogicalDate accepts, it handles
+// date phrases, interpreted dates (INT), periods (FROM .. TO, taken as the range they span),
+// dual years such as 1699/00 and Julian calendar dates, which are converted to the Gregorian calendar.
+// A date that is only a phrase returns a zero Value with the phrase.
+func ParseDate(value string) (Date, error) {
+	var date Date
+
+	value = strings.TrimSpace(value)
+	if open := strings.Index(value, "("); open != -1 {
+		phrase := value[open+1:]
+		if closing := strings.LastIndex(phrase, ")"); closing != -1 {
+			phrase = phrase[:closing]
+		}
+		date.Phrase = strings.TrimSpace(phrase)
+		value = strings.TrimSpace(value[:open])
+	}
+
+	fields := strings.Fields(strings.ToUpper(value))
+	if len(fields) > 0 && fields[0] == "INT" {
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return date, nil
+	}
+
+	julian := false
+	var kept []string
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		switch {
+		case field == calendarGregorian:
+			continue
+		case field == calendarJulian:
+			julian = true
+			continue
+		case strings.HasPrefix(field, "@#D"):
+			// Calendar names may contain a space, as in @#DFRENCH R@
+			for !strings.HasSuffix(field, "@") && i+1 < len(fields) {
+				i++
+				field += " " + fields[i]
+			}
+			return Date{}, fmt.Errorf("unsupported calendar %s in date %q", strings.Trim(field, "@#D"), value)
+		case field == "B.C." || field == "BC":
+			return Date{}, fmt.Errorf("dates before the common era are not supported: %q", value)
+		case strings.Contains(field, "/"):
+			year, err := dualYear(field)
+			if err != nil {
+				return Date{}, fmt.Errorf("invalid date %q: %w", value, err)
+			}
+			field = year
+		}
+		kept = append(kept, field)
+	}
+
+	parsed, err := interfaces.ParseGenealogicalDate(strings.Join(period(kept), " "))
+	if err != nil {
+		return Date{}, err
+	}
+	if julian {
+		parsed.Date = julianToGregorian(parsed.Date)
+		parsed.End = julianToGregorian(parsed.End)
+	}
+	date.Value = parsed
+
+	return date, nil
+}
+
+// FormatDate writes a genealogical date as a GEDCOM date value, such as "12 MAY 1843", "ABT 1843" or
+// "BET 1801 AND 1805". An unknown date is written as "".
+func FormatDate(date interfaces.GenealogicalDate) string {
+	switch {
+	case date.IsZero():
+		return ""
+	case date.Qualifier == interfaces.DateQualifierBetween:
+		return fmt.Sprintf("BET %s AND %s", formatDatePart(date.Date), formatDatePart(date.End))
+	case date.Qualifier != "":
+		return date.Qualifier + " " + formatDatePart(date.Date)
+	default:
+		return formatDatePart(date.Date)
+	}
+}
+
+// formatDatePart writes a date part as "12 MAY 1843", "MAY 1843" or "1843"
+func formatDatePart(part interfaces.DatePart) string {
+	switch {
+	case part.Month == 0:
+		return strconv.Itoa(part.Year)
+	case part.Day == 0:
+		return fmt.Sprintf("%s %d", months[part.Month-1], part.Year)
+	default:
+		return fmt.Sprintf("%d %s %d", part.Day, months[part.Month-1], part.Year)
+	}
+}
+
+// period rewrites a period into the range it spans: FROM a TO b becomes BET a AND b, FROM a becomes AFT a
+// and TO b becomes BEF b
+func period(fields []string) []string {
+	if len(fields) == 0 || (fields[0] != "FROM" && fields[0] != "TO") {
+		return fields
+	}
+
+	to := -1
+	for i, field := range fields {
+		if field == "TO" {
+			to = i
+		}
+	}
+
+	switch {
+	case fields[0] == "TO":
+		return append([]string{interfaces.DateQualifierBefore}, fields[1:]...)
+	case to == -1:
+		return append([]string{interfaces.DateQualifierAfter}, fields[1:]...)
+	default:
+		rewritten := append([]string{interfaces.DateQualifierBetween}, fields[1:to]...)
+		rewritten = append(rewritten, "AND")
+		return append(rewritten, fields[to+1:]...)
+	}
+}
+
+// dualYear reads a dual year such as 1699/00 or 1699/1700, written for dates between 1 January and
+// the start of the year in March under the old style, as the later, new style year
+func dualYear(field string) (string, error) {
+	first, second, _ := strings.Cut(field, "/")
+	year, err := strconv.Atoi(first)
+	if err != nil {
+		return "", fmt.Errorf("invalid dual year %q", field)
+	}
+	if _, err := strconv.Atoi(second); err != nil || second == "" {
+		return "", fmt.Errorf("invalid dual year %q", field)
+	}
+	return strconv.Itoa(year + 1), nil
+}
+
+// julianToGregorian converts a full Julian calendar date to the Gregorian calendar. Dates without a day
+// cannot be converted exactly and are kept as they are.
+func julianToGregorian(part interfaces.DatePart) interfaces.DatePart {
+	if part.Day == 0 {
+		return part
+	}
+
+	// Julian day number of the Julian calendar date
+	a := (14 - part.Month) / 12
+	y := part.Year + 4800 - a
+	m := part.Month + 12*a - 3
+	jdn := part.Day + (153*m+2)/5 + 365*y + y/4 - 32083
+
+	t := time.Unix(int64(jdn-julianDayUnixEpoch)*86400, 0).UTC()
+	return interfaces.DatePart{Year: t.Year(), Month: int(t.Month()), Day: t.Day()}
+}
@@ -0,0 +1,153 @@
+package gedcom
+
+import (
+	"testing"
+
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		phrase  string
+		wantErr bool
+	}{
+		{name: "exact date", input: "12 MAY 1843", want: "1843-05-12"},
+		{name: "lower case", input: "abt 1850", want: "ABT 1850"},
+		{name: "between", input: "BET 1850 AND 1860", want: "BET 1850 AND 1860"},
+		{name: "period", input: "FROM 1850 TO 1860", want: "BET 1850 AND 1860"},
+		{name: "period without end", input: "FROM 1850", want: "AFT 1850"},
+		{name: "period without start", input: "TO 1860", want: "BEF 1860"},
+		{name: "interpreted date", input: "INT 1850 (about the emigration)", want: "1850", phrase: "about the emigration"},
+		{name: "date phrase", input: "(before the war)", phrase: "before the war"},
+		{name: "empty", input: ""},
+		{name: "dual year", input: "10 FEB 1699/00", want: "1700-02-10"},
+		{name: "dual year in full", input: "FEB 1699/1700", want: "1700-02"},
+		{name: "Gregorian calendar", input: "@#DGREGORIAN@ MAR 1790", want: "1790-03"},
+		{name: "Julian calendar", input: "@#DJULIAN@ 1 JAN 1700", want: "1700-01-11"},
+		{name: "Julian calendar in a range", input: "BET @#DJULIAN@ 1690 AND 1700", want: "BET 1690 AND 1700"},
+		{name: "Hebrew calendar", input: "@#DHEBREW@ 5600", wantErr: true},
+		{name: "French republican calendar", input: "@#DFRENCH R@ 1 VEND 12", wantErr: true},
+		{name: "before the common era", input: "44 BC", wantErr: true},
+		{name: "invalid dual year", input: "1699/XX", wantErr: true},
+		{name: "text", input: "FOO", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDate(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseDate(%q) = %q, want an error", tt.input, got.Value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDate(%q) returned error: %v", tt.input, err)
+			}
+			if got.Value.String() != tt.want {
+				t.Errorf("ParseDate(%q) = %q, want %q", tt.input, got.Value, tt.want)
+			}
+			if got.Phrase != tt.phrase {
+				t.Errorf("ParseDate(%q) phrase = %q, want %q", tt.input, got.Phrase, tt.phrase)
+			}
+		})
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "", want: ""},
+		{input: "1843", want: "1843"},
+		{input: "1843-05", want: "MAY 1843"},
+		{input: "1843-05-12", want: "12 MAY 1843"},
+		{input: "ABT 1843", want: "ABT 1843"},
+		{input: "BEF 1790-03", want: "BEF MAR 1790"},
+		{input: "BET 1801 AND 1805-06-01", want: "BET 1801 AND 1 JUN 1805"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			date, err := interfaces.ParseGenealogicalDate(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := FormatDate(date)
+			if got != tt.want {
+				t.Errorf("FormatDate(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+
+			// Formatted dates read back as the same date
+			parsed, err := ParseDate(got)
+			if err != nil {
+				t.Fatalf("ParseDate(%q) returned error: %v", got, err)
+			}
+			if parsed.Value != date {
+				t.Errorf("ParseDate(%q) = %q, want %q", got, parsed.Value, date)
+			}
+		})
+	}
+}
+
+func TestDualYear(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "1699/00", want: "1700"},
+		{input: "1699/1700", want: "1700"},
+		{input: "1750/51", want: "1751"},
+		{input: "1699/", wantErr: true},
+		{input: "/00", wantErr: true},
+		{input: "ABCD/00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := dualYear(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("dualYear(%q) = %q, want an error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("dualYear(%q) returned error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("dualYear(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJulianToGregorian(t *testing.T) {
+	tests := []struct {
+		name   string
+		julian interfaces.DatePart
+		want   interfaces.DatePart
+	}{
+		{name: "Gregorian reform", julian: interfaces.DatePart{Year: 1582, Month: 10, Day: 5}, want: interfaces.DatePart{Year: 1582, Month: 10, Day: 15}},
+		{name: "Julian leap day", julian: interfaces.DatePart{Year: 1700, Month: 2, Day: 29}, want: interfaces.DatePart{Year: 1700, Month: 3, Day: 11}},
+		{name: "Norwegian calendar change", julian: interfaces.DatePart{Year: 1700, Month: 2, Day: 19}, want: interfaces.DatePart{Year: 1700, Month: 3, Day: 1}},
+		{name: "British calendar change", julian: interfaces.DatePart{Year: 1752, Month: 9, Day: 2}, want: interfaces.DatePart{Year: 1752, Month: 9, Day: 13}},
+		{name: "Russian calendar change", julian: interfaces.DatePart{Year: 1918, Month: 1, Day: 31}, want: interfaces.DatePart{Year: 1918, Month: 2, Day: 13}},
+		{name: "month only", julian: interfaces.DatePart{Year: 1700, Month: 2}, want: interfaces.DatePart{Year: 1700, Month: 2}},
+		{name: "year only", julian: interfaces.DatePart{Year: 1700}, want: interfaces.DatePart{Year: 1700}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := julianToGregorian(tt.julian)
+			if got != tt.want {
+				t.Errorf("julianToGregorian(%s) = %s, want %s", tt.julian, got, tt.want)
+			}
+		})
+	}
+}
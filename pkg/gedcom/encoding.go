@@ -0,0 +1,96 @@
+package gedcom
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Character sets
+const (
+	EncodingUTF8    = "UTF-8"
+	EncodingUTF16   = "UNICODE"
+	EncodingANSEL   = "ANSEL"
+	EncodingASCII   = "ASCII"
+	EncodingANSI    = "ANSI"
+	EncodingIBMPC   = "IBMPC"
+	EncodingLatin1  = "ISO-8859-1"
+	encodingUnknown = ""
+)
+
+// headerScanSize is how much of the file is searched for the CHAR tag
+const headerScanSize = 64 << 10
+
+// decode detects the character set of a GEDCOM file and converts it to UTF-8 in composed (NFC) form
+func decode(data []byte) (string, string, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		return decodeUTF8(data[3:])
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}), bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return decodeWith(unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM), data, EncodingUTF16)
+	case len(data) > 1 && data[0] == '0' && data[1] == 0:
+		return decodeWith(unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM), data, EncodingUTF16)
+	case len(data) > 1 && data[0] == 0 && data[1] == '0':
+		return decodeWith(unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM), data, EncodingUTF16)
+	}
+
+	switch charset := declaredCharset(data); charset {
+	case EncodingANSEL:
+		return norm.NFC.String(decodeANSEL(data)), EncodingANSEL, nil
+	case EncodingANSI, "WINDOWS-1252", "CP1252":
+		return decodeWith(charmap.Windows1252, data, EncodingANSI)
+	case EncodingLatin1, "ISO8859-1", "LATIN1":
+		return decodeWith(charmap.ISO8859_1, data, EncodingLatin1)
+	case EncodingIBMPC, "IBM PC", "IBM WINDOWS", "MSDOS":
+		return decodeWith(charmap.CodePage437, data, EncodingIBMPC)
+	case EncodingASCII, EncodingUTF8, "UTF8", encodingUnknown:
+		// ASCII is a subset of UTF-8, and files that do not say are most often UTF-8
+		return decodeUTF8(data)
+	case EncodingUTF16:
+		return "", "", fmt.Errorf("%w: the file declares UNICODE but has no byte order mark", ErrInvalidFile)
+	default:
+		return "", "", fmt.Errorf("%w: unsupported character set %s", ErrInvalidFile, charset)
+	}
+}
+
+// decodeUTF8 checks that the data is valid UTF-8, falling back to Windows-1252 for files that
+// declare UTF-8 but were written by programs using the system code page
+func decodeUTF8(data []byte) (string, string, error) {
+	if !utf8.Valid(data) {
+		return decodeWith(charmap.Windows1252, data, EncodingANSI)
+	}
+	return norm.NFC.String(string(data)), EncodingUTF8, nil
+}
+
+// decodeWith converts data in a character set to UTF-8
+func decodeWith(enc encoding.Encoding, data []byte, name string) (string, string, error) {
+	decoded, err := enc.NewDecoder().Bytes(data)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: the file is not valid %s: %v", ErrInvalidFile, name, err)
+	}
+	return norm.NFC.String(strings.TrimPrefix(string(decoded), "\ufeff")), name, nil
+}
+
+// declaredCharset returns the value of the HEAD.CHAR line, upper-cased, or "" when the header has none
+func declaredCharset(data []byte) string {
+	if len(data) > headerScanSize {
+		data = data[:headerScanSize]
+	}
+	for _, line := range splitLines(string(data)) {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "0" && fields[1] != "HEAD" {
+			// The header has ended
+			break
+		}
+		if len(fields) >= 3 && fields[0] == "1" && strings.EqualFold(fields[1], "CHAR") {
+			return strings.ToUpper(strings.Join(fields[2:], " "))
+		}
+	}
+	return encodingUnknown
+}
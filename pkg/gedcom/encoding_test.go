@@ -0,0 +1,137 @@
+package gedcom
+
+import (
+	"errors"
+	"testing"
+)
+
+// utf16 encodes text as UTF-16 with a byte order mark
+func utf16(text string, bigEndian bool) []byte {
+	data := []byte{0xFF, 0xFE}
+	if bigEndian {
+		data = []byte{0xFE, 0xFF}
+	}
+	for _, r := range text {
+		if bigEndian {
+			data = append(data, byte(r>>8), byte(r))
+		} else {
+			data = append(data, byte(r), byte(r>>8))
+		}
+	}
+	return data
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		want     string
+		encoding string
+	}{
+		{
+			name:     "UTF-8 with byte order mark",
+			input:    []byte("\xef\xbb\xbf0 HEAD\n1 NAME Åse"),
+			want:     "0 HEAD\n1 NAME Åse",
+			encoding: EncodingUTF8,
+		},
+		{
+			name:     "UTF-8 declared",
+			input:    []byte("0 HEAD\n1 CHAR UTF-8\n0 @I1@ INDI\n1 NAME Åse"),
+			want:     "0 HEAD\n1 CHAR UTF-8\n0 @I1@ INDI\n1 NAME Åse",
+			encoding: EncodingUTF8,
+		},
+		{
+			name:     "UTF-8 decomposed",
+			input:    []byte("0 HEAD\n1 NAME A\u030ase"),
+			want:     "0 HEAD\n1 NAME Åse",
+			encoding: EncodingUTF8,
+		},
+		{
+			name:     "UTF-8 declared but Windows-1252",
+			input:    []byte("0 HEAD\n1 CHAR UTF-8\n1 NAME \xc5se"),
+			want:     "0 HEAD\n1 CHAR UTF-8\n1 NAME Åse",
+			encoding: EncodingANSI,
+		},
+		{
+			name:     "UTF-16 little-endian",
+			input:    utf16("0 HEAD\n1 CHAR UNICODE\n1 NAME Åse", false),
+			want:     "0 HEAD\n1 CHAR UNICODE\n1 NAME Åse",
+			encoding: EncodingUTF16,
+		},
+		{
+			name:     "UTF-16 big-endian",
+			input:    utf16("0 HEAD\n1 CHAR UNICODE\n1 NAME Åse", true),
+			want:     "0 HEAD\n1 CHAR UNICODE\n1 NAME Åse",
+			encoding: EncodingUTF16,
+		},
+		{
+			name:     "ANSEL",
+			input:    []byte("0 HEAD\n1 CHAR ANSEL\n1 NAME \xeaAse"),
+			want:     "0 HEAD\n1 CHAR ANSEL\n1 NAME Åse",
+			encoding: EncodingANSEL,
+		},
+		{
+			name:     "ANSI",
+			input:    []byte("0 HEAD\n1 CHAR ANSI\n1 NAME \xc5se \x80"),
+			want:     "0 HEAD\n1 CHAR ANSI\n1 NAME Åse €",
+			encoding: EncodingANSI,
+		},
+		{
+			name:     "Latin-1",
+			input:    []byte("0 HEAD\n1 CHAR ISO-8859-1\n1 NAME \xc5se"),
+			want:     "0 HEAD\n1 CHAR ISO-8859-1\n1 NAME Åse",
+			encoding: EncodingLatin1,
+		},
+		{
+			name:     "IBM PC",
+			input:    []byte("0 HEAD\n1 CHAR IBMPC\n1 NAME \x8fse"),
+			want:     "0 HEAD\n1 CHAR IBMPC\n1 NAME Åse",
+			encoding: EncodingIBMPC,
+		},
+		{
+			name:     "ASCII",
+			input:    []byte("0 HEAD\n1 CHAR ASCII\n1 NAME Ase"),
+			want:     "0 HEAD\n1 CHAR ASCII\n1 NAME Ase",
+			encoding: EncodingUTF8,
+		},
+		{
+			name:     "CHAR after the header",
+			input:    []byte("0 HEAD\n0 @I1@ INDI\n1 CHAR ANSEL\n1 NAME \xc3\x85se"),
+			want:     "0 HEAD\n0 @I1@ INDI\n1 CHAR ANSEL\n1 NAME Åse",
+			encoding: EncodingUTF8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, encoding, err := decode(tt.input)
+			if err != nil {
+				t.Fatalf("decode returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("decode = %q, want %q", got, tt.want)
+			}
+			if encoding != tt.encoding {
+				t.Errorf("encoding = %q, want %q", encoding, tt.encoding)
+			}
+		})
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{name: "UNICODE without byte order mark", input: []byte("0 HEAD\n1 CHAR UNICODE\n")},
+		{name: "unsupported character set", input: []byte("0 HEAD\n1 CHAR EBCDIC\n")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := decode(tt.input); !errors.Is(err, ErrInvalidFile) {
+				t.Errorf("decode returned %v, want ErrInvalidFile", err)
+			}
+		})
+	}
+}
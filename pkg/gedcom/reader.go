@@ -0,0 +1,162 @@
+package gedcom
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidFile is returned when the input is not a GEDCOM file
+var ErrInvalidFile = errors.New("invalid GEDCOM file")
+
+// maxLevel is the deepest nesting accepted, far deeper than any file needs
+const maxLevel = 99
+
+// Parse reads a GEDCOM file. The character set is taken from the byte order mark or the CHAR tag of the header,
+// and the text is converted to UTF-8. Lines that cannot be read are left out with a warning.
+func Parse(r io.Reader) (*Document, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GEDCOM file: %w", err)
+	}
+
+	text, encoding, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := Build(splitLines(text), parseLine)
+	if err != nil {
+		return nil, err
+	}
+	doc.Encoding = encoding
+
+	return doc, nil
+}
+
+// LineParser parses a line of a GEDCOM file into a record without subrecords
+type LineParser func(line string) (*Record, error)
+
+// Build nests the lines of a GEDCOM file, each parsed with parseLine, into a document. Continuation lines (CONC
+// and CONT) are joined to the value of the record they continue. The first line must be 0 HEAD; other lines that
+// cannot be read or do not fit the nesting are left out with a warning.
+func Build(lines []string, parseLine LineParser) (*Document, error) {
+	var header *Record
+	var records []*Record
+	var warnings []string
+
+	// stack holds the open record at each level
+	var stack []*Record
+	for i, line := range lines {
+		lineNumber := i + 1
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		record, err := parseLine(line)
+		if err != nil {
+			if lineNumber == 1 {
+				return nil, fmt.Errorf("%w: line 1: %v", ErrInvalidFile, err)
+			}
+			warnings = append(warnings, fmt.Sprintf("line %d: %v, line skipped", lineNumber, err))
+			continue
+		}
+		record.LineNumber = lineNumber
+
+		if len(stack) == 0 && (record.Level != 0 || record.Tag != "HEAD") {
+			return nil, fmt.Errorf("%w: the file must start with 0 HEAD", ErrInvalidFile)
+		}
+
+		if record.Level > len(stack) {
+			warnings = append(warnings, fmt.Sprintf("line %d: level %d follows level %d, line skipped",
+				lineNumber, record.Level, len(stack)-1))
+			continue
+		}
+		stack = stack[:record.Level]
+
+		if record.Tag == "CONC" || record.Tag == "CONT" {
+			if record.Level == 0 {
+				warnings = append(warnings, fmt.Sprintf("line %d: %s at level 0, line skipped", lineNumber, record.Tag))
+				continue
+			}
+			// Continuation lines extend the value of their parent and keep it open for further continuations
+			parent := stack[record.Level-1]
+			if record.Tag == "CONT" {
+				parent.Value += "\n"
+			}
+			parent.Value += record.Value
+			continue
+		}
+
+		if record.Level == 0 {
+			switch {
+			case header == nil:
+				header = record
+			case record.Tag == "TRLR":
+			default:
+				records = append(records, record)
+			}
+		} else {
+			parent := stack[record.Level-1]
+			parent.Subrecords = append(parent.Subrecords, record)
+		}
+		stack = append(stack, record)
+	}
+
+	if header == nil {
+		return nil, fmt.Errorf("%w: the file is empty", ErrInvalidFile)
+	}
+
+	doc := NewDocument(header, records)
+	doc.Warnings = append(warnings, doc.Warnings...)
+
+	return doc, nil
+}
+
+// parseLine parses a line of the form "level [@xref@] tag [value]"
+func parseLine(line string) (*Record, error) {
+	// Many programs indent nested lines, which the standard does not allow but is harmless
+	line = strings.TrimLeft(line, " \t")
+
+	levelText, rest, _ := strings.Cut(line, " ")
+	level, err := strconv.Atoi(levelText)
+	if err != nil || level < 0 || level > maxLevel {
+		return nil, fmt.Errorf("invalid level %q", levelText)
+	}
+	rest = strings.TrimLeft(rest, " ")
+
+	record := &Record{Level: level}
+	if strings.HasPrefix(rest, "@") {
+		record.XRef, rest, _ = strings.Cut(rest, " ")
+		if !IsPointer(record.XRef) {
+			return nil, fmt.Errorf("invalid cross-reference %q", record.XRef)
+		}
+		rest = strings.TrimLeft(rest, " ")
+	}
+
+	// The value starts after the single space following the tag, so leading spaces of CONC values are kept
+	record.Tag, record.Value, _ = strings.Cut(rest, " ")
+	if record.Tag == "" {
+		return nil, fmt.Errorf("missing tag")
+	}
+	record.Tag = strings.ToUpper(record.Tag)
+	if record.Tag != "CONC" {
+		record.Value = strings.TrimRight(record.Value, " \t")
+	}
+	// Outside cross-references, an @ in a value is written as @@
+	if !IsPointer(record.Value) {
+		record.Value = strings.ReplaceAll(record.Value, "@@", "@")
+	}
+
+	return record, nil
+}
+
+// splitLines splits text on any of the line terminators GEDCOM allows: CR, LF, CR LF and LF CR
+func splitLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\n\r", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	return strings.Split(text, "\n")
+}
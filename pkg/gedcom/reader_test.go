@@ -0,0 +1,197 @@
+package gedcom
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		xref     string
+		path     []string
+		want     string
+		records  int
+		warnings int
+	}{
+		{
+			name:    "nested value",
+			input:   "0 HEAD\n0 @I1@ INDI\n1 BIRT\n2 DATE 12 MAY 1843\n0 TRLR\n",
+			xref:    "@I1@",
+			path:    []string{"BIRT", "DATE"},
+			want:    "12 MAY 1843",
+			records: 1,
+		},
+		{
+			name:    "continuation lines",
+			input:   "0 HEAD\n0 @N1@ NOTE First\n1 CONC  line\n1 CONT second line\n0 TRLR\n",
+			xref:    "@N1@",
+			want:    "First line\nsecond line",
+			records: 1,
+		},
+		{
+			name:    "continuation of a nested value",
+			input:   "0 HEAD\n0 @I1@ INDI\n1 NOTE a\n2 CONT b\n2 CONC c\n1 SEX M\n0 TRLR\n",
+			xref:    "@I1@",
+			path:    []string{"NOTE"},
+			want:    "a\nbc",
+			records: 1,
+		},
+		{
+			name:    "CR LF line endings",
+			input:   "0 HEAD\r\n0 @I1@ INDI\r\n1 NAME Ole /Hansen/\r\n0 TRLR\r\n",
+			xref:    "@I1@",
+			path:    []string{"NAME"},
+			want:    "Ole /Hansen/",
+			records: 1,
+		},
+		{
+			name:    "CR line endings",
+			input:   "0 HEAD\r0 @I1@ INDI\r1 NAME Ole /Hansen/\r0 TRLR\r",
+			xref:    "@I1@",
+			path:    []string{"NAME"},
+			want:    "Ole /Hansen/",
+			records: 1,
+		},
+		{
+			name:    "indented lines and lower-case tags",
+			input:   "0 HEAD\n0 @I1@ INDI\n  1 name Ole /Hansen/\n0 TRLR\n",
+			xref:    "@I1@",
+			path:    []string{"NAME"},
+			want:    "Ole /Hansen/",
+			records: 1,
+		},
+		{
+			name:    "escaped at sign",
+			input:   "0 HEAD\n0 @I1@ INDI\n1 EMAIL ole@@example.com\n0 TRLR\n",
+			xref:    "@I1@",
+			path:    []string{"EMAIL"},
+			want:    "ole@example.com",
+			records: 1,
+		},
+		{
+			name:    "pointer",
+			input:   "0 HEAD\n0 @I1@ INDI\n1 FAMC @F1@\n0 @F1@ FAM\n0 TRLR\n",
+			xref:    "@I1@",
+			path:    []string{"FAMC"},
+			want:    "@F1@",
+			records: 2,
+		},
+		{
+			name:     "skipped level",
+			input:    "0 HEAD\n0 @I1@ INDI\n2 DATE 1843\n1 SEX M\n0 TRLR\n",
+			xref:     "@I1@",
+			path:     []string{"SEX"},
+			want:     "M",
+			records:  1,
+			warnings: 1,
+		},
+		{
+			name:     "invalid line",
+			input:    "0 HEAD\n0 @I1@ INDI\nX NAME Ole\n1 SEX M\n0 TRLR\n",
+			xref:     "@I1@",
+			path:     []string{"SEX"},
+			want:     "M",
+			records:  1,
+			warnings: 1,
+		},
+		{
+			name:     "duplicate cross-reference",
+			input:    "0 HEAD\n0 @I1@ INDI\n1 SEX M\n0 @I1@ INDI\n1 SEX F\n0 TRLR\n",
+			xref:     "@I1@",
+			path:     []string{"SEX"},
+			want:     "M",
+			records:  2,
+			warnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse returned error: %v", err)
+			}
+			if len(doc.Records) != tt.records {
+				t.Errorf("got %d records, want %d", len(doc.Records), tt.records)
+			}
+			if len(doc.Warnings) != tt.warnings {
+				t.Errorf("got warnings %q, want %d", doc.Warnings, tt.warnings)
+			}
+
+			record := doc.Find(tt.xref)
+			for _, tag := range tt.path {
+				if record == nil {
+					break
+				}
+				record = record.First(tag)
+			}
+			if record == nil {
+				t.Fatalf("%s %s not found", tt.xref, strings.Join(tt.path, "."))
+			}
+			if record.Value != tt.want {
+				t.Errorf("%s %s = %q, want %q", tt.xref, strings.Join(tt.path, "."), record.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInvalidFile(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: ""},
+		{name: "blank lines", input: "\n\n"},
+		{name: "not GEDCOM", input: "hello world"},
+		{name: "no header", input: "0 @I1@ INDI\n0 TRLR\n"},
+		{name: "unsupported character set", input: "0 HEAD\n1 CHAR EBCDIC\n0 TRLR\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input))
+			if !errors.Is(err, ErrInvalidFile) {
+				t.Errorf("Parse returned %v, want ErrInvalidFile", err)
+			}
+		})
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	doc, err := Parse(strings.NewReader("0 HEAD\n1 GEDC\n2 VERS 5.5.1\n2 FORM LINEAGE-LINKED\n0 TRLR\n"))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if doc.Version != "5.5.1" {
+		t.Errorf("Version = %q, want 5.5.1", doc.Version)
+	}
+	if doc.Encoding != EncodingUTF8 {
+		t.Errorf("Encoding = %q, want %q", doc.Encoding, EncodingUTF8)
+	}
+}
+
+func TestSplitLines(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{name: "LF", input: "a\nb", want: []string{"a", "b"}},
+		{name: "CR LF", input: "a\r\nb", want: []string{"a", "b"}},
+		{name: "LF CR", input: "a\n\rb", want: []string{"a", "b"}},
+		{name: "CR", input: "a\rb", want: []string{"a", "b"}},
+		{name: "blank line", input: "a\n\nb", want: []string{"a", "", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitLines(tt.input)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("splitLines(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Package gedcom reads and writes GEDCOM 5.5.1 files, the genealogy exchange format most family tree programs use
+package gedcom
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Record is a line of a GEDCOM file with the lines nested below it. Level 0 records are the
+// individuals, families, sources and notes of the file; CONC and CONT lines are merged into the value.
+type Record struct {
+	Level int
+	XRef  string
+	Tag   string
+	Value string
+	// LineNumber is the line of the file the record starts on
+	LineNumber int
+	Subrecords []*Record
+}
+
+// Document is a parsed GEDCOM file
+type Document struct {
+	Header *Record
+	// Records are the level 0 records after the header, without the trailer
+	Records []*Record
+	// Encoding is the character set the file was decoded from
+	Encoding string
+	// Version is the GEDCOM version given in the header
+	Version string
+	// Warnings describe lines that could not be read and were left out
+	Warnings []string
+
+	xrefs map[string]*Record
+}
+
+// NewDocument creates a document from a header and the level 0 records after it, indexing their cross-reference
+// identifiers. Identifiers defined more than once are warned about and the first is used.
+func NewDocument(header *Record, records []*Record) *Document {
+	doc := &Document{
+		Header:  header,
+		Records: records,
+		xrefs:   make(map[string]*Record),
+	}
+	if gedc := header.First("GEDC"); gedc != nil {
+		doc.Version = gedc.Text("VERS")
+	}
+
+	for _, record := range records {
+		if record.XRef == "" {
+			continue
+		}
+		if _, found := doc.xrefs[record.XRef]; found {
+			doc.Warnings = append(doc.Warnings, fmt.Sprintf("line %d: %s is defined more than once, the first is used",
+				record.LineNumber, record.XRef))
+			continue
+		}
+		doc.xrefs[record.XRef] = record
+	}
+
+	return doc
+}
+
+// Find returns the level 0 record with a cross-reference identifier such as @I1@, or nil when there is none
+func (d *Document) Find(xref string) *Record {
+	return d.xrefs[xref]
+}
+
+// RecordsByTag returns the level 0 records with a tag, in file order
+func (d *Document) RecordsByTag(tag string) []*Record {
+	var records []*Record
+	for _, record := range d.Records {
+		if record.Tag == tag {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// First returns the first subrecord with a tag, or nil when there is none
+func (r *Record) First(tag string) *Record {
+	if r == nil {
+		return nil
+	}
+	for _, sub := range r.Subrecords {
+		if sub.Tag == tag {
+			return sub
+		}
+	}
+	return nil
+}
+
+// All returns the subrecords with a tag
+func (r *Record) All(tag string) []*Record {
+	if r == nil {
+		return nil
+	}
+	var subs []*Record
+	for _, sub := range r.Subrecords {
+		if sub.Tag == tag {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
+// Text returns the trimmed value of the first subrecord with a tag, or "" when there is none
+func (r *Record) Text(tag string) string {
+	if sub := r.First(tag); sub != nil {
+		return strings.TrimSpace(sub.Value)
+	}
+	return ""
+}
+
+// Pointer returns the cross-reference the record points to, such as @S1@, or "" when its value is not a pointer
+func (r *Record) Pointer() string {
+	if r == nil || !IsPointer(r.Value) {
+		return ""
+	}
+	return r.Value
+}
+
+// IsPointer reports whether a value is a cross-reference such as @I1@
+func IsPointer(value string) bool {
+	return len(value) > 2 && value[0] == '@' && value[len(value)-1] == '@' && value[1] != '#' &&
+		!strings.Contains(value[1:len(value)-1], "@")
+}
@@ -0,0 +1,116 @@
+package gedcom
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// maxValueLength is the longest value, in characters, written on a line; longer values continue on CONC lines.
+// GEDCOM 5.5.1 limits lines to 255 characters including the level, cross-reference and tag.
+const maxValueLength = 200
+
+// NewHeader creates the header of a lineage-linked GEDCOM 5.5.1 file in UTF-8, written by the program
+// named source on date and submitted by the SUBM record with the cross-reference submitter
+func NewHeader(source, submitter string, date time.Time) *Record {
+	header := &Record{Tag: "HEAD"}
+	header.Add("SOUR", source)
+	header.Add("DATE", strings.ToUpper(date.Format("2 Jan 2006")))
+	header.Add("SUBM", submitter)
+	gedc := header.Add("GEDC", "")
+	gedc.Add("VERS", "5.5.1")
+	gedc.Add("FORM", "LINEAGE-LINKED")
+	header.Add("CHAR", EncodingUTF8)
+	return header
+}
+
+// Add appends a subrecord with a tag and value and returns it
+func (r *Record) Add(tag, value string) *Record {
+	sub := &Record{Level: r.Level + 1, Tag: tag, Value: value}
+	r.Subrecords = append(r.Subrecords, sub)
+	return sub
+}
+
+// Write writes a GEDCOM file in UTF-8 with a header, the level 0 records and the trailer. The levels are
+// taken from the nesting of the records; values with line breaks continue on CONT lines and long values on CONC lines.
+func Write(w io.Writer, header *Record, records []*Record) error {
+	bw := bufio.NewWriter(w)
+
+	writeRecord(bw, header, 0)
+	for _, record := range records {
+		writeRecord(bw, record, 0)
+	}
+	writeLine(bw, 0, "", "TRLR", "")
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write GEDCOM: %w", err)
+	}
+	return nil
+}
+
+// writeRecord writes a record at a level followed by its subrecords
+func writeRecord(bw *bufio.Writer, record *Record, level int) {
+	value := strings.ReplaceAll(strings.ReplaceAll(record.Value, "\r\n", "\n"), "\r", "\n")
+	if !IsPointer(value) {
+		value = strings.ReplaceAll(value, "@", "@@")
+	}
+	for i, line := range strings.Split(value, "\n") {
+		chunks := splitValue(line)
+		for j, chunk := range chunks {
+			switch {
+			case i == 0 && j == 0:
+				writeLine(bw, level, record.XRef, record.Tag, chunk)
+			case j == 0:
+				writeLine(bw, level+1, "", "CONT", chunk)
+			default:
+				writeLine(bw, level+1, "", "CONC", chunk)
+			}
+		}
+	}
+
+	for _, sub := range record.Subrecords {
+		writeRecord(bw, sub, level+1)
+	}
+}
+
+// writeLine writes a single GEDCOM line
+func writeLine(bw *bufio.Writer, level int, xref, tag, value string) {
+	fmt.Fprintf(bw, "%d ", level)
+	if xref != "" {
+		bw.WriteString(xref + " ")
+	}
+	bw.WriteString(tag)
+	if value != "" {
+		bw.WriteString(" " + value)
+	}
+	bw.WriteString("\n")
+}
+
+// splitValue splits a value without line breaks into chunks of at most maxValueLength characters.
+// Chunks are split between two non-space characters where possible, as readers may trim the lines,
+// and never within an escaped @@.
+func splitValue(value string) []string {
+	runes := []rune(value)
+	var chunks []string
+	for len(runes) > maxValueLength {
+		split := maxValueLength
+		for split > maxValueLength/2 && (unicode.IsSpace(runes[split-1]) || unicode.IsSpace(runes[split]) || runes[split-1] == '@') {
+			split--
+		}
+		if split == maxValueLength/2 {
+			// There is nowhere better to split; split after an even number of @ to keep @@ together
+			split = maxValueLength
+			ats := 0
+			for ats < split && runes[split-1-ats] == '@' {
+				ats++
+			}
+			split -= ats % 2
+		}
+		chunks = append(chunks, string(runes[:split]))
+		runes = runes[split:]
+	}
+	return append(chunks, string(runes))
+}
@@ -0,0 +1,113 @@
+package gedcom
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestWriteRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "plain", value: "Ole Hansen"},
+		{name: "line breaks", value: "First line\nsecond line\n\nfourth line"},
+		{name: "CR LF line breaks", value: "First line\r\nsecond line"},
+		{name: "at signs", value: "ole@example.com and @@twice@"},
+		{name: "long value", value: strings.Repeat("word ", 100)},
+		{name: "long value without spaces", value: strings.Repeat("x", 450)},
+		{name: "long value of at signs", value: strings.Repeat("@", 450)},
+		{name: "non-ASCII", value: strings.Repeat("Ærlig Åse Østby ", 30)},
+		{name: "leading and trailing spaces of a continued line", value: "a\n  indented"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := NewHeader("familytree", "@U1@", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+			note := &Record{XRef: "@N1@", Tag: "NOTE", Value: tt.value}
+			indi := &Record{XRef: "@I1@", Tag: "INDI"}
+			indi.Add("NOTE", tt.value)
+			indi.Add("FAMC", "@F1@")
+
+			var buf bytes.Buffer
+			if err := Write(&buf, header, []*Record{note, indi}); err != nil {
+				t.Fatalf("Write returned error: %v", err)
+			}
+			for i, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+				if n := utf8.RuneCountInString(line); n > 255 {
+					t.Errorf("line %d is %d characters, more than GEDCOM allows", i+1, n)
+				}
+			}
+
+			doc, err := Parse(&buf)
+			if err != nil {
+				t.Fatalf("Parse returned error: %v", err)
+			}
+			if len(doc.Warnings) != 0 {
+				t.Errorf("Parse warned %q", doc.Warnings)
+			}
+			if doc.Version != "5.5.1" || doc.Encoding != EncodingUTF8 {
+				t.Errorf("read GEDCOM %s in %s, want 5.5.1 in %s", doc.Version, doc.Encoding, EncodingUTF8)
+			}
+
+			want := strings.ReplaceAll(tt.value, "\r\n", "\n")
+			if got := doc.Find("@N1@").Value; got != want {
+				t.Errorf("NOTE record = %q, want %q", got, want)
+			}
+			if got := doc.Find("@I1@").First("NOTE").Value; got != want {
+				t.Errorf("INDI.NOTE = %q, want %q", got, want)
+			}
+			if got := doc.Find("@I1@").Text("FAMC"); got != "@F1@" {
+				t.Errorf("INDI.FAMC = %q, want @F1@", got)
+			}
+		})
+	}
+}
+
+func TestSplitValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		chunks int
+	}{
+		{name: "empty", value: "", chunks: 1},
+		{name: "short", value: "Ole Hansen", chunks: 1},
+		{name: "exactly the limit", value: strings.Repeat("x", maxValueLength), chunks: 1},
+		{name: "one over the limit", value: strings.Repeat("x", maxValueLength+1), chunks: 2},
+		{name: "words", value: strings.Repeat("word ", 100), chunks: 3},
+		{name: "at signs", value: strings.Repeat("@@", 150), chunks: 2},
+		{name: "multi-byte characters", value: strings.Repeat("å", 2*maxValueLength), chunks: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks := splitValue(tt.value)
+			if len(chunks) != tt.chunks {
+				t.Errorf("splitValue returned %d chunks, want %d", len(chunks), tt.chunks)
+			}
+			if strings.Join(chunks, "") != tt.value {
+				t.Errorf("chunks %q do not join to the value", chunks)
+			}
+
+			for i, chunk := range chunks {
+				if n := utf8.RuneCountInString(chunk); n > maxValueLength {
+					t.Errorf("chunk %d is %d characters, want at most %d", i, n, maxValueLength)
+				}
+				if i == len(chunks)-1 {
+					continue
+				}
+				// Readers may trim lines, so chunks must not end or start with spaces
+				if strings.HasSuffix(chunk, " ") || strings.HasPrefix(chunks[i+1], " ") {
+					t.Errorf("split between chunk %d and %d is next to a space", i, i+1)
+				}
+				// An escaped @@ must not be split
+				if ats := len(chunk) - len(strings.TrimRight(chunk, "@")); ats%2 != 0 {
+					t.Errorf("chunk %d ends in an odd number of @", i)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package gedcom7
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/pkg/gedcom"
+)
+
+// DatasetName is the name of the GEDCOM file in a GEDZIP archive
+const DatasetName = "gedcom.ged"
+
+// ErrFileTooLarge is returned when a file in a GEDZIP archive is larger than the size it may be read to
+var ErrFileTooLarge = errors.New("file too large")
+
+// Archive is a GEDZIP archive: a GEDCOM 7.0 file and the files its FILE structures refer to
+type Archive struct {
+	*Document
+	files map[string]*zip.File
+}
+
+// ArchiveFile is a file to bundle in a GEDZIP archive
+type ArchiveFile struct {
+	// Path is the path of the file in the archive, referred to from FILE structures as FileReference(Path)
+	Path string
+	// Open opens the content of the file
+	Open func() (io.ReadCloser, error)
+}
+
+// OpenArchive reads the GEDCOM file of a GEDZIP archive of size bytes, which may be at most maxDatasetSize bytes
+// once decompressed. The other files are read when opened.
+func OpenArchive(r io.ReaderAt, size, maxDatasetSize int64) (*Archive, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: not a GEDZIP archive: %v", gedcom.ErrInvalidFile, err)
+	}
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, file := range zr.File {
+		files[file.Name] = file
+	}
+
+	dataset, found := files[DatasetName]
+	if !found {
+		return nil, fmt.Errorf("%w: the archive has no %s", gedcom.ErrInvalidFile, DatasetName)
+	}
+	content, err := openLimited(dataset, maxDatasetSize)
+	if err != nil {
+		if errors.Is(err, ErrFileTooLarge) {
+			return nil, fmt.Errorf("%w: %v", gedcom.ErrInvalidFile, err)
+		}
+		return nil, fmt.Errorf("%w: failed to open %s: %v", gedcom.ErrInvalidFile, DatasetName, err)
+	}
+	defer func() {
+		_ = content.Close()
+	}()
+
+	doc, err := Parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Archive{Document: doc, files: files}, nil
+}
+
+// ReadArchive reads a GEDZIP archive whose GEDCOM file may be at most maxDatasetSize bytes once decompressed
+func ReadArchive(r io.Reader, maxDatasetSize int64) (*Archive, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GEDZIP archive: %w", err)
+	}
+	return OpenArchive(bytes.NewReader(data), int64(len(data)), maxDatasetSize)
+}
+
+// Open opens the file in the archive a FILE structure refers to, which may be at most maxSize bytes once
+// decompressed. References to files outside the archive, such as URLs, return an error wrapping fs.ErrNotExist,
+// and larger files an error wrapping ErrFileTooLarge.
+func (a *Archive) Open(reference string, maxSize int64) (io.ReadCloser, error) {
+	name, err := FilePath(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	file, found := a.files[name]
+	if !found || name == DatasetName {
+		return nil, fmt.Errorf("%s is not in the archive: %w", reference, fs.ErrNotExist)
+	}
+	return openLimited(file, maxSize)
+}
+
+// openLimited opens a file in an archive that may be at most maxSize bytes once decompressed. The size in the
+// archive is checked before decompressing, and reading stops at maxSize bytes in case the size is wrong.
+func openLimited(file *zip.File, maxSize int64) (io.ReadCloser, error) {
+	if file.UncompressedSize64 > uint64(maxSize) {
+		return nil, fmt.Errorf("%s is %d bytes, more than the %d allowed: %w", file.Name, file.UncompressedSize64,
+			maxSize, ErrFileTooLarge)
+	}
+
+	content, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(content, maxSize), content}, nil
+}
+
+// FilePath returns the path in a GEDZIP archive of the file a FILE structure refers to, the reverse of
+// FileReference. References to files outside the archive, such as URLs, return an error wrapping fs.ErrNotExist.
+func FilePath(reference string) (string, error) {
+	name, err := url.PathUnescape(reference)
+	if err != nil || strings.Contains(reference, ":") || strings.HasPrefix(name, "/") {
+		return "", fmt.Errorf("%s is not in the archive: %w", reference, fs.ErrNotExist)
+	}
+	return path.Clean(name), nil
+}
+
+// FileReference returns the value of a FILE structure referring to the file at a path in a GEDZIP archive
+func FileReference(filePath string) string {
+	segments := strings.Split(filePath, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// WriteArchive writes a GEDZIP archive with a GEDCOM 7.0 file of the header and records and the files they refer to
+func WriteArchive(w io.Writer, header *gedcom.Record, records []*gedcom.Record, files []ArchiveFile) error {
+	zw := zip.NewWriter(w)
+
+	dataset, err := zw.Create(DatasetName)
+	if err != nil {
+		return fmt.Errorf("failed to write GEDZIP archive: %w", err)
+	}
+	if err := Write(dataset, header, records); err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if err := writeArchiveFile(zw, file); err != nil {
+			return err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write GEDZIP archive: %w", err)
+	}
+	return nil
+}
+
+// writeArchiveFile copies a file into an archive. Files are stored as they are, since media files are
+// compressed already.
+func writeArchiveFile(zw *zip.Writer, file ArchiveFile) error {
+	content, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", file.Path, err)
+	}
+	defer func() {
+		_ = content.Close()
+	}()
+
+	entry, err := zw.CreateHeader(&zip.FileHeader{Name: file.Path, Method: zip.Store})
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", file.Path, err)
+	}
+	if _, err := io.Copy(entry, content); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file.Path, err)
+	}
+	return nil
+}
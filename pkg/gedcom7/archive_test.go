@@ -0,0 +1,147 @@
+package gedcom7
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rogerwesterbo/familytree/pkg/gedcom"
+)
+
+// testArchive writes a GEDZIP archive with a person referring to a photo of size bytes
+func testArchive(t *testing.T, size int) []byte {
+	t.Helper()
+
+	header := NewHeader("familytree", "@U1@", nil, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	obje := &gedcom.Record{XRef: "@O1@", Tag: "OBJE"}
+	obje.Add("FILE", FileReference("media/Åse Berg.jpg")).Add("FORM", "image/jpeg")
+	indi := &gedcom.Record{XRef: "@I1@", Tag: "INDI"}
+	indi.Add("OBJE", "@O1@")
+
+	photo := bytes.Repeat([]byte("x"), size)
+	files := []ArchiveFile{{
+		Path: "media/Åse Berg.jpg",
+		Open: func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(photo)), nil },
+	}}
+
+	var buf bytes.Buffer
+	if err := WriteArchive(&buf, header, []*gedcom.Record{indi, obje}, files); err != nil {
+		t.Fatalf("WriteArchive returned error: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	archive, err := ReadArchive(bytes.NewReader(testArchive(t, 100)), 1<<20)
+	if err != nil {
+		t.Fatalf("ReadArchive returned error: %v", err)
+	}
+
+	reference := archive.Find("@O1@").Text("FILE")
+	content, err := archive.Open(reference, 1<<20)
+	if err != nil {
+		t.Fatalf("Open(%q) returned error: %v", reference, err)
+	}
+	defer func() {
+		_ = content.Close()
+	}()
+	data, err := io.ReadAll(content)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", reference, err)
+	}
+	if len(data) != 100 {
+		t.Errorf("read %d bytes of %s, want 100", len(data), reference)
+	}
+}
+
+func TestArchiveOpen(t *testing.T) {
+	archive, err := ReadArchive(bytes.NewReader(testArchive(t, 100)), 1<<20)
+	if err != nil {
+		t.Fatalf("ReadArchive returned error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		reference string
+		maxSize   int64
+		wantErr   error
+	}{
+		{name: "file", reference: "media/%C3%85se%20Berg.jpg", maxSize: 100},
+		{name: "file larger than allowed", reference: "media/%C3%85se%20Berg.jpg", maxSize: 99, wantErr: ErrFileTooLarge},
+		{name: "missing file", reference: "media/other.jpg", maxSize: 100, wantErr: fs.ErrNotExist},
+		{name: "dataset", reference: DatasetName, maxSize: 1 << 20, wantErr: fs.ErrNotExist},
+		{name: "URL", reference: "https://example.com/photo.jpg", maxSize: 100, wantErr: fs.ErrNotExist},
+		{name: "absolute path", reference: "/etc/passwd", maxSize: 100, wantErr: fs.ErrNotExist},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := archive.Open(tt.reference, tt.maxSize)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Open(%q) returned %v, want %v", tt.reference, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Open(%q) returned error: %v", tt.reference, err)
+			}
+			_ = content.Close()
+		})
+	}
+}
+
+func TestReadArchiveInvalid(t *testing.T) {
+	var noDataset bytes.Buffer
+	zw := zip.NewWriter(&noDataset)
+	if _, err := zw.Create("photo.jpg"); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		data           []byte
+		maxDatasetSize int64
+	}{
+		{name: "not a zip file", data: []byte("0 HEAD\n0 TRLR\n"), maxDatasetSize: 1 << 20},
+		{name: "no dataset", data: noDataset.Bytes(), maxDatasetSize: 1 << 20},
+		{name: "dataset larger than allowed", data: testArchive(t, 0), maxDatasetSize: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadArchive(bytes.NewReader(tt.data), tt.maxDatasetSize)
+			if !errors.Is(err, gedcom.ErrInvalidFile) {
+				t.Errorf("ReadArchive returned %v, want ErrInvalidFile", err)
+			}
+		})
+	}
+}
+
+func TestFileReference(t *testing.T) {
+	tests := []string{"photo.jpg", "media/Åse Berg.jpg", "media/100% #1?.png", "a/b/c.pdf"}
+
+	for _, filePath := range tests {
+		t.Run(filePath, func(t *testing.T) {
+			reference := FileReference(filePath)
+			if strings.ContainsAny(reference, " #?") {
+				t.Errorf("FileReference(%q) = %q is not escaped", filePath, reference)
+			}
+			got, err := FilePath(reference)
+			if err != nil {
+				t.Fatalf("FilePath(%q) returned error: %v", reference, err)
+			}
+			if got != filePath {
+				t.Errorf("FilePath(FileReference(%q)) = %q", filePath, got)
+			}
+		})
+	}
+}
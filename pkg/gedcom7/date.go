@@ -0,0 +1,42 @@
+package gedcom7
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/pkg/gedcom"
+)
+
+// calendarEscapes maps the calendar names of GEDCOM 7.0 dates to the calendar escapes of GEDCOM 5.5.1
+var calendarEscapes = map[string]string{
+	"GREGORIAN": "@#DGREGORIAN@",
+	"JULIAN":    "@#DJULIAN@",
+	"FRENCH_R":  "@#DFRENCH R@",
+	"HEBREW":    "@#DHEBREW@",
+}
+
+// ParseDate reads a GEDCOM 7.0 date value, such as "12 MAY 1843", "ABT 1843", "FROM 1801 TO 1805" or
+// "JULIAN 1 JAN 1700", as package gedcom reads GEDCOM 5.5.1 dates: periods are taken as the range they span
+// and Julian calendar dates are converted to the Gregorian calendar. GEDCOM 7.0 writes the date phrase as
+// the PHRASE structure below the date rather than in the value, so the returned Phrase is phrase.
+func ParseDate(value, phrase string) (gedcom.Date, error) {
+	fields := strings.Fields(strings.ToUpper(value))
+	for i, field := range fields {
+		switch {
+		case calendarEscapes[field] != "":
+			fields[i] = calendarEscapes[field]
+		case field == "BCE":
+			fields[i] = "B.C."
+		case strings.HasPrefix(field, "_"):
+			return gedcom.Date{}, fmt.Errorf("unsupported calendar or epoch %s in date %q", field, value)
+		}
+	}
+
+	date, err := gedcom.ParseDate(strings.Join(fields, " "))
+	if err != nil {
+		return gedcom.Date{}, err
+	}
+	date.Phrase = strings.TrimSpace(phrase)
+
+	return date, nil
+}
@@ -0,0 +1,49 @@
+package gedcom7
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		phrase  string
+		want    string
+		wantErr bool
+	}{
+		{name: "exact date", value: "12 MAY 1843", want: "1843-05-12"},
+		{name: "about", value: "ABT 1843", want: "ABT 1843"},
+		{name: "period", value: "FROM 1801 TO 1805", want: "BET 1801 AND 1805"},
+		{name: "Gregorian calendar", value: "GREGORIAN MAR 1790", want: "1790-03"},
+		{name: "Julian calendar", value: "JULIAN 1 JAN 1700", want: "1700-01-11"},
+		{name: "phrase", value: "1850", phrase: " about the emigration ", want: "1850"},
+		{name: "phrase only", value: "", phrase: "before the war", want: ""},
+		{name: "Hebrew calendar", value: "HEBREW 5600", wantErr: true},
+		{name: "French republican calendar", value: "FRENCH_R 1 VEND 12", wantErr: true},
+		{name: "extension calendar", value: "_MAYAN 1 1 1", wantErr: true},
+		{name: "before the common era", value: "44 BCE", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDate(tt.value, tt.phrase)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseDate(%q) = %q, want an error", tt.value, got.Value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDate(%q) returned error: %v", tt.value, err)
+			}
+			if got.Value.String() != tt.want {
+				t.Errorf("ParseDate(%q) = %q, want %q", tt.value, got.Value, tt.want)
+			}
+			if want := strings.TrimSpace(tt.phrase); got.Phrase != want {
+				t.Errorf("ParseDate(%q) phrase = %q, want %q", tt.value, got.Phrase, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Package gedcom7 reads and writes GEDCOM 7.0 files and GEDZIP archives, which bundle a GEDCOM 7.0 file with
+// the media files it refers to. Records are the same as those of GEDCOM 5.5.1 files read by package gedcom.
+package gedcom7
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rogerwesterbo/familytree/pkg/gedcom"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Version is the GEDCOM version written to files
+const Version = "7.0"
+
+// extensionBase is the base of the URIs defining the extension tags this package documents
+const extensionBase = "https://github.com/rogerwesterbo/familytree/gedcom/v7/"
+
+// Extension tags telling how a child is related to the husband and to the wife of a family, written on FAM.CHIL
+// when FAMC.PEDI cannot tell them apart. Many desktop programs use the same tags in GEDCOM 5.5.1 files.
+const (
+	TagFatherRelationship = "_FREL"
+	TagMotherRelationship = "_MREL"
+)
+
+// ExtensionURIs holds the URIs defining the extension tags this package documents, declared in HEAD.SCHMA
+var ExtensionURIs = map[string]string{
+	TagFatherRelationship: extensionBase + TagFatherRelationship,
+	TagMotherRelationship: extensionBase + TagMotherRelationship,
+}
+
+// Document is a parsed GEDCOM 7.0 file
+type Document struct {
+	*gedcom.Document
+	// Schema holds the URIs defining the extension tags of the file by tag, from HEAD.SCHMA
+	Schema map[string]string
+}
+
+// Tag returns the tag this package documents for an extension tag of the file, found through the URI the file
+// defines it with, so files may use tags of their own for the same extension. Other tags are returned as they are.
+func (d *Document) Tag(tag string) string {
+	uri, found := d.Schema[tag]
+	if !found {
+		return tag
+	}
+	for known, knownURI := range ExtensionURIs {
+		if knownURI == uri {
+			return known
+		}
+	}
+	return tag
+}
+
+// Parse reads a GEDCOM 7.0 file, which is always UTF-8 and may start with a byte order mark.
+// Lines that cannot be read are left out with a warning.
+func Parse(r io.Reader) (*Document, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GEDCOM file: %w", err)
+	}
+	if !utf8.Valid(data) {
+		return nil, fmt.Errorf("%w: GEDCOM 7.0 files must be UTF-8", gedcom.ErrInvalidFile)
+	}
+	text := norm.NFC.String(strings.TrimPrefix(string(data), "\ufeff"))
+
+	// GEDCOM 7.0 has no CONC, but files converted from GEDCOM 5.5.1 still use it, so lines nest the same way
+	parsed, err := gedcom.Build(splitLines(text), parseLine)
+	if err != nil {
+		return nil, err
+	}
+	parsed.Encoding = gedcom.EncodingUTF8
+
+	doc := &Document{Document: parsed, Schema: make(map[string]string)}
+	if !strings.HasPrefix(doc.Version, "7.") {
+		return nil, fmt.Errorf("%w: the file is GEDCOM %s, not GEDCOM 7", gedcom.ErrInvalidFile, doc.Version)
+	}
+
+	if schma := doc.Header.First("SCHMA"); schma != nil {
+		for _, tag := range schma.All("TAG") {
+			name, uri, _ := strings.Cut(strings.TrimSpace(tag.Value), " ")
+			doc.Schema[name] = strings.TrimSpace(uri)
+		}
+	}
+
+	return doc, nil
+}
+
+// parseLine parses a line of the form "level [@xref@] tag [value]". Unlike GEDCOM 5.5.1, only a leading @
+// of a value is escaped as @@.
+func parseLine(line string) (*gedcom.Record, error) {
+	levelText, rest, _ := strings.Cut(line, " ")
+	level, err := strconv.Atoi(levelText)
+	if err != nil || level < 0 {
+		return nil, fmt.Errorf("invalid level %q", levelText)
+	}
+
+	record := &gedcom.Record{Level: level}
+	if strings.HasPrefix(rest, "@") {
+		record.XRef, rest, _ = strings.Cut(rest, " ")
+		if !gedcom.IsPointer(record.XRef) {
+			return nil, fmt.Errorf("invalid cross-reference %q", record.XRef)
+		}
+	}
+
+	record.Tag, record.Value, _ = strings.Cut(rest, " ")
+	if record.Tag == "" {
+		return nil, fmt.Errorf("missing tag")
+	}
+	if strings.HasPrefix(record.Value, "@@") {
+		record.Value = record.Value[1:]
+	}
+
+	return record, nil
+}
+
+// splitLines splits text on the line terminators GEDCOM 7.0 allows: CR, LF and CR LF
+func splitLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	return strings.Split(text, "\n")
+}
@@ -0,0 +1,156 @@
+package gedcom7
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rogerwesterbo/familytree/pkg/gedcom"
+)
+
+const testHeader = "0 HEAD\n1 GEDC\n2 VERS 7.0\n"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		xref     string
+		path     []string
+		want     string
+		warnings int
+	}{
+		{
+			name:  "nested value",
+			input: testHeader + "0 @I1@ INDI\n1 BIRT\n2 DATE 12 MAY 1843\n0 TRLR\n",
+			xref:  "@I1@",
+			path:  []string{"BIRT", "DATE"},
+			want:  "12 MAY 1843",
+		},
+		{
+			name:  "continuation line",
+			input: testHeader + "0 @N1@ SNOTE First line\n1 CONT second line\n0 TRLR\n",
+			xref:  "@N1@",
+			want:  "First line\nsecond line",
+		},
+		{
+			name:  "continuation line converted from GEDCOM 5.5.1",
+			input: testHeader + "0 @N1@ SNOTE First\n1 CONC  line\n0 TRLR\n",
+			xref:  "@N1@",
+			want:  "First line",
+		},
+		{
+			name:  "byte order mark and CR LF line endings",
+			input: "\ufeff" + strings.ReplaceAll(testHeader+"0 @I1@ INDI\n1 NAME Åse /Berg/\n0 TRLR\n", "\n", "\r\n"),
+			xref:  "@I1@",
+			path:  []string{"NAME"},
+			want:  "Åse /Berg/",
+		},
+		{
+			name:  "decomposed characters",
+			input: testHeader + "0 @I1@ INDI\n1 NAME A\u030ase /Berg/\n0 TRLR\n",
+			xref:  "@I1@",
+			path:  []string{"NAME"},
+			want:  "Åse /Berg/",
+		},
+		{
+			name:  "leading at sign",
+			input: testHeader + "0 @I1@ INDI\n1 NOTE @@home and @@away\n0 TRLR\n",
+			xref:  "@I1@",
+			path:  []string{"NOTE"},
+			want:  "@home and @@away",
+		},
+		{
+			name:  "pointer",
+			input: testHeader + "0 @I1@ INDI\n1 FAMC @F1@\n0 @F1@ FAM\n0 TRLR\n",
+			xref:  "@I1@",
+			path:  []string{"FAMC"},
+			want:  "@F1@",
+		},
+		{
+			name:     "skipped level",
+			input:    testHeader + "0 @I1@ INDI\n2 DATE 1843\n1 SEX M\n0 TRLR\n",
+			xref:     "@I1@",
+			path:     []string{"SEX"},
+			want:     "M",
+			warnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse returned error: %v", err)
+			}
+			if doc.Encoding != gedcom.EncodingUTF8 {
+				t.Errorf("Encoding = %q, want %q", doc.Encoding, gedcom.EncodingUTF8)
+			}
+			if len(doc.Warnings) != tt.warnings {
+				t.Errorf("got warnings %q, want %d", doc.Warnings, tt.warnings)
+			}
+
+			record := doc.Find(tt.xref)
+			for _, tag := range tt.path {
+				if record == nil {
+					break
+				}
+				record = record.First(tag)
+			}
+			if record == nil {
+				t.Fatalf("%s %s not found", tt.xref, strings.Join(tt.path, "."))
+			}
+			if record.Value != tt.want {
+				t.Errorf("%s %s = %q, want %q", tt.xref, strings.Join(tt.path, "."), record.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInvalidFile(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: ""},
+		{name: "no header", input: "0 @I1@ INDI\n0 TRLR\n"},
+		{name: "GEDCOM 5.5.1", input: "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n0 TRLR\n"},
+		{name: "no version", input: "0 HEAD\n0 TRLR\n"},
+		{name: "not UTF-8", input: testHeader + "0 @I1@ INDI\n1 NAME \xc5se\n0 TRLR\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input))
+			if !errors.Is(err, gedcom.ErrInvalidFile) {
+				t.Errorf("Parse returned %v, want ErrInvalidFile", err)
+			}
+		})
+	}
+}
+
+func TestDocumentTag(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 7.0\n1 SCHMA\n2 TAG _FATHER " + ExtensionURIs[TagFatherRelationship] +
+		"\n2 TAG _OTHER https://example.com/other\n0 TRLR\n"
+	doc, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{tag: "_FATHER", want: TagFatherRelationship},
+		{tag: "_OTHER", want: "_OTHER"},
+		{tag: "_UNDECLARED", want: "_UNDECLARED"},
+		{tag: "BIRT", want: "BIRT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			if got := doc.Tag(tt.tag); got != tt.want {
+				t.Errorf("Tag(%q) = %q, want %q", tt.tag, got, tt.want)
+			}
+		})
+	}
+}
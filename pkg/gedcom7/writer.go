@@ -0,0 +1,88 @@
+package gedcom7
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rogerwesterbo/familytree/pkg/gedcom"
+)
+
+// NewHeader creates the header of a GEDCOM 7.0 file written by the program named source on date and submitted
+// by the SUBM record with the cross-reference submitter, declaring the extension tags in schema by their URIs
+func NewHeader(source, submitter string, schema map[string]string, date time.Time) *gedcom.Record {
+	header := &gedcom.Record{Tag: "HEAD"}
+	header.Add("GEDC", "").Add("VERS", Version)
+	if len(schema) > 0 {
+		schma := header.Add("SCHMA", "")
+		for _, tag := range slices.Sorted(maps.Keys(schema)) {
+			schma.Add("TAG", tag+" "+schema[tag])
+		}
+	}
+	header.Add("SOUR", source)
+	header.Add("DATE", strings.ToUpper(date.Format("2 Jan 2006")))
+	header.Add("SUBM", submitter)
+	return header
+}
+
+// Write writes a GEDCOM 7.0 file with a header, the level 0 records and the trailer. The levels are taken from
+// the nesting of the records; values with line breaks continue on CONT lines. GEDCOM 7.0 has no line length
+// limit, so values are never split with CONC.
+func Write(w io.Writer, header *gedcom.Record, records []*gedcom.Record) error {
+	bw := bufio.NewWriter(w)
+
+	writeRecord(bw, header, 0)
+	for _, record := range records {
+		writeRecord(bw, record, 0)
+	}
+	writeLine(bw, 0, "", "TRLR", "")
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write GEDCOM: %w", err)
+	}
+	return nil
+}
+
+// writeRecord writes a record at a level followed by its subrecords
+func writeRecord(bw *bufio.Writer, record *gedcom.Record, level int) {
+	value := strings.ReplaceAll(strings.ReplaceAll(record.Value, "\r\n", "\n"), "\r", "\n")
+	for i, line := range strings.Split(value, "\n") {
+		if i == 0 {
+			if !gedcom.IsPointer(value) {
+				line = escape(line)
+			}
+			writeLine(bw, level, record.XRef, record.Tag, line)
+		} else {
+			writeLine(bw, level+1, "", "CONT", escape(line))
+		}
+	}
+
+	for _, sub := range record.Subrecords {
+		writeRecord(bw, sub, level+1)
+	}
+}
+
+// escape doubles the leading @ of a value, the only @ GEDCOM 7.0 escapes
+func escape(value string) string {
+	if strings.HasPrefix(value, "@") {
+		return "@" + value
+	}
+	return value
+}
+
+// writeLine writes a single GEDCOM line
+func writeLine(bw *bufio.Writer, level int, xref, tag, value string) {
+	fmt.Fprintf(bw, "%d ", level)
+	if xref != "" {
+		bw.WriteString(xref + " ")
+	}
+	bw.WriteString(tag)
+	if value != "" {
+		bw.WriteString(" " + value)
+	}
+	bw.WriteString("\n")
+}
@@ -0,0 +1,70 @@
+package gedcom7
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rogerwesterbo/familytree/pkg/gedcom"
+)
+
+func TestWriteRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "plain", value: "Ole Hansen"},
+		{name: "line breaks", value: "First line\nsecond line\n\nfourth line"},
+		{name: "CR LF line breaks", value: "First line\r\nsecond line"},
+		{name: "at signs", value: "ole@example.com"},
+		{name: "leading at sign", value: "@home"},
+		{name: "leading at sign on a continued line", value: "first\n@second"},
+		{name: "long value", value: strings.Repeat("word ", 100) + "end"},
+		{name: "non-ASCII", value: "Ærlig Åse Østby"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := map[string]string{TagFatherRelationship: ExtensionURIs[TagFatherRelationship]}
+			header := NewHeader("familytree", "@U1@", schema, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+			note := &gedcom.Record{XRef: "@N1@", Tag: "SNOTE", Value: tt.value}
+			indi := &gedcom.Record{XRef: "@I1@", Tag: "INDI"}
+			indi.Add("NOTE", tt.value)
+			indi.Add("FAMC", "@F1@")
+
+			var buf bytes.Buffer
+			if err := Write(&buf, header, []*gedcom.Record{note, indi}); err != nil {
+				t.Fatalf("Write returned error: %v", err)
+			}
+			if strings.Contains(buf.String(), " CONC ") {
+				t.Errorf("Write split a value with CONC:\n%s", buf.String())
+			}
+
+			doc, err := Parse(&buf)
+			if err != nil {
+				t.Fatalf("Parse returned error: %v", err)
+			}
+			if len(doc.Warnings) != 0 {
+				t.Errorf("Parse warned %q", doc.Warnings)
+			}
+			if doc.Version != Version {
+				t.Errorf("Version = %q, want %q", doc.Version, Version)
+			}
+			if doc.Schema[TagFatherRelationship] != ExtensionURIs[TagFatherRelationship] {
+				t.Errorf("Schema = %v, want %s declared", doc.Schema, TagFatherRelationship)
+			}
+
+			want := strings.ReplaceAll(tt.value, "\r\n", "\n")
+			if got := doc.Find("@N1@").Value; got != want {
+				t.Errorf("SNOTE record = %q, want %q", got, want)
+			}
+			if got := doc.Find("@I1@").First("NOTE").Value; got != want {
+				t.Errorf("INDI.NOTE = %q, want %q", got, want)
+			}
+			if got := doc.Find("@I1@").Text("FAMC"); got != "@F1@" {
+				t.Errorf("INDI.FAMC = %q, want @F1@", got)
+			}
+		})
+	}
+}
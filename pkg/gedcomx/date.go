@@ -0,0 +1,103 @@
+package gedcomx
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+// FormatDate writes a genealogical date in the formal GEDCOM X date format: "+1843-05-12", "A+1843" for
+// approximate dates, "/+1790-03" before, "+1790/" after and "+1801/+1805" between two dates
+func FormatDate(date interfaces.GenealogicalDate) string {
+	switch date.Qualifier {
+	case "":
+		if date.IsZero() {
+			return ""
+		}
+		return formatSimpleDate(date.Date)
+	case interfaces.DateQualifierBetween:
+		return formatSimpleDate(date.Date) + "/" + formatSimpleDate(date.End)
+	case interfaces.DateQualifierBefore:
+		return "/" + formatSimpleDate(date.Date)
+	case interfaces.DateQualifierAfter:
+		return formatSimpleDate(date.Date) + "/"
+	default:
+		// About, calculated and estimated dates are all approximate
+		return "A" + formatSimpleDate(date.Date)
+	}
+}
+
+// formatSimpleDate writes a date part as "+1843-05-12", "+1843-05" or "+1843"
+func formatSimpleDate(part interfaces.DatePart) string {
+	switch {
+	case part.Month == 0:
+		return fmt.Sprintf("%+05d", part.Year)
+	case part.Day == 0:
+		return fmt.Sprintf("%+05d-%02d", part.Year, part.Month)
+	default:
+		return fmt.Sprintf("%+05d-%02d-%02d", part.Year, part.Month, part.Day)
+	}
+}
+
+// ParseDate reads a formal GEDCOM X date. Approximate ranges are read as the range they span; recurring
+// dates and durations are not supported.
+func ParseDate(formal string) (interfaces.GenealogicalDate, error) {
+	formal = strings.TrimSpace(formal)
+	if formal == "" {
+		return interfaces.GenealogicalDate{}, nil
+	}
+
+	rest, approximate := strings.CutPrefix(formal, "A")
+	start, end, isRange := strings.Cut(rest, "/")
+
+	var fields []string
+	for _, simple := range []string{start, end} {
+		if simple == "" {
+			fields = append(fields, "")
+			continue
+		}
+		field, err := simpleDate(simple)
+		if err != nil {
+			return interfaces.GenealogicalDate{}, fmt.Errorf("invalid date %q: %v", formal, err)
+		}
+		fields = append(fields, field)
+	}
+
+	// Rewrite the date in the form genealogical dates are parsed from, such as "BET 1801 AND 1805"
+	var text string
+	switch {
+	case !isRange && approximate:
+		text = interfaces.DateQualifierAbout + " " + fields[0]
+	case !isRange:
+		text = fields[0]
+	case fields[0] == "" && fields[1] == "":
+		return interfaces.GenealogicalDate{}, fmt.Errorf("invalid date %q", formal)
+	case fields[0] == "":
+		text = interfaces.DateQualifierBefore + " " + fields[1]
+	case fields[1] == "":
+		text = interfaces.DateQualifierAfter + " " + fields[0]
+	default:
+		text = fmt.Sprintf("%s %s AND %s", interfaces.DateQualifierBetween, fields[0], fields[1])
+	}
+
+	date, err := interfaces.ParseGenealogicalDate(text)
+	if err != nil {
+		return interfaces.GenealogicalDate{}, fmt.Errorf("invalid date %q: %v", formal, err)
+	}
+	return date, nil
+}
+
+// simpleDate returns the ISO form of a simple GEDCOM X date, such as "1843-05-12" for "+1843-05-12T10:00:00",
+// leaving out the time of day
+func simpleDate(simple string) (string, error) {
+	if strings.HasPrefix(simple, "R") || strings.HasPrefix(simple, "P") {
+		return "", fmt.Errorf("recurring dates and durations are not supported")
+	}
+	simple, _, _ = strings.Cut(simple, "T")
+	year, found := strings.CutPrefix(simple, "+")
+	if !found {
+		return "", fmt.Errorf("dates must start with + for years of the common era")
+	}
+	return year, nil
+}
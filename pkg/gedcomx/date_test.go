@@ -0,0 +1,102 @@
+package gedcomx
+
+import (
+	"testing"
+
+	"github.com/rogerwesterbo/familytree/pkg/interfaces"
+)
+
+func TestFormatDate(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "", want: ""},
+		{input: "1843", want: "+1843"},
+		{input: "1843-05", want: "+1843-05"},
+		{input: "1843-05-12", want: "+1843-05-12"},
+		{input: "0842", want: "+0842"},
+		{input: "ABT 1843", want: "A+1843"},
+		{input: "CAL 1843-05", want: "A+1843-05"},
+		{input: "EST 1843", want: "A+1843"},
+		{input: "BEF 1790-03", want: "/+1790-03"},
+		{input: "AFT 1790", want: "+1790/"},
+		{input: "BET 1801 AND 1805-06-01", want: "+1801/+1805-06-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			date, err := interfaces.ParseGenealogicalDate(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := FormatDate(date); got != tt.want {
+				t.Errorf("FormatDate(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "", want: ""},
+		{input: "+1843", want: "1843"},
+		{input: "+1843-05-12", want: "1843-05-12"},
+		{input: "+1843-05-12T10:30:00", want: "1843-05-12"},
+		{input: "A+1843", want: "ABT 1843"},
+		{input: "/+1790-03", want: "BEF 1790-03"},
+		{input: "+1790/", want: "AFT 1790"},
+		{input: "+1801/+1805", want: "BET 1801 AND 1805"},
+		{input: "A+1801/+1805", want: "BET 1801 AND 1805"},
+		{input: "/", wantErr: true},
+		{input: "1843", wantErr: true},
+		{input: "-0044", wantErr: true},
+		{input: "R3/+1843/P1Y", wantErr: true},
+		{input: "+1843/P10Y", wantErr: true},
+		{input: "+1843-13", wantErr: true},
+		{input: "+1805/+1801", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDate(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseDate(%q) = %q, want an error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDate(%q) returned error: %v", tt.input, err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("ParseDate(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateRoundTrip(t *testing.T) {
+	dates := []string{"1843", "1843-05", "1843-05-12", "ABT 1843", "BEF 1790-03", "AFT 1790", "BET 1801 AND 1805-06-01"}
+
+	for _, input := range dates {
+		t.Run(input, func(t *testing.T) {
+			date, err := interfaces.ParseGenealogicalDate(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			formal := FormatDate(date)
+			got, err := ParseDate(formal)
+			if err != nil {
+				t.Fatalf("ParseDate(%q) returned error: %v", formal, err)
+			}
+			if got != date {
+				t.Errorf("ParseDate(FormatDate(%q)) = %q via %q", input, got, formal)
+			}
+		})
+	}
+}
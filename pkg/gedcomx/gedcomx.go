@@ -0,0 +1,264 @@
+// Package gedcomx reads and writes GEDCOM X documents in their JSON serialization. Only the parts of the
+// conceptual model a family tree needs are covered: persons, relationships, source descriptions, places and agents.
+package gedcomx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MediaType is the media type of GEDCOM X JSON documents
+const MediaType = "application/x-gedcomx-v1+json"
+
+// ErrInvalidDocument is returned when the input is not a GEDCOM X JSON document
+var ErrInvalidDocument = errors.New("invalid GEDCOM X document")
+
+// typeBase is the base of the URIs of the types defined by GEDCOM X
+const typeBase = "http://gedcomx.org/"
+
+// extensionBase is the base of the URIs of the types this package adds for data GEDCOM X has no type for
+const extensionBase = "https://github.com/rogerwesterbo/familytree/gedcomx/"
+
+// Gender types
+const (
+	GenderMale    = typeBase + "Male"
+	GenderFemale  = typeBase + "Female"
+	GenderUnknown = typeBase + "Unknown"
+)
+
+// Name types. Farm and patronymic names are extensions.
+const (
+	NameTypeBirth      = typeBase + "BirthName"
+	NameTypeMarried    = typeBase + "MarriedName"
+	NameTypeAlsoKnown  = typeBase + "AlsoKnownAs"
+	NameTypeNickname   = typeBase + "Nickname"
+	NameTypeReligious  = typeBase + "ReligiousName"
+	NameTypeFarm       = extensionBase + "FarmName"
+	NameTypePatronymic = extensionBase + "PatronymicName"
+)
+
+// Name part types
+const (
+	NamePartGiven   = typeBase + "Given"
+	NamePartSurname = typeBase + "Surname"
+	NamePartPrefix  = typeBase + "Prefix"
+	NamePartSuffix  = typeBase + "Suffix"
+)
+
+// Relationship types
+const (
+	RelationshipCouple      = typeBase + "Couple"
+	RelationshipParentChild = typeBase + "ParentChild"
+)
+
+// Fact types of persons
+const (
+	FactBirth        = typeBase + "Birth"
+	FactDeath        = typeBase + "Death"
+	FactBaptism      = typeBase + "Baptism"
+	FactChristening  = typeBase + "Christening"
+	FactConfirmation = typeBase + "Confirmation"
+	FactEmigration   = typeBase + "Emigration"
+	FactCensus       = typeBase + "Census"
+	FactResidence    = typeBase + "Residence"
+	FactOccupation   = typeBase + "Occupation"
+	FactBurial       = typeBase + "Burial"
+)
+
+// Fact types of couple relationships
+const (
+	FactMarriage            = typeBase + "Marriage"
+	FactDivorce             = typeBase + "Divorce"
+	FactCivilUnion          = typeBase + "CivilUnion"
+	FactDomesticPartnership = typeBase + "DomesticPartnership"
+	FactEngagement          = typeBase + "Engagement"
+)
+
+// Fact types of parent-child relationships
+const (
+	FactBiologicalParent = typeBase + "BiologicalParent"
+	FactAdoptiveParent   = typeBase + "AdoptiveParent"
+	FactStepParent       = typeBase + "StepParent"
+	FactFosterParent     = typeBase + "FosterParent"
+	FactGuardianParent   = typeBase + "GuardianParent"
+)
+
+// Document is a GEDCOM X document
+type Document struct {
+	Description        string              `json:"description,omitempty"`
+	Attribution        *Attribution        `json:"attribution,omitempty"`
+	Persons            []Person            `json:"persons,omitempty"`
+	Relationships      []Relationship      `json:"relationships,omitempty"`
+	SourceDescriptions []SourceDescription `json:"sourceDescriptions,omitempty"`
+	Agents             []Agent             `json:"agents,omitempty"`
+	Places             []PlaceDescription  `json:"places,omitempty"`
+}
+
+// Attribution tells who contributed data and when
+type Attribution struct {
+	Contributor *ResourceReference `json:"contributor,omitempty"`
+	// Modified is the time of the contribution in milliseconds since the Unix epoch
+	Modified int64 `json:"modified,omitempty"`
+}
+
+// Agent is a person or organization contributing data
+type Agent struct {
+	ID    string      `json:"id,omitempty"`
+	Names []TextValue `json:"names,omitempty"`
+}
+
+// Person is a person with the names, gender and facts concluded about them
+type Person struct {
+	ID string `json:"id,omitempty"`
+	// Private is set for persons whose details are withheld
+	Private bool              `json:"private,omitempty"`
+	Gender  *Gender           `json:"gender,omitempty"`
+	Names   []Name            `json:"names,omitempty"`
+	Facts   []Fact            `json:"facts,omitempty"`
+	Sources []SourceReference `json:"sources,omitempty"`
+	Notes   []Note            `json:"notes,omitempty"`
+}
+
+// Gender is the gender of a person
+type Gender struct {
+	Type string `json:"type"`
+}
+
+// Name is a name of a person in one or more forms
+type Name struct {
+	Type      string     `json:"type,omitempty"`
+	Preferred bool       `json:"preferred,omitempty"`
+	NameForms []NameForm `json:"nameForms,omitempty"`
+	Date      *Date      `json:"date,omitempty"`
+}
+
+// NameForm is a name written out in full and in parts
+type NameForm struct {
+	FullText string     `json:"fullText,omitempty"`
+	Parts    []NamePart `json:"parts,omitempty"`
+}
+
+// NamePart is a part of a name, such as the given name or the surname
+type NamePart struct {
+	Type  string `json:"type,omitempty"`
+	Value string `json:"value"`
+}
+
+// Fact is something that happened to or is true of a person or relationship
+type Fact struct {
+	Type    string            `json:"type"`
+	Date    *Date             `json:"date,omitempty"`
+	Place   *PlaceReference   `json:"place,omitempty"`
+	Value   string            `json:"value,omitempty"`
+	Sources []SourceReference `json:"sources,omitempty"`
+	Notes   []Note            `json:"notes,omitempty"`
+}
+
+// Date is a date as written in the record and in the formal GEDCOM X date format, such as "A+1843"
+type Date struct {
+	Original string `json:"original,omitempty"`
+	Formal   string `json:"formal,omitempty"`
+}
+
+// PlaceReference is a place as written in the record, optionally referring to a place description
+type PlaceReference struct {
+	Original string `json:"original,omitempty"`
+	// Description refers to a place description, such as "#P1"
+	Description string `json:"description,omitempty"`
+}
+
+// Relationship is a couple or parent-child relationship between two persons. In parent-child relationships
+// Person1 is the parent.
+type Relationship struct {
+	ID      string            `json:"id,omitempty"`
+	Type    string            `json:"type"`
+	Person1 ResourceReference `json:"person1"`
+	Person2 ResourceReference `json:"person2"`
+	Facts   []Fact            `json:"facts,omitempty"`
+	Sources []SourceReference `json:"sources,omitempty"`
+	Notes   []Note            `json:"notes,omitempty"`
+}
+
+// ResourceReference refers to a resource, such as "#P1" for the element with ID P1 in the same document
+type ResourceReference struct {
+	Resource   string `json:"resource,omitempty"`
+	ResourceID string `json:"resourceId,omitempty"`
+}
+
+// SourceDescription describes a source, or a part of one, such as a page, when ComponentOf is set
+type SourceDescription struct {
+	ID           string             `json:"id,omitempty"`
+	Citations    []TextValue        `json:"citations,omitempty"`
+	Titles       []TextValue        `json:"titles,omitempty"`
+	About        string             `json:"about,omitempty"`
+	ComponentOf  *SourceReference   `json:"componentOf,omitempty"`
+	Descriptions []TextValue        `json:"descriptions,omitempty"`
+	Notes        []Note             `json:"notes,omitempty"`
+	Repository   *ResourceReference `json:"repository,omitempty"`
+}
+
+// SourceReference refers to a source description, such as "#S1"
+type SourceReference struct {
+	Description string `json:"description"`
+}
+
+// PlaceDescription describes a place within the place containing it
+type PlaceDescription struct {
+	ID           string             `json:"id,omitempty"`
+	Names        []TextValue        `json:"names,omitempty"`
+	Latitude     *float64           `json:"latitude,omitempty"`
+	Longitude    *float64           `json:"longitude,omitempty"`
+	Jurisdiction *ResourceReference `json:"jurisdiction,omitempty"`
+}
+
+// Note is a note about a person, relationship, fact or source
+type Note struct {
+	Subject string `json:"subject,omitempty"`
+	Text    string `json:"text"`
+}
+
+// TextValue is a text, optionally in a language
+type TextValue struct {
+	Lang  string `json:"lang,omitempty"`
+	Value string `json:"value"`
+}
+
+// Read reads a GEDCOM X JSON document
+func Read(r io.Reader) (*Document, error) {
+	var doc Document
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+	}
+	return &doc, nil
+}
+
+// Write writes a GEDCOM X JSON document, indented
+func Write(w io.Writer, doc *Document) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to write GEDCOM X: %w", err)
+	}
+	return nil
+}
+
+// LocalID returns the ID a reference refers to within the same document, such as P1 for "#P1",
+// or "" when it refers to another document
+func LocalID(reference string) string {
+	id, found := strings.CutPrefix(reference, "#")
+	if !found {
+		return ""
+	}
+	return id
+}
+
+// Text returns the first of the values, or "" when there are none
+func Text(values []TextValue) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(values[0].Value)
+}
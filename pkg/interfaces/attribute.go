@@ -0,0 +1,83 @@
+package interfaces
+
+import "time"
+
+// AttributeType defines a custom fact persons can have in this deployment, such as an occupation or a farm number.
+// Person values are stored in Person.Attributes under the attribute type name.
+type AttributeType struct {
+	Key         string    `json:"_key,omitempty"`
+	ID          string    `json:"_id,omitempty"`
+	Rev         string    `json:"_rev,omitempty"`
+	Name        string    `json:"name" binding:"required"`
+	Label       string    `json:"label,omitempty"`
+	ValueType   string    `json:"valueType" binding:"required"`
+	EnumValues  []string  `json:"enumValues,omitempty"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// AttributeTypeCreateRequest represents the request body for creating an attribute type
+type AttributeTypeCreateRequest struct {
+	Name        string   `json:"name" binding:"required" example:"religion"`
+	Label       string   `json:"label,omitempty" example:"Religion"`
+	ValueType   string   `json:"valueType" binding:"required" example:"enum"`
+	EnumValues  []string `json:"enumValues,omitempty" example:"Lutheran,Catholic,Quaker"`
+	Description string   `json:"description,omitempty" example:"Religious denomination"`
+}
+
+// AttributeTypeUpdateRequest represents the request body for updating an attribute type.
+// The name and value type cannot change, since stored values depend on them.
+// When enum values are given they replace the current enum values.
+type AttributeTypeUpdateRequest struct {
+	Label       string    `json:"label,omitempty" example:"Religion"`
+	EnumValues  *[]string `json:"enumValues,omitempty" example:"Lutheran,Catholic,Quaker"`
+	Description string    `json:"description,omitempty" example:"Religious denomination"`
+}
+
+// AttributeTypeResponse represents the response body for attribute type operations
+type AttributeTypeResponse struct {
+	AttributeType *AttributeType `json:"attributeType,omitempty"`
+	Message       string         `json:"message,omitempty"`
+}
+
+// AttributeTypesListResponse represents the response body for listing attribute types
+type AttributeTypesListResponse struct {
+	AttributeTypes []AttributeType `json:"attributeTypes"`
+	Count          int             `json:"count"`
+}
+
+// AttributeFilter selects persons whose attribute has a value. Date values match on their canonical form.
+type AttributeFilter struct {
+	Name  string
+	Value any
+}
+
+// Attribute value types
+const (
+	AttributeValueTypeString = "string"
+	AttributeValueTypeDate   = "date"
+	AttributeValueTypeNumber = "number"
+	AttributeValueTypeEnum   = "enum"
+	AttributeValueTypePlace  = "place"
+)
+
+// SetMetadata sets the ArangoDB metadata fields
+func (a *AttributeType) SetMetadata(key, id, rev string) {
+	a.Key = key
+	a.ID = id
+	a.Rev = rev
+}
+
+// SetTimestamps sets the created and updated timestamps
+func (a *AttributeType) SetTimestamps(createdAt, updatedAt time.Time) {
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = createdAt
+	}
+	a.UpdatedAt = updatedAt
+}
+
+// GetUpdatedAt returns the updated timestamp
+func (a AttributeType) GetUpdatedAt() time.Time {
+	return a.UpdatedAt
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import "context"
+
+// AttributeTypeRepository defines the interface for attribute type data access operations
+type AttributeTypeRepository interface {
+	Repository[AttributeType]
+
+	// FindByName finds the attribute type with the given name, or nil when there is none
+	FindByName(ctx context.Context, name string) (*AttributeType, error)
+
+	// IsUsed reports whether any person has a value for the attribute
+	IsUsed(ctx context.Context, name string) (bool, error)
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStore defines the interface for storing the content of media files by key
+type BlobStore interface {
+	// Put stores the content read from r under key, replacing any content already stored under it
+	Put(ctx context.Context, key string, r io.Reader) error
+
+	// Get opens the content stored under key. The caller must close it.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the content stored under key. Deleting missing content is not an error.
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,137 @@
+package interfaces
+
+import "time"
+
+// Citation represents a specific place in a source, such as a page and entry, supporting facts
+type Citation struct {
+	Key           string `json:"_key,omitempty"`
+	ID            string `json:"_id,omitempty"`
+	Rev           string `json:"_rev,omitempty"`
+	SourceID      string `json:"sourceId" binding:"required"`
+	Page          string `json:"page,omitempty"`
+	Entry         string `json:"entry,omitempty"`
+	URL           string `json:"url,omitempty"`
+	Transcription string `json:"transcription,omitempty"`
+	// Quality rates the evidence from 0 (unreliable) to 3 (direct and primary), as the GEDCOM QUAY tag does
+	Quality int `json:"quality"`
+	// Subjects are stored as citation link edges and filled in when the citation is read
+	Subjects  []CitationSubject `json:"subjects,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+}
+
+// CitationSubject is a person, relationship or event a citation supports, optionally narrowed to one fact
+type CitationSubject struct {
+	ID   string `json:"id" binding:"required" example:"persons/123"`
+	Fact string `json:"fact,omitempty" example:"birth"`
+}
+
+// CitationLink is the edge from a citation to the person, relationship or event it supports
+type CitationLink struct {
+	Key       string    `json:"_key,omitempty"`
+	ID        string    `json:"_id,omitempty"`
+	Rev       string    `json:"_rev,omitempty"`
+	From      string    `json:"_from" binding:"required"`
+	To        string    `json:"_to" binding:"required"`
+	Fact      string    `json:"fact,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// CitationReference is a citation supporting a person, relationship or event, with its source
+type CitationReference struct {
+	SubjectID string   `json:"subjectId" example:"persons/123"`
+	Fact      string   `json:"fact,omitempty" example:"birth"`
+	Citation  Citation `json:"citation"`
+	Source    *Source  `json:"source,omitempty"`
+}
+
+// CitationCreateRequest represents the request body for creating a citation
+type CitationCreateRequest struct {
+	SourceID      string            `json:"sourceId" binding:"required" example:"sources/123"`
+	Page          string            `json:"page,omitempty" example:"42"`
+	Entry         string            `json:"entry,omitempty" example:"Baptism no. 17"`
+	URL           string            `json:"url,omitempty" example:"https://www.digitalarkivet.no/view/255/pg00000000000042"`
+	Transcription string            `json:"transcription,omitempty" example:"Døbt: Ole, søn af Hans Olsen"`
+	Quality       int               `json:"quality" example:"3"`
+	Subjects      []CitationSubject `json:"subjects,omitempty"`
+}
+
+// CitationUpdateRequest represents the request body for updating a citation.
+// When subjects are given they replace the current subjects.
+type CitationUpdateRequest struct {
+	SourceID      string             `json:"sourceId,omitempty" example:"sources/123"`
+	Page          string             `json:"page,omitempty" example:"42"`
+	Entry         string             `json:"entry,omitempty" example:"Baptism no. 17"`
+	URL           string             `json:"url,omitempty" example:"https://www.digitalarkivet.no/view/255/pg00000000000042"`
+	Transcription string             `json:"transcription,omitempty" example:"Døbt: Ole, søn af Hans Olsen"`
+	Quality       *int               `json:"quality,omitempty" example:"3"`
+	Subjects      *[]CitationSubject `json:"subjects,omitempty"`
+}
+
+// CitationResponse represents the response body for citation operations
+type CitationResponse struct {
+	Citation *Citation `json:"citation,omitempty"`
+	Message  string    `json:"message,omitempty"`
+}
+
+// CitationsListResponse represents the response body for listing citations
+type CitationsListResponse struct {
+	Citations []Citation `json:"citations"`
+	Count     int        `json:"count"`
+}
+
+// SubjectCitationsResponse represents the response body for the citations supporting a person, relationship or event
+type SubjectCitationsResponse struct {
+	SubjectID string              `json:"subjectId" example:"persons/123"`
+	Citations []CitationReference `json:"citations"`
+	Count     int                 `json:"count"`
+}
+
+// Citation quality ratings
+const (
+	CitationQualityUnreliable   = 0
+	CitationQualityQuestionable = 1
+	CitationQualitySecondary    = 2
+	CitationQualityPrimary      = 3
+)
+
+// SetMetadata sets the ArangoDB metadata fields
+func (c *Citation) SetMetadata(key, id, rev string) {
+	c.Key = key
+	c.ID = id
+	c.Rev = rev
+}
+
+// SetTimestamps sets the created and updated timestamps
+func (c *Citation) SetTimestamps(createdAt, updatedAt time.Time) {
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = createdAt
+	}
+	c.UpdatedAt = updatedAt
+}
+
+// GetUpdatedAt returns the updated timestamp
+func (c Citation) GetUpdatedAt() time.Time {
+	return c.UpdatedAt
+}
+
+// SetMetadata sets the ArangoDB metadata fields
+func (l *CitationLink) SetMetadata(key, id, rev string) {
+	l.Key = key
+	l.ID = id
+	l.Rev = rev
+}
+
+// SetTimestamps sets the created and updated timestamps
+func (l *CitationLink) SetTimestamps(createdAt, updatedAt time.Time) {
+	if l.CreatedAt.IsZero() {
+		l.CreatedAt = createdAt
+	}
+	l.UpdatedAt = updatedAt
+}
+
+// GetUpdatedAt returns the updated timestamp
+func (l CitationLink) GetUpdatedAt() time.Time {
+	return l.UpdatedAt
+}
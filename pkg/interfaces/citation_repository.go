@@ -0,0 +1,31 @@
+package interfaces
+
+import "context"
+
+// SourceRepository defines the interface for source data access operations
+type SourceRepository interface {
+	Repository[Source]
+
+	// IsCited reports whether any citation refers to a source
+	IsCited(ctx context.Context, sourceID string) (bool, error)
+}
+
+// CitationRepository defines the interface for citation data access operations
+// It embeds the generic Repository interface and adds citation-specific methods
+type CitationRepository interface {
+	Repository[Citation]
+
+	// FindBySubjects finds the citations supporting the given persons, relationships or events, with their sources
+	FindBySubjects(ctx context.Context, subjectIDs []string) ([]CitationReference, error)
+}
+
+// CitationLinkRepository defines the interface for citation link edge data access operations
+type CitationLinkRepository interface {
+	Repository[CitationLink]
+
+	// FindByCitations finds the citation link edges of the given citations
+	FindByCitations(ctx context.Context, citationIDs []string) ([]CitationLink, error)
+
+	// DeleteByCitation deletes the citation link edges of a citation
+	DeleteByCitation(ctx context.Context, citationID string) error
+}
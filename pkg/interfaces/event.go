@@ -0,0 +1,135 @@
+package interfaces
+
+import "time"
+
+// Event represents a life event, such as a baptism or a burial, that persons take part in
+type Event struct {
+	Key         string           `json:"_key,omitempty"`
+	ID          string           `json:"_id,omitempty"`
+	Rev         string           `json:"_rev,omitempty"`
+	Type        string           `json:"type" binding:"required"`
+	Date        GenealogicalDate `json:"date,omitzero"`
+	PlaceID     string           `json:"placeId,omitempty"`
+	Description string           `json:"description,omitempty"`
+	// Participants are stored as participant edges and filled in when the event is read
+	Participants []EventParticipant `json:"participants,omitempty"`
+	CreatedAt    time.Time          `json:"createdAt"`
+	UpdatedAt    time.Time          `json:"updatedAt"`
+}
+
+// EventParticipant is a person taking part in an event in a role
+type EventParticipant struct {
+	PersonID string `json:"personId" binding:"required" example:"persons/123"`
+	Role     string `json:"role" binding:"required" example:"principal"`
+}
+
+// Participant is the edge from a person to an event they take part in
+type Participant struct {
+	Key       string    `json:"_key,omitempty"`
+	ID        string    `json:"_id,omitempty"`
+	Rev       string    `json:"_rev,omitempty"`
+	From      string    `json:"_from" binding:"required"`
+	To        string    `json:"_to" binding:"required"`
+	Role      string    `json:"role" binding:"required"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// EventCreateRequest represents the request body for creating an event
+type EventCreateRequest struct {
+	Type         string             `json:"type" binding:"required" example:"baptism"`
+	Date         GenealogicalDate   `json:"date,omitzero" swaggertype:"string" example:"ABT 1843"`
+	PlaceID      string             `json:"placeId,omitempty" example:"places/123"`
+	Description  string             `json:"description,omitempty" example:"Baptised by the parish priest"`
+	Participants []EventParticipant `json:"participants,omitempty"`
+}
+
+// EventUpdateRequest represents the request body for updating an event.
+// When participants are given they replace the current participants.
+type EventUpdateRequest struct {
+	Type         string              `json:"type,omitempty" example:"baptism"`
+	Date         GenealogicalDate    `json:"date,omitzero" swaggertype:"string" example:"1843-05-12"`
+	PlaceID      string              `json:"placeId,omitempty" example:"places/123"`
+	Description  string              `json:"description,omitempty" example:"Baptised by the parish priest"`
+	Participants *[]EventParticipant `json:"participants,omitempty"`
+}
+
+// EventResponse represents the response body for event operations
+type EventResponse struct {
+	Event   *Event `json:"event,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// EventsListResponse represents the response body for listing events
+type EventsListResponse struct {
+	Events []Event `json:"events"`
+	Count  int     `json:"count"`
+}
+
+// PersonEventsResponse represents the response body for the events of a person
+type PersonEventsResponse struct {
+	PersonID string  `json:"personId" example:"persons/123"`
+	Events   []Event `json:"events"`
+	Count    int     `json:"count"`
+}
+
+// Event types
+const (
+	EventTypeBaptism      = "baptism"
+	EventTypeConfirmation = "confirmation"
+	EventTypeMarriage     = "marriage"
+	EventTypeDivorce      = "divorce"
+	EventTypeEmigration   = "emigration"
+	EventTypeCensus       = "census"
+	EventTypeResidence    = "residence"
+	EventTypeOccupation   = "occupation"
+	EventTypeBurial       = "burial"
+)
+
+// Participant roles
+const (
+	ParticipantRolePrincipal = "principal"
+	ParticipantRoleWitness   = "witness"
+	ParticipantRoleGodparent = "godparent"
+	ParticipantRoleOfficiant = "officiant"
+)
+
+// SetMetadata sets the ArangoDB metadata fields
+func (e *Event) SetMetadata(key, id, rev string) {
+	e.Key = key
+	e.ID = id
+	e.Rev = rev
+}
+
+// SetTimestamps sets the created and updated timestamps
+func (e *Event) SetTimestamps(createdAt, updatedAt time.Time) {
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = createdAt
+	}
+	e.UpdatedAt = updatedAt
+}
+
+// GetUpdatedAt returns the updated timestamp
+func (e Event) GetUpdatedAt() time.Time {
+	return e.UpdatedAt
+}
+
+// SetMetadata sets the ArangoDB metadata fields
+func (p *Participant) SetMetadata(key, id, rev string) {
+	p.Key = key
+	p.ID = id
+	p.Rev = rev
+}
+
+// SetTimestamps sets the created and updated timestamps
+func (p *Participant) SetTimestamps(createdAt, updatedAt time.Time) {
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = createdAt
+	}
+	p.UpdatedAt = updatedAt
+}
+
+// GetUpdatedAt returns the updated timestamp
+func (p Participant) GetUpdatedAt() time.Time {
+	return p.UpdatedAt
+}
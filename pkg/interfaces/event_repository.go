@@ -0,0 +1,26 @@
+package interfaces
+
+import "context"
+
+// EventRepository defines the interface for event data access operations
+// It embeds the generic Repository interface and adds event-specific methods
+type EventRepository interface {
+	Repository[Event]
+
+	// FindByPerson finds the events a person takes part in, ordered by date
+	FindByPerson(ctx context.Context, personID string) ([]Event, error)
+
+	// MigratePlaces moves the place names of events stored before events referred to places by ID into a place ID
+	MigratePlaces(ctx context.Context, resolve func(ctx context.Context, name string) (string, error)) (int, error)
+}
+
+// ParticipantRepository defines the interface for participant edge data access operations
+type ParticipantRepository interface {
+	Repository[Participant]
+
+	// FindByEvents finds the participant edges of the given events
+	FindByEvents(ctx context.Context, eventIDs []string) ([]Participant, error)
+
+	// DeleteByEvent deletes the participant edges of an event
+	DeleteByEvent(ctx context.Context, eventID string) error
+}
@@ -0,0 +1,50 @@
+package interfaces
+
+import "time"
+
+// Export formats
+const (
+	ExportFormatJSON    = "json"
+	ExportFormatGedcom  = "gedcom"
+	ExportFormatGedcom7 = "gedcom7"
+	ExportFormatGedzip  = "gedzip"
+	ExportFormatGedcomX = "gedcomx"
+)
+
+// Export scopes, the relatives of a person exported with them
+const (
+	ExportScopeAncestors   = "ancestors"
+	ExportScopeDescendants = "descendants"
+	ExportScopeBoth        = "both"
+)
+
+// ExportOptions describes what an export contains and who it is for
+type ExportOptions struct {
+	Format string
+	// PersonID limits the export to a person and their relatives in Scope, up to Depth generations.
+	// When empty, the whole tree is exported.
+	PersonID string
+	Scope    string
+	Depth    int
+	// Numbering is the descendant numbering system the descendants of PersonID are labelled with, if any
+	Numbering string
+	// Privacy is the most restricted privacy level exported; persons more restricted are redacted,
+	// or left out when HideRestricted is set
+	Privacy        string
+	HideRestricted bool
+	// Submitter is the name of the user exporting, written as the submitter of GEDCOM files
+	Submitter string
+}
+
+// ExportData is the JSON export of a tree
+type ExportData struct {
+	Persons       []Person       `json:"persons"`
+	Relationships []Relationship `json:"relationships"`
+	Places        []Place        `json:"places"`
+	// Numbering is the descendant numbering system of DescendantNumbers
+	Numbering string `json:"numbering,omitempty" example:"daboville"`
+	// DescendantNumbers holds the numbers of the descendants by person ID; a descendant reached through
+	// two parents has a number under each of them
+	DescendantNumbers map[string][]string `json:"descendantNumbers,omitempty"`
+	ExportedAt        time.Time           `json:"exportedAt"`
+}
@@ -0,0 +1,39 @@
+package interfaces
+
+// Family is a family unit derived from the relationship edges: up to two partners and the children they share.
+// Partners who share children form a family even when no spouse edge connects them.
+type Family struct {
+	ID       string        `json:"id" example:"123+456"`
+	Partners []Person      `json:"partners"`
+	Union    *Relationship `json:"union,omitempty"`
+	Children []Person      `json:"children"`
+}
+
+// FamilyCreateRequest represents the request body for creating a family
+type FamilyCreateRequest struct {
+	Partners  []string         `json:"partners" binding:"required" example:"persons/123,persons/456"`
+	Children  []string         `json:"children,omitempty" example:"persons/789"`
+	Married   bool             `json:"married" example:"true"`
+	StartDate GenealogicalDate `json:"startDate,omitzero" swaggertype:"string" example:"ABT 2000"`
+	EndDate   GenealogicalDate `json:"endDate,omitzero" swaggertype:"string" example:"2020-12-31"`
+	Notes     string           `json:"notes,omitempty" example:"Married in Oslo"`
+}
+
+// FamilyResponse represents the response body for family operations
+type FamilyResponse struct {
+	Family  *Family `json:"family,omitempty"`
+	Message string  `json:"message,omitempty"`
+}
+
+// FamiliesListResponse represents the response body for listing families
+type FamiliesListResponse struct {
+	Families []Family `json:"families"`
+	Count    int      `json:"count"`
+}
+
+// PersonFamiliesResponse represents the response body for the families of a person
+type PersonFamiliesResponse struct {
+	PersonID  string   `json:"personId" example:"persons/123"`
+	AsPartner []Family `json:"asPartner"`
+	AsChild   []Family `json:"asChild"`
+}
@@ -0,0 +1,382 @@
+package interfaces
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Date qualifiers, following the GEDCOM date modifiers
+const (
+	DateQualifierAbout      = "ABT"
+	DateQualifierBefore     = "BEF"
+	DateQualifierAfter      = "AFT"
+	DateQualifierBetween    = "BET"
+	DateQualifierCalculated = "CAL"
+	DateQualifierEstimated  = "EST"
+)
+
+// Date precisions
+const (
+	DatePrecisionYear  = "year"
+	DatePrecisionMonth = "month"
+	DatePrecisionDay   = "day"
+)
+
+var monthAbbreviations = []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+
+// DatePart is a calendar date where the day, or both the month and the day, may be unknown (zero)
+type DatePart struct {
+	Year  int `json:"year"`
+	Month int `json:"month,omitempty"`
+	Day   int `json:"day,omitempty"`
+}
+
+// GenealogicalDate is a date as genealogists record it: partial ("1843", "1843-05"), qualified ("ABT 1843",
+// "BEF 1790") or a range ("BET 1801 AND 1805"). The zero value is an unknown date.
+type GenealogicalDate struct {
+	Qualifier string
+	Date      DatePart
+	// End is the end of the range for BET..AND dates
+	End DatePart
+}
+
+// genealogicalDateJSON is the stored and transferred form of a genealogical date
+type genealogicalDateJSON struct {
+	Value     string `json:"value"`
+	Qualifier string `json:"qualifier,omitempty"`
+	Precision string `json:"precision"`
+	SortKey   int    `json:"sortKey"`
+}
+
+// ParseGenealogicalDate parses a date such as "1843-05-12", "1843", "ABT 1843", "BEF MAR 1790",
+// "BET 1801 AND 1805" or "12 MAY 1843". RFC 3339 timestamps are accepted as exact dates.
+func ParseGenealogicalDate(s string) (GenealogicalDate, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return GenealogicalDate{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		if t.IsZero() {
+			return GenealogicalDate{}, nil
+		}
+		return GenealogicalDate{Date: DatePart{Year: t.Year(), Month: int(t.Month()), Day: t.Day()}}, nil
+	}
+
+	fields := strings.Fields(strings.ToUpper(s))
+	var d GenealogicalDate
+	switch fields[0] {
+	case DateQualifierAbout, DateQualifierBefore, DateQualifierAfter,
+		DateQualifierCalculated, DateQualifierEstimated, DateQualifierBetween:
+		d.Qualifier = fields[0]
+		fields = fields[1:]
+	}
+
+	if d.Qualifier == DateQualifierBetween {
+		and := -1
+		for i, field := range fields {
+			if field == "AND" {
+				and = i
+				break
+			}
+		}
+		if and == -1 {
+			return GenealogicalDate{}, fmt.Errorf("invalid date %q: BET requires AND", s)
+		}
+
+		start, err := parseDatePart(fields[:and])
+		if err != nil {
+			return GenealogicalDate{}, fmt.Errorf("invalid date %q: %w", s, err)
+		}
+		end, err := parseDatePart(fields[and+1:])
+		if err != nil {
+			return GenealogicalDate{}, fmt.Errorf("invalid date %q: %w", s, err)
+		}
+		if end.latest() < start.earliest() {
+			return GenealogicalDate{}, fmt.Errorf("invalid date %q: range ends before it starts", s)
+		}
+
+		d.Date, d.End = start, end
+		return d, nil
+	}
+
+	date, err := parseDatePart(fields)
+	if err != nil {
+		return GenealogicalDate{}, fmt.Errorf("invalid date %q: %w", s, err)
+	}
+	d.Date = date
+
+	return d, nil
+}
+
+// parseDatePart parses an ISO date ("1843", "1843-05", "1843-05-12") or a GEDCOM date ("1843", "MAY 1843", "12 MAY 1843")
+func parseDatePart(fields []string) (DatePart, error) {
+	var part DatePart
+	var err error
+
+	switch {
+	case len(fields) == 1 && strings.Contains(fields[0], "-"):
+		segments := strings.Split(fields[0], "-")
+		if len(segments) > 3 {
+			return DatePart{}, fmt.Errorf("expected YYYY, YYYY-MM or YYYY-MM-DD")
+		}
+		numbers := make([]int, len(segments))
+		for i, segment := range segments {
+			if numbers[i], err = strconv.Atoi(segment); err != nil {
+				return DatePart{}, fmt.Errorf("expected YYYY, YYYY-MM or YYYY-MM-DD")
+			}
+		}
+		part.Year = numbers[0]
+		if len(numbers) > 1 {
+			part.Month = numbers[1]
+		}
+		if len(numbers) > 2 {
+			part.Day = numbers[2]
+		}
+	case len(fields) >= 1 && len(fields) <= 3:
+		if part.Year, err = strconv.Atoi(fields[len(fields)-1]); err != nil {
+			return DatePart{}, fmt.Errorf("invalid year %q", fields[len(fields)-1])
+		}
+		if len(fields) >= 2 {
+			part.Month = monthNumber(fields[len(fields)-2])
+			if part.Month == 0 {
+				return DatePart{}, fmt.Errorf("invalid month %q", fields[len(fields)-2])
+			}
+		}
+		if len(fields) == 3 {
+			if part.Day, err = strconv.Atoi(fields[0]); err != nil {
+				return DatePart{}, fmt.Errorf("invalid day %q", fields[0])
+			}
+		}
+	default:
+		return DatePart{}, fmt.Errorf("missing date")
+	}
+
+	if err := part.validate(); err != nil {
+		return DatePart{}, err
+	}
+
+	return part, nil
+}
+
+// monthNumber returns the number of a GEDCOM month abbreviation, or 0 when it is not one
+func monthNumber(abbreviation string) int {
+	for i, month := range monthAbbreviations {
+		if month == abbreviation {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// validate checks that the year, month and day form a real (possibly partial) date
+func (p DatePart) validate() error {
+	if p.Year < 1 || p.Year > 9999 {
+		return fmt.Errorf("year must be between 1 and 9999")
+	}
+	if p.Month < 0 || p.Month > 12 {
+		return fmt.Errorf("month must be between 1 and 12")
+	}
+	if p.Day != 0 {
+		if p.Month == 0 {
+			return fmt.Errorf("a day requires a month")
+		}
+		if p.Day < 1 || p.Day > daysIn(p.Year, p.Month) {
+			return fmt.Errorf("day %d does not exist in %04d-%02d", p.Day, p.Year, p.Month)
+		}
+	}
+	return nil
+}
+
+// daysIn returns the number of days in a month
+func daysIn(year, month int) int {
+	return time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// Precision returns how much of the date is known
+func (p DatePart) Precision() string {
+	switch {
+	case p.Day != 0:
+		return DatePrecisionDay
+	case p.Month != 0:
+		return DatePrecisionMonth
+	default:
+		return DatePrecisionYear
+	}
+}
+
+// String returns the ISO form of the date part, e.g. "1843", "1843-05" or "1843-05-12"
+func (p DatePart) String() string {
+	switch p.Precision() {
+	case DatePrecisionDay:
+		return fmt.Sprintf("%04d-%02d-%02d", p.Year, p.Month, p.Day)
+	case DatePrecisionMonth:
+		return fmt.Sprintf("%04d-%02d", p.Year, p.Month)
+	default:
+		return fmt.Sprintf("%04d", p.Year)
+	}
+}
+
+// key returns the date part as a YYYYMMDD number with unknown parts as zero
+func (p DatePart) key() int {
+	return p.Year*10000 + p.Month*100 + p.Day
+}
+
+// earliest returns the first day the date part may refer to as a YYYYMMDD number
+func (p DatePart) earliest() int {
+	month, day := p.Month, p.Day
+	if month == 0 {
+		month = 1
+	}
+	if day == 0 {
+		day = 1
+	}
+	return p.Year*10000 + month*100 + day
+}
+
+// latest returns the last day the date part may refer to as a YYYYMMDD number
+func (p DatePart) latest() int {
+	month, day := p.Month, p.Day
+	if month == 0 {
+		month = 12
+	}
+	if day == 0 {
+		day = daysIn(p.Year, month)
+	}
+	return p.Year*10000 + month*100 + day
+}
+
+// IsZero reports whether the date is unknown
+func (d GenealogicalDate) IsZero() bool {
+	return d.Date.Year == 0
+}
+
+// IsExact reports whether the date is a single unqualified day
+func (d GenealogicalDate) IsExact() bool {
+	return !d.IsZero() && d.Qualifier == "" && d.Date.Precision() == DatePrecisionDay
+}
+
+// Precision returns how much of the date is known
+func (d GenealogicalDate) Precision() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Date.Precision()
+}
+
+// String returns the canonical form of the date, e.g. "1843-05-12", "ABT 1843" or "BET 1801 AND 1805"
+func (d GenealogicalDate) String() string {
+	switch {
+	case d.IsZero():
+		return ""
+	case d.Qualifier == DateQualifierBetween:
+		return fmt.Sprintf("BET %s AND %s", d.Date, d.End)
+	case d.Qualifier != "":
+		return d.Qualifier + " " + d.Date.String()
+	default:
+		return d.Date.String()
+	}
+}
+
+// SortKey returns a number that orders dates chronologically. Unknown dates sort first,
+// BEF dates just before and AFT dates just after the period they name.
+func (d GenealogicalDate) SortKey() int {
+	switch {
+	case d.IsZero():
+		return 0
+	case d.Qualifier == DateQualifierBefore:
+		return d.Date.key() - 1
+	case d.Qualifier == DateQualifierAfter:
+		switch d.Date.Precision() {
+		case DatePrecisionYear:
+			return d.Date.key() + 9999
+		case DatePrecisionMonth:
+			return d.Date.key() + 99
+		default:
+			return d.Date.key() + 1
+		}
+	default:
+		return d.Date.key()
+	}
+}
+
+// Compare orders two dates by their sort keys
+func (d GenealogicalDate) Compare(other GenealogicalDate) int {
+	return d.SortKey() - other.SortKey()
+}
+
+// Bounds returns the earliest and latest days the date may refer to as YYYYMMDD numbers.
+// Approximate dates (ABT, CAL, EST) and open ends (BEF, AFT) are unbounded in that direction.
+func (d GenealogicalDate) Bounds() (earliest, latest int) {
+	switch d.Qualifier {
+	case "":
+		return d.Date.earliest(), d.Date.latest()
+	case DateQualifierBetween:
+		return d.Date.earliest(), d.End.latest()
+	case DateQualifierBefore:
+		return 0, d.Date.earliest()
+	case DateQualifierAfter:
+		return d.Date.latest(), math.MaxInt
+	default:
+		return 0, math.MaxInt
+	}
+}
+
+// DefinitelyBefore reports whether the date certainly falls before another date. Unknown dates are never before anything.
+func (d GenealogicalDate) DefinitelyBefore(other GenealogicalDate) bool {
+	if d.IsZero() || other.IsZero() {
+		return false
+	}
+	_, latest := d.Bounds()
+	earliest, _ := other.Bounds()
+	return latest < earliest
+}
+
+// MarshalJSON encodes the date as its canonical value with the precision and sort key, or null when it is unknown
+func (d GenealogicalDate) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(genealogicalDateJSON{
+		Value:     d.String(),
+		Qualifier: d.Qualifier,
+		Precision: d.Precision(),
+		SortKey:   d.SortKey(),
+	})
+}
+
+// UnmarshalJSON decodes a date from a string in any form ParseGenealogicalDate accepts, including the
+// RFC 3339 timestamps stored before dates became genealogical, or from the object MarshalJSON writes
+func (d *GenealogicalDate) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*d = GenealogicalDate{}
+		return nil
+	}
+
+	var value string
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+	} else {
+		var stored genealogicalDateJSON
+		if err := json.Unmarshal(data, &stored); err != nil {
+			return err
+		}
+		value = stored.Value
+	}
+
+	parsed, err := ParseGenealogicalDate(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package interfaces
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseGenealogicalDate(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		want      string
+		precision string
+		wantErr   bool
+	}{
+		{name: "empty", input: "", want: ""},
+		{name: "year", input: "1843", want: "1843", precision: DatePrecisionYear},
+		{name: "year and month", input: "1843-05", want: "1843-05", precision: DatePrecisionMonth},
+		{name: "full date", input: "1843-05-12", want: "1843-05-12", precision: DatePrecisionDay},
+		{name: "GEDCOM form", input: "12 MAY 1843", want: "1843-05-12", precision: DatePrecisionDay},
+		{name: "GEDCOM month", input: "may 1843", want: "1843-05", precision: DatePrecisionMonth},
+		{name: "about", input: "ABT 1843", want: "ABT 1843", precision: DatePrecisionYear},
+		{name: "before", input: "bef MAR 1790", want: "BEF 1790-03", precision: DatePrecisionMonth},
+		{name: "after", input: "AFT 1790-03-01", want: "AFT 1790-03-01", precision: DatePrecisionDay},
+		{name: "calculated", input: "CAL 1801", want: "CAL 1801", precision: DatePrecisionYear},
+		{name: "estimated", input: "EST 1801", want: "EST 1801", precision: DatePrecisionYear},
+		{name: "between", input: "BET 1801 AND 1805", want: "BET 1801 AND 1805", precision: DatePrecisionYear},
+		{name: "between GEDCOM dates", input: "BET 1 JAN 1801 AND MAR 1801", want: "BET 1801-01-01 AND 1801-03", precision: DatePrecisionDay},
+		{name: "RFC 3339 timestamp", input: "1843-05-12T00:00:00Z", want: "1843-05-12", precision: DatePrecisionDay},
+		{name: "zero timestamp", input: "0001-01-01T00:00:00Z", want: ""},
+		{name: "leap day", input: "1844-02-29", want: "1844-02-29", precision: DatePrecisionDay},
+		{name: "no leap day", input: "1900-02-29", wantErr: true},
+		{name: "month out of range", input: "1843-13", wantErr: true},
+		{name: "year out of range", input: "10000", wantErr: true},
+		{name: "day without month", input: "12 1843", wantErr: true},
+		{name: "unknown month", input: "12 FOO 1843", wantErr: true},
+		{name: "between without and", input: "BET 1801 1805", wantErr: true},
+		{name: "range ending before it starts", input: "BET 1805 AND 1801", wantErr: true},
+		{name: "qualifier only", input: "ABT", wantErr: true},
+		{name: "text", input: "sometime", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseGenealogicalDate(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseGenealogicalDate(%q) = %q, want an error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseGenealogicalDate(%q) returned error: %v", tt.input, err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("ParseGenealogicalDate(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if got.Precision() != tt.precision {
+				t.Errorf("ParseGenealogicalDate(%q).Precision() = %q, want %q", tt.input, got.Precision(), tt.precision)
+			}
+		})
+	}
+}
+
+func TestGenealogicalDateSortKey(t *testing.T) {
+	// Dates in chronological order
+	ordered := []string{"", "BEF 1843", "1843", "1843-05", "BEF 1843-05-12", "1843-05-12", "AFT 1843-05-12", "AFT 1843-05", "AFT 1843", "1844"}
+
+	for i := 1; i < len(ordered); i++ {
+		earlier, err := ParseGenealogicalDate(ordered[i-1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		later, err := ParseGenealogicalDate(ordered[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if earlier.Compare(later) >= 0 {
+			t.Errorf("%q should sort before %q, got sort keys %d and %d", ordered[i-1], ordered[i], earlier.SortKey(), later.SortKey())
+		}
+	}
+}
+
+func TestGenealogicalDateDefinitelyBefore(t *testing.T) {
+	tests := []struct {
+		date  string
+		other string
+		want  bool
+	}{
+		{date: "1843", other: "1844", want: true},
+		{date: "1843", other: "1843-05", want: false},
+		{date: "1843-05-12", other: "1843-05-13", want: true},
+		{date: "BEF 1843", other: "1843-06", want: true},
+		{date: "AFT 1843", other: "1850", want: false},
+		{date: "ABT 1843", other: "1850", want: false},
+		{date: "BET 1801 AND 1805", other: "1806", want: true},
+		{date: "BET 1801 AND 1805", other: "1805", want: false},
+		{date: "", other: "1850", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.date+" before "+tt.other, func(t *testing.T) {
+			date, err := ParseGenealogicalDate(tt.date)
+			if err != nil {
+				t.Fatal(err)
+			}
+			other, err := ParseGenealogicalDate(tt.other)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := date.DefinitelyBefore(other); got != tt.want {
+				t.Errorf("DefinitelyBefore = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenealogicalDateJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "string", input: `"ABT 1843"`, want: "ABT 1843"},
+		{name: "timestamp", input: `"1843-05-12T00:00:00Z"`, want: "1843-05-12"},
+		{name: "stored object", input: `{"value":"BET 1801 AND 1805","qualifier":"BET","precision":"year","sortKey":18010000}`, want: "BET 1801 AND 1805"},
+		{name: "null", input: `null`, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var date GenealogicalDate
+			if err := json.Unmarshal([]byte(tt.input), &date); err != nil {
+				t.Fatalf("Unmarshal(%s) returned error: %v", tt.input, err)
+			}
+			if date.String() != tt.want {
+				t.Fatalf("Unmarshal(%s) = %q, want %q", tt.input, date, tt.want)
+			}
+
+			// The stored form reads back as the same date
+			data, err := json.Marshal(date)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var again GenealogicalDate
+			if err := json.Unmarshal(data, &again); err != nil {
+				t.Fatalf("Unmarshal(%s) returned error: %v", data, err)
+			}
+			if again != date {
+				t.Errorf("round trip through %s = %q, want %q", data, again, date)
+			}
+		})
+	}
+}
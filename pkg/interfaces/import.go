@@ -0,0 +1,61 @@
+package interfaces
+
+// Import formats
+const (
+	ImportFormatGedcom  = "gedcom"
+	ImportFormatGedcom7 = "gedcom7"
+	ImportFormatGedzip  = "gedzip"
+	ImportFormatGedcomX = "gedcomx"
+	ImportFormatCSV     = "csv"
+)
+
+// ImportReport describes what an import created and what it had to leave out
+type ImportReport struct {
+	Format string `json:"format" example:"gedcom"`
+	// Encoding is the character set the file was read in
+	Encoding string       `json:"encoding,omitempty" example:"ANSEL"`
+	Created  ImportCounts `json:"created"`
+	// SkippedTags counts the tags that were not imported by their path, such as INDI.OCCU.AGE
+	SkippedTags map[string]int `json:"skippedTags"`
+	Warnings    []string       `json:"warnings"`
+	// DryRun is set when the file was only validated; Created then counts what importing it would create
+	DryRun bool `json:"dryRun,omitempty"`
+	// RowErrors lists the rows of a spreadsheet that were left out, with why
+	RowErrors []ImportRowError `json:"rowErrors,omitempty"`
+}
+
+// ImportRowError tells why a row of a spreadsheet was left out
+type ImportRowError struct {
+	// Sheet is persons or relationships
+	Sheet string `json:"sheet" example:"persons"`
+	// Row is the row number as a spreadsheet shows it, with the header as row 1
+	Row   int    `json:"row" example:"7"`
+	Error string `json:"error" example:"invalid email format"`
+}
+
+// CSVImportOptions describes how a spreadsheet of persons is read
+type CSVImportOptions struct {
+	// Mapping maps the column headers to person fields, such as "Fornavn" to firstName, or to "" to leave
+	// the column out. Columns named after a field are read without being mapped.
+	Mapping map[string]string `json:"mapping,omitempty"`
+	// DryRun validates every row and reports the errors without writing anything
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// ImportCounts counts the records an import created
+type ImportCounts struct {
+	Persons       int `json:"persons"`
+	Relationships int `json:"relationships"`
+	Events        int `json:"events"`
+	Places        int `json:"places"`
+	Sources       int `json:"sources"`
+	Citations     int `json:"citations"`
+	Notes         int `json:"notes"`
+	Media         int `json:"media"`
+}
+
+// ImportResponse represents the response body for an import
+type ImportResponse struct {
+	Report  *ImportReport `json:"report,omitempty"`
+	Message string        `json:"message,omitempty"`
+}
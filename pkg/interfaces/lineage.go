@@ -0,0 +1,178 @@
+package interfaces
+
+// Ancestor represents an ancestor reached by walking parent edges upward from a person
+type Ancestor struct {
+	Person     Person         `json:"person"`
+	Generation int            `json:"generation" example:"1"`
+	Path       []Relationship `json:"path"`
+}
+
+// Relative is a spouse or descendant of a person, with the number of generations between them (0 for a spouse)
+type Relative struct {
+	Person     Person `json:"person"`
+	Generation int    `json:"generation"`
+}
+
+// AncestorsResponse represents the response body for ancestor traversal
+type AncestorsResponse struct {
+	PersonID  string     `json:"personId" example:"persons/123"`
+	Depth     int        `json:"depth" example:"10"`
+	Ancestors []Ancestor `json:"ancestors"`
+	Count     int        `json:"count"`
+}
+
+// DescendantLink is a parent-to-child step found while walking parent edges downward
+type DescendantLink struct {
+	ParentID     string       `json:"parentId"`
+	Person       Person       `json:"person"`
+	Relationship Relationship `json:"relationship"`
+}
+
+// SpouseLink is a spouse edge attached to a person in a traversal
+type SpouseLink struct {
+	PersonID     string       `json:"personId"`
+	Spouse       Person       `json:"spouse"`
+	Relationship Relationship `json:"relationship"`
+}
+
+// DescendantGraph is the flat result of a descendant traversal
+type DescendantGraph struct {
+	Links   []DescendantLink `json:"links"`
+	Spouses []SpouseLink     `json:"spouses"`
+}
+
+// Spouse represents a spouse of a person together with the edge connecting them
+type Spouse struct {
+	Person       Person       `json:"person"`
+	Relationship Relationship `json:"relationship"`
+}
+
+// DescendantNode represents a person in a nested descendant tree
+type DescendantNode struct {
+	Person     Person           `json:"person"`
+	Generation int              `json:"generation" example:"0"`
+	Spouses    []Spouse         `json:"spouses,omitempty"`
+	Children   []DescendantNode `json:"children,omitempty"`
+}
+
+// DescendantsResponse represents the response body for descendant traversal
+type DescendantsResponse struct {
+	PersonID string          `json:"personId" example:"persons/123"`
+	Depth    int             `json:"depth" example:"10"`
+	Tree     *DescendantNode `json:"tree"`
+	Count    int             `json:"count"`
+}
+
+// Kinship describes how person B is related to person A, read as "B is A's <relationship>"
+type Kinship struct {
+	Related          bool     `json:"related"`
+	Relationship     string   `json:"relationship" example:"second cousin once removed"`
+	GenerationsFromA int      `json:"generationsFromA" example:"3"`
+	GenerationsFromB int      `json:"generationsFromB" example:"4"`
+	CommonAncestors  []Person `json:"commonAncestors,omitempty"`
+	Half             bool     `json:"half"`
+	InLaw            bool     `json:"inLaw"`
+	Via              *Person  `json:"via,omitempty"`
+}
+
+// KinshipResponse represents the response body for the kinship calculator
+type KinshipResponse struct {
+	PersonA string   `json:"personA" example:"persons/123"`
+	PersonB string   `json:"personB" example:"persons/456"`
+	Kinship *Kinship `json:"kinship"`
+}
+
+// RelationshipPath is a chain of persons connected by relationship edges
+type RelationshipPath struct {
+	Persons       []Person       `json:"persons"`
+	Relationships []Relationship `json:"relationships"`
+	Length        int            `json:"length" example:"3"`
+}
+
+// PathsResponse represents the response body for relationship path searches
+type PathsResponse struct {
+	PersonA string             `json:"personA" example:"persons/123"`
+	PersonB string             `json:"personB" example:"persons/456"`
+	Paths   []RelationshipPath `json:"paths"`
+	Count   int                `json:"count"`
+}
+
+// AncestorPaths holds every distinct path from a person up to one of their ancestors.
+// Each path lists the person IDs from the person to the ancestor.
+type AncestorPaths struct {
+	Person Person     `json:"person"`
+	Paths  [][]string `json:"paths"`
+}
+
+// CollapsedAncestor is an ancestor reached through more than one path
+type CollapsedAncestor struct {
+	Person       Person     `json:"person"`
+	Multiplicity int        `json:"multiplicity" example:"2"`
+	Generations  []int      `json:"generations"`
+	Paths        [][]string `json:"paths"`
+}
+
+// CollapseUnion is a couple whose shared ancestry causes pedigree collapse
+type CollapseUnion struct {
+	Partners        []Person `json:"partners"`
+	ChildID         string   `json:"childId" example:"persons/123"`
+	Relationship    string   `json:"relationship" example:"first cousin"`
+	SharedAncestors []string `json:"sharedAncestors"`
+}
+
+// PedigreeCollapseResponse represents the response body for the pedigree collapse report
+type PedigreeCollapseResponse struct {
+	PersonID            string              `json:"personId" example:"persons/123"`
+	Depth               int                 `json:"depth" example:"10"`
+	DuplicatedAncestors []CollapsedAncestor `json:"duplicatedAncestors"`
+	Unions              []CollapseUnion     `json:"unions"`
+}
+
+// AncestorLink is a child-to-parent step found while walking parent edges upward
+type AncestorLink struct {
+	ChildID      string       `json:"childId"`
+	Person       Person       `json:"person"`
+	Relationship Relationship `json:"relationship"`
+}
+
+// AhnentafelEntry is a numbered slot in an Ahnentafel (Sosa-Stradonitz) list.
+// The root is 1, the father of n is 2n and the mother of n is 2n+1.
+type AhnentafelEntry struct {
+	Number     int     `json:"number" example:"2"`
+	Generation int     `json:"generation" example:"2"`
+	Person     *Person `json:"person"`
+	Missing    bool    `json:"missing"`
+	// SameAs is the first number held by the same person when pedigree collapse places them more than once
+	SameAs int `json:"sameAs,omitempty"`
+}
+
+// AhnentafelResponse represents the response body for Ahnentafel numbering
+type AhnentafelResponse struct {
+	PersonID    string            `json:"personId" example:"persons/123"`
+	Generations int               `json:"generations" example:"5"`
+	Entries     []AhnentafelEntry `json:"entries"`
+	Count       int               `json:"count"`
+}
+
+// Descendant numbering systems
+const (
+	NumberingDAboville = "daboville"
+	NumberingHenry     = "henry"
+)
+
+// NumberedDescendant is a descendant labelled with a descendant numbering system
+type NumberedDescendant struct {
+	Number       string `json:"number" example:"1.2.3"`
+	ParentNumber string `json:"parentNumber,omitempty" example:"1.2"`
+	Generation   int    `json:"generation" example:"2"`
+	Person       Person `json:"person"`
+}
+
+// DescendantNumbersResponse represents the response body for descendant numbering
+type DescendantNumbersResponse struct {
+	PersonID    string               `json:"personId" example:"persons/123"`
+	System      string               `json:"system" example:"daboville"`
+	Depth       int                  `json:"depth" example:"10"`
+	Descendants []NumberedDescendant `json:"descendants"`
+	Count       int                  `json:"count"`
+}
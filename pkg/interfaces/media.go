@@ -0,0 +1,106 @@
+package interfaces
+
+import "time"
+
+// Media represents the metadata of a photo, scanned document or audio recording.
+// The content itself is kept in a blob store under StorageKey.
+type Media struct {
+	Key        string           `json:"_key,omitempty"`
+	ID         string           `json:"_id,omitempty"`
+	Rev        string           `json:"_rev,omitempty"`
+	FileName   string           `json:"fileName"`
+	MimeType   string           `json:"mimeType"`
+	Size       int64            `json:"size"`
+	Checksum   string           `json:"checksum"`
+	Width      int              `json:"width,omitempty"`
+	Height     int              `json:"height,omitempty"`
+	Caption    string           `json:"caption,omitempty"`
+	Date       GenealogicalDate `json:"date,omitzero"`
+	StorageKey string           `json:"storageKey"`
+	// Subjects are stored as media link edges and filled in when the media is read
+	Subjects  []string  `json:"subjects,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// MediaLink is the edge from a media file to a person, event or source it is attached to
+type MediaLink struct {
+	Key       string    `json:"_key,omitempty"`
+	ID        string    `json:"_id,omitempty"`
+	Rev       string    `json:"_rev,omitempty"`
+	From      string    `json:"_from" binding:"required"`
+	To        string    `json:"_to" binding:"required"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// MediaUploadRequest holds the form fields sent along with an uploaded file
+type MediaUploadRequest struct {
+	FileName string
+	// MimeType is the type declared by the client, used when the type cannot be detected from the content
+	MimeType string
+	Caption  string
+	Date     GenealogicalDate
+	Subjects []string
+}
+
+// MediaUpdateRequest represents the request body for updating the metadata of a media file.
+// When subjects are given they replace the current subjects.
+type MediaUpdateRequest struct {
+	FileName string           `json:"fileName,omitempty" example:"confirmation-1857.jpg"`
+	Caption  string           `json:"caption,omitempty" example:"Ole Hansen at his confirmation"`
+	Date     GenealogicalDate `json:"date,omitzero" swaggertype:"string" example:"ABT 1857"`
+	Subjects *[]string        `json:"subjects,omitempty"`
+}
+
+// MediaResponse represents the response body for media operations
+type MediaResponse struct {
+	Media   *Media `json:"media,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// MediaListResponse represents the response body for listing media
+type MediaListResponse struct {
+	Media []Media `json:"media"`
+	Count int     `json:"count"`
+}
+
+// SetMetadata sets the ArangoDB metadata fields
+func (m *Media) SetMetadata(key, id, rev string) {
+	m.Key = key
+	m.ID = id
+	m.Rev = rev
+}
+
+// SetTimestamps sets the created and updated timestamps
+func (m *Media) SetTimestamps(createdAt, updatedAt time.Time) {
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = createdAt
+	}
+	m.UpdatedAt = updatedAt
+}
+
+// GetUpdatedAt returns the updated timestamp
+func (m Media) GetUpdatedAt() time.Time {
+	return m.UpdatedAt
+}
+
+// SetMetadata sets the ArangoDB metadata fields
+func (l *MediaLink) SetMetadata(key, id, rev string) {
+	l.Key = key
+	l.ID = id
+	l.Rev = rev
+}
+
+// SetTimestamps sets the created and updated timestamps
+func (l *MediaLink) SetTimestamps(createdAt, updatedAt time.Time) {
+	if l.CreatedAt.IsZero() {
+		l.CreatedAt = createdAt
+	}
+	l.UpdatedAt = updatedAt
+}
+
+// GetUpdatedAt returns the updated timestamp
+func (l MediaLink) GetUpdatedAt() time.Time {
+	return l.UpdatedAt
+}
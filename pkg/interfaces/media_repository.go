@@ -0,0 +1,22 @@
+package interfaces
+
+import "context"
+
+// MediaRepository defines the interface for media metadata access operations
+type MediaRepository interface {
+	Repository[Media]
+
+	// FindBySubject finds the media attached to a person, event or source
+	FindBySubject(ctx context.Context, subjectID string) ([]Media, error)
+}
+
+// MediaLinkRepository defines the interface for media link edge data access operations
+type MediaLinkRepository interface {
+	Repository[MediaLink]
+
+	// FindByMedia finds the media link edges of the given media
+	FindByMedia(ctx context.Context, mediaIDs []string) ([]MediaLink, error)
+
+	// DeleteByMedia deletes the media link edges of a media file
+	DeleteByMedia(ctx context.Context, mediaID string) error
+}
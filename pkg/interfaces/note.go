@@ -0,0 +1,101 @@
+package interfaces
+
+import "time"
+
+// Note represents a Markdown research note attached to a person, relationship or event.
+// A note with a status is a research task, optionally assigned to someone and due by a date.
+type Note struct {
+	Key       string     `json:"_key,omitempty"`
+	ID        string     `json:"_id,omitempty"`
+	Rev       string     `json:"_rev,omitempty"`
+	SubjectID string     `json:"subjectId" binding:"required"`
+	Title     string     `json:"title,omitempty"`
+	Body      string     `json:"body" binding:"required"`
+	Author    NoteAuthor `json:"author"`
+	// Status, Assignee and DueDate are written even when empty, so clearing them on update removes the stored values
+	Status    string           `json:"status"`
+	Assignee  string           `json:"assignee"`
+	DueDate   GenealogicalDate `json:"dueDate"`
+	CreatedAt time.Time        `json:"createdAt"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+}
+
+// NoteAuthor is the user who wrote a note
+type NoteAuthor struct {
+	UserID   string `json:"userId"`
+	Username string `json:"username,omitempty"`
+	Email    string `json:"email,omitempty"`
+}
+
+// Task statuses
+const (
+	TaskStatusOpen       = "open"
+	TaskStatusInProgress = "in_progress"
+	TaskStatusDone       = "done"
+	TaskStatusCancelled  = "cancelled"
+)
+
+// NoteCreateRequest represents the request body for creating a note.
+// A note given an assignee or a due date without a status is an open task.
+type NoteCreateRequest struct {
+	SubjectID string           `json:"subjectId" binding:"required" example:"persons/123"`
+	Title     string           `json:"title,omitempty" example:"Which Ole Hansen emigrated in 1882?"`
+	Body      string           `json:"body" binding:"required" example:"Two candidates in the **1875 census**, check the emigrant protocols."`
+	Status    string           `json:"status,omitempty" example:"open"`
+	Assignee  string           `json:"assignee,omitempty" example:"kari"`
+	DueDate   GenealogicalDate `json:"dueDate,omitzero" swaggertype:"string" example:"2026-12-01"`
+}
+
+// NoteUpdateRequest represents the request body for updating a note.
+// An empty status turns a task back into a plain note; an empty assignee or due date removes it.
+type NoteUpdateRequest struct {
+	SubjectID string            `json:"subjectId,omitempty" example:"persons/123"`
+	Title     string            `json:"title,omitempty" example:"Which Ole Hansen emigrated in 1882?"`
+	Body      string            `json:"body,omitempty" example:"Found him in the emigrant protocol, see the citation."`
+	Status    *string           `json:"status,omitempty" example:"done"`
+	Assignee  *string           `json:"assignee,omitempty" example:"kari"`
+	DueDate   *GenealogicalDate `json:"dueDate,omitempty" swaggertype:"string" example:"2026-12-01"`
+}
+
+// NoteResponse represents the response body for note operations
+type NoteResponse struct {
+	Note    *Note  `json:"note,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// NotesListResponse represents the response body for listing notes
+type NotesListResponse struct {
+	Notes []Note `json:"notes"`
+	Count int    `json:"count"`
+}
+
+// TasksListResponse represents the response body for listing research tasks
+type TasksListResponse struct {
+	Tasks []Note `json:"tasks"`
+	Count int    `json:"count"`
+}
+
+// IsTask reports whether the note is a research task
+func (n Note) IsTask() bool {
+	return n.Status != ""
+}
+
+// SetMetadata sets the ArangoDB metadata fields
+func (n *Note) SetMetadata(key, id, rev string) {
+	n.Key = key
+	n.ID = id
+	n.Rev = rev
+}
+
+// SetTimestamps sets the created and updated timestamps
+func (n *Note) SetTimestamps(createdAt, updatedAt time.Time) {
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = createdAt
+	}
+	n.UpdatedAt = updatedAt
+}
+
+// GetUpdatedAt returns the updated timestamp
+func (n Note) GetUpdatedAt() time.Time {
+	return n.UpdatedAt
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import "context"
+
+// NoteRepository defines the interface for note data access operations
+type NoteRepository interface {
+	Repository[Note]
+
+	// FindBySubject finds the notes attached to a person, relationship or event
+	FindBySubject(ctx context.Context, subjectID string) ([]Note, error)
+
+	// FindTasks finds the research tasks with one of the statuses, optionally only those
+	// assigned to assignee or attached to subjectID
+	FindTasks(ctx context.Context, statuses []string, assignee, subjectID string) ([]Note, error)
+}
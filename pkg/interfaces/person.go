@@ -1,45 +1,103 @@
 package interfaces
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
-// Person represents a person in the family tree
+// Person represents a person in the family tree. FirstName and LastName hold the preferred of its names.
 type Person struct {
-	Key       string    `json:"_key,omitempty"`
-	ID        string    `json:"_id,omitempty"`
-	Rev       string    `json:"_rev,omitempty"`
-	FirstName string    `json:"firstName" binding:"required"`
-	LastName  string    `json:"lastName" binding:"required"`
-	BirthDate time.Time `json:"birthDate,omitempty"`
-	DeathDate time.Time `json:"deathDate,omitempty"`
-	Gender    string    `json:"gender,omitempty"`
-	Email     string    `json:"email,omitempty"`
-	Phone     string    `json:"phone,omitempty"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	Key          string           `json:"_key,omitempty"`
+	ID           string           `json:"_id,omitempty"`
+	Rev          string           `json:"_rev,omitempty"`
+	FirstName    string           `json:"firstName" binding:"required"`
+	LastName     string           `json:"lastName" binding:"required"`
+	Names        []PersonName     `json:"names,omitempty"`
+	BirthDate    GenealogicalDate `json:"birthDate,omitzero"`
+	BirthPlaceID string           `json:"birthPlaceId,omitempty"`
+	DeathDate    GenealogicalDate `json:"deathDate,omitzero"`
+	DeathPlaceID string           `json:"deathPlaceId,omitempty"`
+	Gender       string           `json:"gender,omitempty"`
+	Email        string           `json:"email,omitempty"`
+	Phone        string           `json:"phone,omitempty"`
+	// Privacy overrides who may see the person; when empty, living persons are family and others public
+	Privacy string `json:"privacy"`
+	// Living is filled in with whether the person is probably living when requested
+	Living *bool `json:"living,omitempty"`
+	// Redacted is set on persons whose details are hidden from the viewer
+	Redacted bool `json:"redacted,omitempty"`
+	// Attributes holds the values of custom attribute types by attribute type name
+	Attributes map[string]any `json:"attributes,omitempty"`
+	// Citations are filled in from the citation links when requested
+	Citations []CitationReference `json:"citations,omitempty"`
+	CreatedAt time.Time           `json:"createdAt"`
+	UpdatedAt time.Time           `json:"updatedAt"`
 }
 
-// PersonCreateRequest represents the request body for creating a person
+// PersonName is one of the names a person is known under, optionally limited to the period it was in use
+type PersonName struct {
+	GivenName string           `json:"givenName,omitempty" example:"Ole"`
+	Surname   string           `json:"surname,omitempty" example:"Haugen"`
+	Type      string           `json:"type" binding:"required" example:"farm"`
+	ValidFrom GenealogicalDate `json:"validFrom,omitzero" swaggertype:"string" example:"1850"`
+	ValidTo   GenealogicalDate `json:"validTo,omitzero" swaggertype:"string" example:"1870"`
+	Preferred bool             `json:"preferred,omitempty"`
+}
+
+// Name types
+const (
+	NameTypeBirth      = "birth"
+	NameTypeMarried    = "married"
+	NameTypePatronymic = "patronymic"
+	NameTypeFarm       = "farm"
+	NameTypeAlias      = "alias"
+	NameTypeReligious  = "religious"
+)
+
+// PersonCreateRequest represents the request body for creating a person.
+// When names are given, firstName and lastName are taken from the preferred name;
+// otherwise firstName and lastName are required and become the birth name.
 type PersonCreateRequest struct {
-	FirstName string    `json:"firstName" binding:"required" example:"John"`
-	LastName  string    `json:"lastName" binding:"required" example:"Doe"`
-	BirthDate time.Time `json:"birthDate,omitempty" example:"1980-01-15T00:00:00Z"`
-	DeathDate time.Time `json:"deathDate,omitempty" example:"2050-05-20T00:00:00Z"`
-	Gender    string    `json:"gender,omitempty" example:"male"`
-	Email     string    `json:"email,omitempty" example:"john.doe@example.com"`
-	Phone     string    `json:"phone,omitempty" example:"+1234567890"`
+	FirstName    string           `json:"firstName,omitempty" example:"John"`
+	LastName     string           `json:"lastName,omitempty" example:"Doe"`
+	Names        []PersonName     `json:"names,omitempty"`
+	BirthDate    GenealogicalDate `json:"birthDate,omitzero" swaggertype:"string" example:"1980-01-15"`
+	BirthPlaceID string           `json:"birthPlaceId,omitempty" example:"places/123"`
+	DeathDate    GenealogicalDate `json:"deathDate,omitzero" swaggertype:"string" example:"ABT 2050"`
+	DeathPlaceID string           `json:"deathPlaceId,omitempty" example:"places/456"`
+	Gender       string           `json:"gender,omitempty" example:"male"`
+	Email        string           `json:"email,omitempty" example:"john.doe@example.com"`
+	Phone        string           `json:"phone,omitempty" example:"+1234567890"`
+	Attributes   map[string]any   `json:"attributes,omitempty" swaggertype:"object"`
+	Privacy      string           `json:"privacy,omitempty" example:"family"`
 }
 
-// PersonUpdateRequest represents the request body for updating a person
+// PersonUpdateRequest represents the request body for updating a person.
+// When names are given they replace the current names; otherwise firstName and lastName
+// update the preferred name. Attributes are merged into the current attributes, and an attribute set to null is removed.
+// An empty privacy removes the privacy override.
 type PersonUpdateRequest struct {
-	FirstName string    `json:"firstName,omitempty" example:"John"`
-	LastName  string    `json:"lastName,omitempty" example:"Doe"`
-	BirthDate time.Time `json:"birthDate,omitempty" example:"1980-01-15T00:00:00Z"`
-	DeathDate time.Time `json:"deathDate,omitempty" example:"2050-05-20T00:00:00Z"`
-	Gender    string    `json:"gender,omitempty" example:"male"`
-	Email     string    `json:"email,omitempty" example:"john.doe@example.com"`
-	Phone     string    `json:"phone,omitempty" example:"+1234567890"`
+	FirstName    string           `json:"firstName,omitempty" example:"John"`
+	LastName     string           `json:"lastName,omitempty" example:"Doe"`
+	Names        *[]PersonName    `json:"names,omitempty"`
+	BirthDate    GenealogicalDate `json:"birthDate,omitzero" swaggertype:"string" example:"1980-01-15"`
+	BirthPlaceID string           `json:"birthPlaceId,omitempty" example:"places/123"`
+	DeathDate    GenealogicalDate `json:"deathDate,omitzero" swaggertype:"string" example:"ABT 2050"`
+	DeathPlaceID string           `json:"deathPlaceId,omitempty" example:"places/456"`
+	Gender       string           `json:"gender,omitempty" example:"male"`
+	Email        string           `json:"email,omitempty" example:"john.doe@example.com"`
+	Phone        string           `json:"phone,omitempty" example:"+1234567890"`
+	Attributes   map[string]any   `json:"attributes,omitempty" swaggertype:"object"`
+	Privacy      *string          `json:"privacy,omitempty" example:"family"`
 }
 
+// Privacy levels, from the least to the most restricted
+const (
+	PrivacyPublic  = "public"
+	PrivacyFamily  = "family"
+	PrivacyPrivate = "private"
+)
+
 // PersonResponse represents the response body for person operations
 type PersonResponse struct {
 	Person  *Person `json:"person,omitempty"`
@@ -71,3 +129,8 @@ func (p *Person) SetTimestamps(createdAt, updatedAt time.Time) {
 func (p Person) GetUpdatedAt() time.Time {
 	return p.UpdatedAt
 }
+
+// DisplayName returns the preferred name of the person as "first last"
+func (p Person) DisplayName() string {
+	return strings.TrimSpace(p.FirstName + " " + p.LastName)
+}
@@ -7,6 +7,9 @@ import "context"
 type PersonRepository interface {
 	Repository[Person]
 
-	// FindByName finds persons by first name and/or last name
-	FindByName(ctx context.Context, firstName, lastName string) ([]Person, error)
+	// Search finds persons with a name matching the given name and/or surname and with all the given attribute values
+	Search(ctx context.Context, firstName, lastName string, attributes []AttributeFilter) ([]Person, error)
+
+	// FindByIDs finds the persons with the given document IDs
+	FindByIDs(ctx context.Context, ids []string) ([]Person, error)
 }
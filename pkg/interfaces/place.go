@@ -0,0 +1,108 @@
+package interfaces
+
+import "time"
+
+// Place represents a place in the gazetteer, such as a farm or a parish, within its parent place
+type Place struct {
+	Key            string      `json:"_key,omitempty"`
+	ID             string      `json:"_id,omitempty"`
+	Rev            string      `json:"_rev,omitempty"`
+	Name           string      `json:"name" binding:"required"`
+	Type           string      `json:"type" binding:"required"`
+	ParentID       string      `json:"parentId,omitempty"`
+	AlternateNames []PlaceName `json:"alternateNames,omitempty"`
+	Latitude       *float64    `json:"latitude,omitempty"`
+	Longitude      *float64    `json:"longitude,omitempty"`
+	CreatedAt      time.Time   `json:"createdAt"`
+	UpdatedAt      time.Time   `json:"updatedAt"`
+}
+
+// PlaceName is an alternate or historical name of a place, optionally limited to the period it was in use
+type PlaceName struct {
+	Name      string           `json:"name" binding:"required" example:"Christiania"`
+	Language  string           `json:"language,omitempty" example:"da"`
+	ValidFrom GenealogicalDate `json:"validFrom,omitzero" swaggertype:"string" example:"1624"`
+	ValidTo   GenealogicalDate `json:"validTo,omitzero" swaggertype:"string" example:"1924"`
+}
+
+// PlaceCreateRequest represents the request body for creating a place
+type PlaceCreateRequest struct {
+	Name           string      `json:"name" binding:"required" example:"Oslo"`
+	Type           string      `json:"type" binding:"required" example:"municipality"`
+	ParentID       string      `json:"parentId,omitempty" example:"places/123"`
+	AlternateNames []PlaceName `json:"alternateNames,omitempty"`
+	Latitude       *float64    `json:"latitude,omitempty" example:"59.9139"`
+	Longitude      *float64    `json:"longitude,omitempty" example:"10.7522"`
+}
+
+// PlaceUpdateRequest represents the request body for updating a place.
+// When alternate names are given they replace the current alternate names.
+type PlaceUpdateRequest struct {
+	Name           string       `json:"name,omitempty" example:"Oslo"`
+	Type           string       `json:"type,omitempty" example:"municipality"`
+	ParentID       string       `json:"parentId,omitempty" example:"places/123"`
+	AlternateNames *[]PlaceName `json:"alternateNames,omitempty"`
+	Latitude       *float64     `json:"latitude,omitempty" example:"59.9139"`
+	Longitude      *float64     `json:"longitude,omitempty" example:"10.7522"`
+}
+
+// PlaceResponse represents the response body for place operations
+type PlaceResponse struct {
+	Place *Place `json:"place,omitempty"`
+	// Hierarchy lists the parent places from the closest parent up to the country
+	Hierarchy []Place `json:"hierarchy,omitempty"`
+	Message   string  `json:"message,omitempty"`
+}
+
+// PlacesListResponse represents the response body for listing places
+type PlacesListResponse struct {
+	Places []Place `json:"places"`
+	Count  int     `json:"count"`
+}
+
+// Place types
+const (
+	PlaceTypeFarm         = "farm"
+	PlaceTypeParish       = "parish"
+	PlaceTypeMunicipality = "municipality"
+	PlaceTypeCounty       = "county"
+	PlaceTypeCountry      = "country"
+)
+
+// NameAt returns the name the place had at a date: the first alternate name whose validity range
+// contains the date, or the current name when none does or the date is unknown
+func (p Place) NameAt(date GenealogicalDate) string {
+	if date.IsZero() {
+		return p.Name
+	}
+	for _, name := range p.AlternateNames {
+		if name.ValidFrom.IsZero() && name.ValidTo.IsZero() {
+			continue
+		}
+		if date.DefinitelyBefore(name.ValidFrom) || name.ValidTo.DefinitelyBefore(date) {
+			continue
+		}
+		return name.Name
+	}
+	return p.Name
+}
+
+// SetMetadata sets the ArangoDB metadata fields
+func (p *Place) SetMetadata(key, id, rev string) {
+	p.Key = key
+	p.ID = id
+	p.Rev = rev
+}
+
+// SetTimestamps sets the created and updated timestamps
+func (p *Place) SetTimestamps(createdAt, updatedAt time.Time) {
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = createdAt
+	}
+	p.UpdatedAt = updatedAt
+}
+
+// GetUpdatedAt returns the updated timestamp
+func (p Place) GetUpdatedAt() time.Time {
+	return p.UpdatedAt
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import "context"
+
+// PlaceRepository defines the interface for place data access operations
+// It embeds the generic Repository interface and adds place-specific methods
+type PlaceRepository interface {
+	Repository[Place]
+
+	// FindByName finds places whose name or one of whose alternate names contains the given text, ignoring case
+	FindByName(ctx context.Context, name string) ([]Place, error)
+
+	// FindChildren finds the places directly within a parent place
+	FindChildren(ctx context.Context, parentID string) ([]Place, error)
+
+	// IsReferenced reports whether a place is the parent of another place or is referred to by a person or an event
+	IsReferenced(ctx context.Context, placeID string) (bool, error)
+}
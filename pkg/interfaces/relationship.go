@@ -5,37 +5,40 @@ import "time"
 // Relationship represents a relationship between two persons in the family tree
 // This is an edge document in ArangoDB
 type Relationship struct {
-	Key          string    `json:"_key,omitempty"`
-	ID           string    `json:"_id,omitempty"`
-	Rev          string    `json:"_rev,omitempty"`
-	From         string    `json:"_from" binding:"required"`
-	To           string    `json:"_to" binding:"required"`
-	RelationType string    `json:"relationType" binding:"required"`
-	StartDate    time.Time `json:"startDate,omitempty"`
-	EndDate      time.Time `json:"endDate,omitempty"`
-	Notes        string    `json:"notes,omitempty"`
-	CreatedAt    time.Time `json:"createdAt"`
-	UpdatedAt    time.Time `json:"updatedAt"`
+	Key          string           `json:"_key,omitempty"`
+	ID           string           `json:"_id,omitempty"`
+	Rev          string           `json:"_rev,omitempty"`
+	From         string           `json:"_from" binding:"required"`
+	To           string           `json:"_to" binding:"required"`
+	RelationType string           `json:"relationType" binding:"required"`
+	Qualifier    string           `json:"qualifier,omitempty"`
+	StartDate    GenealogicalDate `json:"startDate,omitzero"`
+	EndDate      GenealogicalDate `json:"endDate,omitzero"`
+	Notes        string           `json:"notes,omitempty"`
+	CreatedAt    time.Time        `json:"createdAt"`
+	UpdatedAt    time.Time        `json:"updatedAt"`
 }
 
 // RelationshipCreateRequest represents the request body for creating a relationship
 type RelationshipCreateRequest struct {
-	From         string    `json:"from" binding:"required" example:"persons/123"`
-	To           string    `json:"to" binding:"required" example:"persons/456"`
-	RelationType string    `json:"relationType" binding:"required" example:"parent"`
-	StartDate    time.Time `json:"startDate,omitempty" example:"2000-01-01T00:00:00Z"`
-	EndDate      time.Time `json:"endDate,omitempty" example:"2020-12-31T00:00:00Z"`
-	Notes        string    `json:"notes,omitempty" example:"Biological parent"`
+	From         string           `json:"from" binding:"required" example:"persons/123"`
+	To           string           `json:"to" binding:"required" example:"persons/456"`
+	RelationType string           `json:"relationType" binding:"required" example:"parent"`
+	Qualifier    string           `json:"qualifier,omitempty" example:"adoptive"`
+	StartDate    GenealogicalDate `json:"startDate,omitzero" swaggertype:"string" example:"2000-01-01"`
+	EndDate      GenealogicalDate `json:"endDate,omitzero" swaggertype:"string" example:"BET 2019 AND 2020"`
+	Notes        string           `json:"notes,omitempty" example:"Adopted after the death of his parents"`
 }
 
 // RelationshipUpdateRequest represents the request body for updating a relationship
 type RelationshipUpdateRequest struct {
-	From         string    `json:"from,omitempty" example:"persons/123"`
-	To           string    `json:"to,omitempty" example:"persons/456"`
-	RelationType string    `json:"relationType,omitempty" example:"parent"`
-	StartDate    time.Time `json:"startDate,omitempty" example:"2000-01-01T00:00:00Z"`
-	EndDate      time.Time `json:"endDate,omitempty" example:"2020-12-31T00:00:00Z"`
-	Notes        string    `json:"notes,omitempty" example:"Biological parent"`
+	From         string           `json:"from,omitempty" example:"persons/123"`
+	To           string           `json:"to,omitempty" example:"persons/456"`
+	RelationType string           `json:"relationType,omitempty" example:"parent"`
+	Qualifier    string           `json:"qualifier,omitempty" example:"adoptive"`
+	StartDate    GenealogicalDate `json:"startDate,omitzero" swaggertype:"string" example:"2000-01-01"`
+	EndDate      GenealogicalDate `json:"endDate,omitzero" swaggertype:"string" example:"BET 2019 AND 2020"`
+	Notes        string           `json:"notes,omitempty" example:"Adopted after the death of his parents"`
 }
 
 // RelationshipResponse represents the response body for relationship operations
@@ -58,6 +61,43 @@ const (
 	RelationTypeSibling = "sibling"
 )
 
+// Qualifiers of parent and child relationships, telling how the parent came to be one.
+// A parent relationship without a qualifier is taken as biological.
+const (
+	RelationQualifierBiological = "biological"
+	RelationQualifierAdoptive   = "adoptive"
+	RelationQualifierStep       = "step"
+	RelationQualifierFoster     = "foster"
+	RelationQualifierGuardian   = "guardian"
+)
+
+// Qualifiers of spouse relationships, telling what kind of partnership it is
+const (
+	RelationQualifierMarried      = "married"
+	RelationQualifierCivilUnion   = "civil_union"
+	RelationQualifierCohabitation = "cohabitation"
+	RelationQualifierEngaged      = "engaged"
+	RelationQualifierDivorced     = "divorced"
+)
+
+// ParentQualifier returns the qualifier of a parent or child relationship, biological when none is recorded
+func (r Relationship) ParentQualifier() string {
+	if r.Qualifier == "" {
+		return RelationQualifierBiological
+	}
+	return r.Qualifier
+}
+
+// AsParentEdge returns a parent or child relationship as a parent relationship from the parent to the child.
+// Child relationships point from the child to the parent, so they are turned around.
+func (r Relationship) AsParentEdge() Relationship {
+	if r.RelationType == RelationTypeChild {
+		r.From, r.To = r.To, r.From
+		r.RelationType = RelationTypeParent
+	}
+	return r
+}
+
 // SetMetadata sets the ArangoDB metadata fields
 func (r *Relationship) SetMetadata(key, id, rev string) {
 	r.Key = key
@@ -12,4 +12,37 @@ type RelationshipRepository interface {
 
 	// FindByType finds relationships by type
 	FindByType(ctx context.Context, relationType string) ([]Relationship, error)
+
+	// FindFamilyEdges finds the parent, child and spouse edges of a person, their parents and their children
+	FindFamilyEdges(ctx context.Context, personID string) ([]Relationship, error)
+
+	// FindAncestors walks parent edges upward from a person, up to maxDepth generations.
+	// When qualifiers are given, only parent edges with one of them are followed.
+	FindAncestors(ctx context.Context, personID string, maxDepth int, qualifiers []string) ([]Ancestor, error)
+
+	// FindAncestorLinks finds every distinct child-to-parent step above a person, up to maxDepth generations
+	FindAncestorLinks(ctx context.Context, personID string, maxDepth int, qualifiers []string) ([]AncestorLink, error)
+
+	// FindDuplicatedAncestors finds the ancestors of a person reached through more than one path,
+	// up to maxDepth generations, with every distinct path to them. At most maxPaths paths are followed,
+	// and complete is false when there are more.
+	FindDuplicatedAncestors(ctx context.Context, personID string, maxDepth, maxPaths int, qualifiers []string) ([]AncestorPaths, bool, error)
+
+	// FindDescendants walks parent edges downward from a person, up to maxDepth generations,
+	// optionally collecting the spouses of everyone reached
+	FindDescendants(ctx context.Context, personID string, maxDepth int, includeSpouses bool, qualifiers []string) (*DescendantGraph, error)
+
+	// FindPaths finds up to k shortest paths between two persons over any relationship edge
+	FindPaths(ctx context.Context, fromID, toID string, k int) ([]RelationshipPath, error)
+
+	// FindAncestorPath finds the shortest chain of parent edges from a person up to one of their ancestors,
+	// leaving out the relationship with excludeID. The path is empty when there is no such ancestor.
+	FindAncestorPath(ctx context.Context, personID, ancestorID string, maxDepth int, excludeID string) ([]Relationship, error)
+
+	// FindSpousesAndDescendants finds the spouses and the descendants, up to maxDepth generations, of each of the persons,
+	// keyed by person ID. Only the dates of the relatives are read.
+	FindSpousesAndDescendants(ctx context.Context, personIDs []string, maxDepth int) (map[string][]Relative, error)
+
+	// MigrateAdoptions qualifies parent and child edges whose notes mention an adoption as adoptive, once per database
+	MigrateAdoptions(ctx context.Context) (int, error)
 }
@@ -0,0 +1,79 @@
+package interfaces
+
+import "time"
+
+// Source represents where information comes from, such as a church book or a census
+type Source struct {
+	Key        string    `json:"_key,omitempty"`
+	ID         string    `json:"_id,omitempty"`
+	Rev        string    `json:"_rev,omitempty"`
+	Title      string    `json:"title" binding:"required"`
+	Type       string    `json:"type" binding:"required"`
+	Author     string    `json:"author,omitempty"`
+	Repository string    `json:"repository,omitempty"`
+	URL        string    `json:"url,omitempty"`
+	Notes      string    `json:"notes,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// SourceCreateRequest represents the request body for creating a source
+type SourceCreateRequest struct {
+	Title      string `json:"title" binding:"required" example:"Ministerialbok for Aker prestegjeld 1836-1847"`
+	Type       string `json:"type" binding:"required" example:"church_book"`
+	Author     string `json:"author,omitempty" example:"Aker parish"`
+	Repository string `json:"repository,omitempty" example:"Arkivverket"`
+	URL        string `json:"url,omitempty" example:"https://www.digitalarkivet.no/"`
+	Notes      string `json:"notes,omitempty" example:"Baptisms and marriages"`
+}
+
+// SourceUpdateRequest represents the request body for updating a source
+type SourceUpdateRequest struct {
+	Title      string `json:"title,omitempty" example:"Ministerialbok for Aker prestegjeld 1836-1847"`
+	Type       string `json:"type,omitempty" example:"church_book"`
+	Author     string `json:"author,omitempty" example:"Aker parish"`
+	Repository string `json:"repository,omitempty" example:"Arkivverket"`
+	URL        string `json:"url,omitempty" example:"https://www.digitalarkivet.no/"`
+	Notes      string `json:"notes,omitempty" example:"Baptisms and marriages"`
+}
+
+// SourceResponse represents the response body for source operations
+type SourceResponse struct {
+	Source  *Source `json:"source,omitempty"`
+	Message string  `json:"message,omitempty"`
+}
+
+// SourcesListResponse represents the response body for listing sources
+type SourcesListResponse struct {
+	Sources []Source `json:"sources"`
+	Count   int      `json:"count"`
+}
+
+// Source types
+const (
+	SourceTypeChurchBook = "church_book"
+	SourceTypeCensus     = "census"
+	SourceTypeGravestone = "gravestone"
+	SourceTypeInterview  = "interview"
+	SourceTypeOther      = "other"
+)
+
+// SetMetadata sets the ArangoDB metadata fields
+func (s *Source) SetMetadata(key, id, rev string) {
+	s.Key = key
+	s.ID = id
+	s.Rev = rev
+}
+
+// SetTimestamps sets the created and updated timestamps
+func (s *Source) SetTimestamps(createdAt, updatedAt time.Time) {
+	if s.CreatedAt.IsZero() {
+		s.CreatedAt = createdAt
+	}
+	s.UpdatedAt = updatedAt
+}
+
+// GetUpdatedAt returns the updated timestamp
+func (s Source) GetUpdatedAt() time.Time {
+	return s.UpdatedAt
+}
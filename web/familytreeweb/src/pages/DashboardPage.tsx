@@ -10,6 +10,7 @@ import {
 } from '@radix-ui/react-icons';
 import * as api from '../services/api';
 import * as adminApi from '../services/admin-api';
+import { formatYear } from '../utils/personFormatting';
 
 export default function DashboardPage() {
   const [persons, setPersons] = useState<api.Person[]>([]);
@@ -254,7 +255,7 @@ export default function DashboardPage() {
                             </Text>
                           </Flex>
                           <Badge color="blue">
-                            {person.birthDate ? new Date(person.birthDate).getFullYear() : 'N/A'}
+                            {person.birthDate ? formatYear(person.birthDate) : 'N/A'}
                           </Badge>
                         </Flex>
                       </Box>
@@ -95,7 +95,7 @@ export default function ExportPage() {
 
   const formatDescriptions = {
     json: 'JSON format - Standard structured data format for easy parsing and import',
-    gedcom: 'GEDCOM format - Standard genealogy data format (future support)',
+    gedcom: 'GEDCOM format - GEDCOM 5.5.1 file for other genealogy programs',
     csv: 'CSV format - Comma-separated values for spreadsheet applications (future support)',
   };
 
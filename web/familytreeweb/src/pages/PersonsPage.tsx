@@ -20,6 +20,7 @@ import {
   ReloadIcon,
 } from '@radix-ui/react-icons';
 import * as api from '../services/api';
+import { formatDate } from '../utils/personFormatting';
 
 export default function PersonsPage() {
   const [persons, setPersons] = useState<api.Person[]>([]);
@@ -171,9 +172,7 @@ export default function PersonsPage() {
                       </Table.Cell>
                       <Table.Cell>
                         <Text size="2" color="gray">
-                          {person.birthDate
-                            ? new Date(person.birthDate).toLocaleDateString()
-                            : 'N/A'}
+                          {formatDate(person.birthDate)}
                         </Text>
                       </Table.Cell>
                       <Table.Cell>
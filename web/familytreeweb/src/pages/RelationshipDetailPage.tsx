@@ -13,6 +13,7 @@ import {
 } from '@radix-ui/themes';
 import { ArrowLeftIcon, TrashIcon, Pencil1Icon } from '@radix-ui/react-icons';
 import * as api from '../services/api';
+import { formatDate } from '../utils/personFormatting';
 
 export default function RelationshipDetailPage() {
   const { id } = useParams<{ id: string }>();
@@ -132,7 +133,7 @@ export default function RelationshipDetailPage() {
                 <Text size="2" weight="bold" color="gray">
                   Start Date
                 </Text>
-                <Text size="3">{new Date(relationship.startDate).toLocaleDateString()}</Text>
+                <Text size="3">{formatDate(relationship.startDate)}</Text>
               </Flex>
             </>
           )}
@@ -142,7 +143,7 @@ export default function RelationshipDetailPage() {
               <Text size="2" weight="bold" color="gray">
                 End Date
               </Text>
-              <Text size="3">{new Date(relationship.endDate).toLocaleDateString()}</Text>
+              <Text size="3">{formatDate(relationship.endDate)}</Text>
             </Flex>
           )}
 
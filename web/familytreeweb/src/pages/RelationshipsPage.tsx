@@ -14,6 +14,7 @@ import {
 } from '@radix-ui/themes';
 import { PlusIcon, MagnifyingGlassIcon, ReloadIcon } from '@radix-ui/react-icons';
 import * as api from '../services/api';
+import { formatDate } from '../utils/personFormatting';
 
 export default function RelationshipsPage() {
   const [relationships, setRelationships] = useState<api.Relationship[]>([]);
@@ -170,9 +171,7 @@ export default function RelationshipsPage() {
                       </Table.Cell>
                       <Table.Cell>
                         <Text size="2" color="gray">
-                          {relationship.startDate
-                            ? new Date(relationship.startDate).toLocaleDateString()
-                            : 'N/A'}
+                          {formatDate(relationship.startDate)}
                         </Text>
                       </Table.Cell>
                       <Table.Cell>
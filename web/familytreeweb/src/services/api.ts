@@ -2,15 +2,45 @@ import { getValidAccessToken } from './auth';
 
 const API_BASE_URL = import.meta.env.VITE_API_URL || 'http://localhost:15000';
 
+/**
+ * A genealogical date: exact ("1843-05-12"), partial ("1843", "1843-05"),
+ * qualified ("ABT 1843", "BEF 1790") or a range ("BET 1801 AND 1805")
+ */
+export interface GenealogicalDate {
+  value: string;
+  qualifier?: 'ABT' | 'BEF' | 'AFT' | 'BET' | 'CAL' | 'EST';
+  precision: 'year' | 'month' | 'day';
+  sortKey: number;
+}
+
+/**
+ * One of the names a person is known under
+ */
+export interface PersonName {
+  givenName?: string;
+  surname?: string;
+  type: 'birth' | 'married' | 'patronymic' | 'farm' | 'alias' | 'religious';
+  validFrom?: GenealogicalDate;
+  validTo?: GenealogicalDate;
+  preferred?: boolean;
+}
+
 export interface Person {
   id?: string;
   firstName: string;
   lastName: string;
-  birthDate?: string;
-  deathDate?: string;
+  names?: PersonName[];
+  birthDate?: GenealogicalDate;
+  birthPlaceId?: string;
+  deathDate?: GenealogicalDate;
+  deathPlaceId?: string;
   gender?: string;
   email?: string;
   phone?: string;
+  privacy?: string; // public, family, private; empty follows the living status
+  living?: boolean;
+  redacted?: boolean;
+  attributes?: Record<string, unknown>;
   createdAt?: string;
   updatedAt?: string;
 }
@@ -18,10 +48,11 @@ export interface Person {
 export interface Relationship {
   id?: string;
   type: string; // parent, child, spouse, sibling, etc.
+  qualifier?: string; // biological, adoptive, step, foster, guardian; married, civil_union, cohabitation, engaged, divorced
   fromPersonId: string;
   toPersonId: string;
-  startDate?: string;
-  endDate?: string;
+  startDate?: GenealogicalDate;
+  endDate?: GenealogicalDate;
   notes?: string;
   createdAt?: string;
   updatedAt?: string;
@@ -1,4 +1,4 @@
-import type { Person, Relationship } from '../services/api';
+import type { GenealogicalDate, Person, Relationship } from '../services/api';
 
 /**
  * Format a person's full name for display
@@ -10,13 +10,28 @@ export function formatPersonName(person: Person): string {
   return `${person.firstName || ''} ${person.lastName || ''}`.trim();
 }
 
-export function formatDate(date: string | undefined): string {
+/**
+ * Format a genealogical date for display. Exact dates use the locale format,
+ * partial and qualified dates are shown as recorded, e.g. "ABT 1843"
+ */
+export function formatDate(date: GenealogicalDate | undefined): string {
   if (!date) return 'N/A';
-  try {
-    return new Date(date).toLocaleDateString();
-  } catch {
-    return date;
+  if (date.precision === 'day' && !date.qualifier) {
+    return new Date(date.value).toLocaleDateString();
+  }
+  return date.value;
+}
+
+/**
+ * Format the year of a genealogical date for display, e.g. "1843", "ABT 1843" or "1801-1805"
+ */
+export function formatYear(date: GenealogicalDate | undefined): string {
+  if (!date) return '';
+  const years = date.value.match(/\d{4}/g) ?? [];
+  if (date.qualifier === 'BET') {
+    return years.join('-');
   }
+  return date.qualifier ? `${date.qualifier} ${years[0]}` : years[0];
 }
 
 /**
@@ -24,15 +39,15 @@ export function formatDate(date: string | undefined): string {
  */
 export function formatBirthYear(person: Person): string {
   if (!person.birthDate) return 'Unknown';
-  return new Date(person.birthDate).getFullYear().toString();
+  return formatYear(person.birthDate);
 }
 
 /**
  * Format a person's lifespan for display
  */
 export function formatLifespan(person: Person): string {
-  const birthYear = person.birthDate ? new Date(person.birthDate).getFullYear() : '?';
-  const deathYear = person.deathDate ? new Date(person.deathDate).getFullYear() : '';
+  const birthYear = person.birthDate ? formatYear(person.birthDate) : '?';
+  const deathYear = formatYear(person.deathDate);
 
   if (deathYear) {
     return `${birthYear} - ${deathYear}`;
@@ -47,10 +62,10 @@ export function getPersonDetails(person: Person): string[] {
   const details: string[] = [];
 
   if (person.birthDate) {
-    details.push(`Born: ${new Date(person.birthDate).toLocaleDateString()}`);
+    details.push(`Born: ${formatDate(person.birthDate)}`);
   }
   if (person.deathDate) {
-    details.push(`Died: ${new Date(person.deathDate).toLocaleDateString()}`);
+    details.push(`Died: ${formatDate(person.deathDate)}`);
   }
   if (person.gender) {
     details.push(`Gender: ${person.gender}`);
@@ -81,10 +96,10 @@ export function getRelationshipDetails(relationship: Relationship): string[] {
   details.push(`Type: ${relationship.type}`);
 
   if (relationship.startDate) {
-    details.push(`Started: ${new Date(relationship.startDate).toLocaleDateString()}`);
+    details.push(`Started: ${formatDate(relationship.startDate)}`);
   }
   if (relationship.endDate) {
-    details.push(`Ended: ${new Date(relationship.endDate).toLocaleDateString()}`);
+    details.push(`Ended: ${formatDate(relationship.endDate)}`);
   }
   if (relationship.notes) {
     details.push(`Notes: ${relationship.notes}`);